@@ -4,6 +4,7 @@ package attestation
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
@@ -29,7 +30,8 @@ type Service struct {
 	incomingChan  chan *pb.Attestation
 	// store is the mapping of individual
 	// validator's public key to it's latest attestation.
-	store map[[48]byte]*pb.Attestation
+	store     map[[48]byte]*pb.Attestation
+	storeLock sync.RWMutex
 }
 
 // Config options for the service.
@@ -99,6 +101,8 @@ func (a *Service) LatestAttestation(index int) (*pb.Attestation, error) {
 	}
 	pubKey := bytesutil.ToBytes48(state.ValidatorRegistry[index].Pubkey)
 
+	a.storeLock.RLock()
+	defer a.storeLock.RUnlock()
 	// return error if validator has no attestation.
 	if _, exists := a.store[pubKey]; !exists {
 		return nil, fmt.Errorf("validator index %d does not have an attestation", index)
@@ -107,6 +111,20 @@ func (a *Service) LatestAttestation(index int) (*pb.Attestation, error) {
 	return a.store[pubKey], nil
 }
 
+// LatestAttestations returns the latest attestation of every validator in the given
+// registry which has attested, keyed by validator index.
+func (a *Service) LatestAttestations(validators []*pb.Validator) map[uint64]*pb.Attestation {
+	a.storeLock.RLock()
+	defer a.storeLock.RUnlock()
+	attestations := make(map[uint64]*pb.Attestation)
+	for i, validator := range validators {
+		if attestation, exists := a.store[bytesutil.ToBytes48(validator.Pubkey)]; exists {
+			attestations[uint64(i)] = attestation
+		}
+	}
+	return attestations
+}
+
 // LatestAttestationTarget returns the target block the validator index attested to,
 // the highest slotNumber attestation in attestation pool gets returned.
 //
@@ -172,6 +190,8 @@ func (a *Service) updateLatestAttestation(attestation *pb.Attestation) error {
 	bitfield := attestation.AggregationBitfield
 	totalBits := len(bitfield) * 8
 
+	a.storeLock.Lock()
+	defer a.storeLock.Unlock()
 	// Check each bit of participation bitfield to find out which
 	// attester has submitted new attestation.
 	// This is has O(n) run time and could be optimized down the line.
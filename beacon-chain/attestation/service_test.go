@@ -200,3 +200,24 @@ func TestLatestAttestationTarget_ReturnsLatestAttestedBlock(t *testing.T) {
 		t.Errorf("Wanted: %v, got: %v", block, latestAttestedBlock)
 	}
 }
+
+func TestLatestAttestations_ReturnsAttestationsByIndex(t *testing.T) {
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	service := NewAttestationService(context.Background(), &Config{BeaconDB: beaconDB})
+
+	attestation := &pb.Attestation{
+		Data: &pb.AttestationData{
+			Slot: 5,
+		}}
+	service.store[bytesutil.ToBytes48([]byte{'B'})] = attestation
+
+	validators := []*pb.Validator{{Pubkey: []byte{'A'}}, {Pubkey: []byte{'B'}}}
+	attestations := service.LatestAttestations(validators)
+	if len(attestations) != 1 {
+		t.Fatalf("Expected 1 attestation, received %d", len(attestations))
+	}
+	if !reflect.DeepEqual(attestations[1], attestation) {
+		t.Errorf("Wanted: %v, got: %v", attestation, attestations[1])
+	}
+}
@@ -0,0 +1,258 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// nonExistentNode is used as the index of a missing parent,
+// best child or best descendant of a node in the block tree.
+const nonExistentNode = -1
+
+// treeNode is a block in the fork-choice block tree. Nodes reference their parent,
+// best child and best descendant by their index in the tree's node list.
+type treeNode struct {
+	root           [32]byte
+	slot           uint64
+	parent         int
+	weight         uint64
+	bestChild      int
+	bestDescendant int
+}
+
+// latestVote is the most recent block a validator attested to, along with the
+// block and balance which are currently accounted for in the tree weights. A vote
+// for a block which is not part of the tree yet stays pending until the block is added.
+type latestVote struct {
+	slot           uint64
+	nextRoot       [32]byte
+	currentRoot    [32]byte
+	currentBalance uint64
+	applied        bool
+}
+
+// forkChoiceStore is an in-memory block tree which keeps the accumulated vote weight of
+// every block. The nodes are stored in insertion order, which guarantees that a parent
+// is always located before its children. Blocks and votes are added incrementally and
+// the head is found by applying the pending weight changes in a single backwards pass
+// over the nodes, which makes a head query linear in the number of blocks in the tree.
+type forkChoiceStore struct {
+	lock          sync.RWMutex
+	nodes         []*treeNode
+	indices       map[[32]byte]int
+	votes         map[uint64]*latestVote
+	finalizedRoot [32]byte
+}
+
+// newForkChoiceStore creates an empty block tree.
+func newForkChoiceStore() *forkChoiceStore {
+	return &forkChoiceStore{
+		indices: make(map[[32]byte]int),
+		votes:   make(map[uint64]*latestVote),
+	}
+}
+
+// hasBlock returns true if the block root is part of the tree.
+func (s *forkChoiceStore) hasBlock(root [32]byte) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.indices[root]
+	return ok
+}
+
+// size returns the number of blocks in the tree.
+func (s *forkChoiceStore) size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.nodes)
+}
+
+// insertBlock adds a block to the tree. A block whose parent is not part of the
+// tree is added without a parent, this is the case for the first justified block.
+func (s *forkChoiceStore) insertBlock(root [32]byte, parentRoot [32]byte, slot uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.indices[root]; ok {
+		return
+	}
+
+	parent := nonExistentNode
+	if idx, ok := s.indices[parentRoot]; ok {
+		parent = idx
+	}
+	index := len(s.nodes)
+	s.indices[root] = index
+	s.nodes = append(s.nodes, &treeNode{
+		root:           root,
+		slot:           slot,
+		parent:         parent,
+		bestChild:      nonExistentNode,
+		bestDescendant: nonExistentNode,
+	})
+	if parent != nonExistentNode {
+		s.updateBestChildAndDescendant(parent, index)
+	}
+}
+
+// processAttestation records the latest vote of a validator. Votes which are
+// not newer than the validator's current vote are ignored.
+func (s *forkChoiceStore) processAttestation(validatorIndex uint64, root [32]byte, slot uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	vote, ok := s.votes[validatorIndex]
+	if !ok {
+		s.votes[validatorIndex] = &latestVote{slot: slot, nextRoot: root}
+		return
+	}
+	if slot > vote.slot {
+		vote.slot = slot
+		vote.nextRoot = root
+	}
+}
+
+// head applies the votes received since the last head query, weighted by the given
+// validator balances, and returns the root of the best descendant of the justified block.
+//
+// Spec pseudocode definition:
+//    head = start_block
+//    while 1:
+//        children = get_children(store, head)
+//        if len(children) == 0:
+//            return head
+//        head = max(children, key=get_vote_count)
+func (s *forkChoiceStore) head(justifiedRoot [32]byte, balances []uint64) ([32]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	justifiedIndex, ok := s.indices[justifiedRoot]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("justified block %#x is not in the block tree", justifiedRoot)
+	}
+
+	s.applyWeightChanges(s.computeDeltas(balances))
+
+	justified := s.nodes[justifiedIndex]
+	if justified.bestDescendant == nonExistentNode {
+		return justified.root, nil
+	}
+	return s.nodes[justified.bestDescendant].root, nil
+}
+
+// computeDeltas returns the weight change of every node caused by the votes and
+// balances which changed since the last time the weights were updated. Votes for
+// blocks which are not part of the tree are left pending, and the weight of their
+// previous block is kept until the block they vote for is added.
+func (s *forkChoiceStore) computeDeltas(balances []uint64) []int64 {
+	deltas := make([]int64, len(s.nodes))
+	for validatorIndex, vote := range s.votes {
+		var newBalance uint64
+		if validatorIndex < uint64(len(balances)) {
+			newBalance = balances[validatorIndex]
+		}
+		if vote.applied && vote.currentRoot == vote.nextRoot && vote.currentBalance == newBalance {
+			continue
+		}
+		nextIndex, ok := s.indices[vote.nextRoot]
+		if !ok {
+			continue
+		}
+		if vote.applied {
+			if idx, ok := s.indices[vote.currentRoot]; ok {
+				deltas[idx] -= int64(vote.currentBalance)
+			}
+		}
+		deltas[nextIndex] += int64(newBalance)
+		vote.currentRoot = vote.nextRoot
+		vote.currentBalance = newBalance
+		vote.applied = true
+	}
+	return deltas
+}
+
+// applyWeightChanges walks the nodes backwards, adding each node's delta to its weight
+// and propagating it to its parent. Since children are always located after their parent,
+// every node's weight and best descendant is final before its parent is evaluated.
+func (s *forkChoiceStore) applyWeightChanges(deltas []int64) {
+	for i := len(s.nodes) - 1; i >= 0; i-- {
+		node := s.nodes[i]
+		node.weight = uint64(int64(node.weight) + deltas[i])
+		if node.parent != nonExistentNode {
+			deltas[node.parent] += deltas[i]
+		}
+	}
+	for i := len(s.nodes) - 1; i >= 0; i-- {
+		if parent := s.nodes[i].parent; parent != nonExistentNode {
+			s.updateBestChildAndDescendant(parent, i)
+		}
+	}
+}
+
+// updateBestChildAndDescendant compares a child with the current best child of its parent
+// and updates the parent's best child and best descendant accordingly. Ties in weight are
+// broken by favoring the higher block root.
+func (s *forkChoiceStore) updateBestChildAndDescendant(parentIndex int, childIndex int) {
+	parent := s.nodes[parentIndex]
+	child := s.nodes[childIndex]
+	if parent.bestChild != childIndex && parent.bestChild != nonExistentNode {
+		best := s.nodes[parent.bestChild]
+		if child.weight < best.weight {
+			return
+		}
+		if child.weight == best.weight && bytes.Compare(child.root[:], best.root[:]) < 0 {
+			return
+		}
+	}
+	parent.bestChild = childIndex
+	if child.bestDescendant == nonExistentNode {
+		parent.bestDescendant = childIndex
+	} else {
+		parent.bestDescendant = child.bestDescendant
+	}
+}
+
+// prune removes every block which does not descend from the finalized block,
+// including the ancestors of the finalized block itself.
+func (s *forkChoiceStore) prune(finalizedRoot [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if finalizedRoot == s.finalizedRoot {
+		return nil
+	}
+	finalizedIndex, ok := s.indices[finalizedRoot]
+	if !ok {
+		return fmt.Errorf("finalized block %#x is not in the block tree", finalizedRoot)
+	}
+
+	// Maps the index of every kept node to its new index.
+	newIndices := map[int]int{finalizedIndex: 0}
+	nodes := []*treeNode{s.nodes[finalizedIndex]}
+	for i := finalizedIndex + 1; i < len(s.nodes); i++ {
+		newParent, ok := newIndices[s.nodes[i].parent]
+		if !ok {
+			continue
+		}
+		newIndices[i] = len(nodes)
+		s.nodes[i].parent = newParent
+		nodes = append(nodes, s.nodes[i])
+	}
+
+	indices := make(map[[32]byte]int, len(nodes))
+	for i, node := range nodes {
+		indices[node.root] = i
+		node.bestChild = remapIndex(newIndices, node.bestChild)
+		node.bestDescendant = remapIndex(newIndices, node.bestDescendant)
+	}
+	nodes[0].parent = nonExistentNode
+	s.nodes = nodes
+	s.indices = indices
+	s.finalizedRoot = finalizedRoot
+	return nil
+}
+
+func remapIndex(newIndices map[int]int, index int) int {
+	if newIndex, ok := newIndices[index]; ok {
+		return newIndex
+	}
+	return nonExistentNode
+}
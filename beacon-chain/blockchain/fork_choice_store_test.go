@@ -0,0 +1,255 @@
+package blockchain
+
+import (
+	"strings"
+	"testing"
+)
+
+func rootFromByte(b byte) [32]byte {
+	return [32]byte{b}
+}
+
+// setupForkChoiceTree builds the following block tree:
+//
+//         / - B - D
+//  A(0) -
+//         \ - C - E
+//
+func setupForkChoiceTree() *forkChoiceStore {
+	store := newForkChoiceStore()
+	store.insertBlock(rootFromByte('A'), [32]byte{}, 0)
+	store.insertBlock(rootFromByte('B'), rootFromByte('A'), 1)
+	store.insertBlock(rootFromByte('C'), rootFromByte('A'), 1)
+	store.insertBlock(rootFromByte('D'), rootFromByte('B'), 2)
+	store.insertBlock(rootFromByte('E'), rootFromByte('C'), 2)
+	return store
+}
+
+func TestForkChoiceStore_HeadOfSingleChain(t *testing.T) {
+	store := newForkChoiceStore()
+	store.insertBlock(rootFromByte('A'), [32]byte{}, 0)
+	store.insertBlock(rootFromByte('B'), rootFromByte('A'), 1)
+	store.insertBlock(rootFromByte('C'), rootFromByte('B'), 2)
+
+	head, err := store.head(rootFromByte('A'), nil)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('C') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('C'), head)
+	}
+}
+
+func TestForkChoiceStore_HeaviestBranchWins(t *testing.T) {
+	store := setupForkChoiceTree()
+	balances := []uint64{10, 10, 10}
+	store.processAttestation(0, rootFromByte('D'), 2)
+	store.processAttestation(1, rootFromByte('E'), 2)
+	store.processAttestation(2, rootFromByte('C'), 1)
+
+	head, err := store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+}
+
+func TestForkChoiceStore_VoteChangeMovesHead(t *testing.T) {
+	store := setupForkChoiceTree()
+	balances := []uint64{10, 10}
+	store.processAttestation(0, rootFromByte('E'), 2)
+
+	head, err := store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+
+	// Validator 0 switches to the other branch, and validator 1 joins it.
+	store.processAttestation(0, rootFromByte('D'), 3)
+	store.processAttestation(1, rootFromByte('B'), 3)
+	head, err = store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('D') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('D'), head)
+	}
+	if weight := store.nodes[store.indices[rootFromByte('C')]].weight; weight != 0 {
+		t.Errorf("Expected the abandoned branch to have no weight, received %d", weight)
+	}
+	if weight := store.nodes[store.indices[rootFromByte('A')]].weight; weight != 20 {
+		t.Errorf("Expected the root to accumulate every vote, received %d", weight)
+	}
+}
+
+func TestForkChoiceStore_IgnoresOlderVotes(t *testing.T) {
+	store := setupForkChoiceTree()
+	balances := []uint64{10}
+	store.processAttestation(0, rootFromByte('E'), 5)
+	store.processAttestation(0, rootFromByte('D'), 4)
+
+	head, err := store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+}
+
+func TestForkChoiceStore_VoteForUnknownBlockStaysPending(t *testing.T) {
+	store := setupForkChoiceTree()
+	balances := []uint64{10}
+	store.processAttestation(0, rootFromByte('E'), 2)
+	if _, err := store.head(rootFromByte('A'), balances); err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+
+	// The block voted for has not been received, so the vote keeps counting for E.
+	store.processAttestation(0, rootFromByte('F'), 3)
+	head, err := store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+
+	store.insertBlock(rootFromByte('F'), rootFromByte('D'), 3)
+	head, err = store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('F') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('F'), head)
+	}
+
+	// Moving the vote away from the late block removes exactly the weight it received.
+	store.processAttestation(0, rootFromByte('E'), 4)
+	head, err = store.head(rootFromByte('A'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+	for _, root := range [][32]byte{rootFromByte('B'), rootFromByte('D'), rootFromByte('F')} {
+		if weight := store.nodes[store.indices[root]].weight; weight != 0 {
+			t.Errorf("Expected block %#x to have no weight, received %d", root, weight)
+		}
+	}
+	if weight := store.nodes[store.indices[rootFromByte('A')]].weight; weight != 10 {
+		t.Errorf("Expected the root to have weight 10, received %d", weight)
+	}
+}
+
+func TestForkChoiceStore_BalanceChangeMovesHead(t *testing.T) {
+	store := setupForkChoiceTree()
+	store.processAttestation(0, rootFromByte('D'), 2)
+	store.processAttestation(1, rootFromByte('E'), 2)
+
+	head, err := store.head(rootFromByte('A'), []uint64{20, 10})
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('D') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('D'), head)
+	}
+
+	head, err = store.head(rootFromByte('A'), []uint64{0, 10})
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+}
+
+func TestForkChoiceStore_TieBreaksOnHigherRoot(t *testing.T) {
+	store := setupForkChoiceTree()
+
+	head, err := store.head(rootFromByte('A'), nil)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('E') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('E'), head)
+	}
+}
+
+func TestForkChoiceStore_StartsFromJustifiedBlock(t *testing.T) {
+	store := setupForkChoiceTree()
+	store.processAttestation(0, rootFromByte('E'), 2)
+
+	head, err := store.head(rootFromByte('B'), []uint64{10})
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('D') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('D'), head)
+	}
+}
+
+func TestForkChoiceStore_UnknownJustifiedBlock(t *testing.T) {
+	store := setupForkChoiceTree()
+
+	want := "is not in the block tree"
+	if _, err := store.head(rootFromByte('Z'), nil); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %s, received %v", want, err)
+	}
+}
+
+func TestForkChoiceStore_PruneRemovesConflictingBlocks(t *testing.T) {
+	store := setupForkChoiceTree()
+	store.insertBlock(rootFromByte('F'), rootFromByte('D'), 3)
+	store.processAttestation(0, rootFromByte('F'), 3)
+	store.processAttestation(1, rootFromByte('E'), 2)
+	balances := []uint64{10, 10}
+	if _, err := store.head(rootFromByte('A'), balances); err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+
+	if err := store.prune(rootFromByte('B')); err != nil {
+		t.Fatalf("Could not prune block tree: %v", err)
+	}
+	if store.size() != 3 {
+		t.Errorf("Expected 3 blocks after pruning, received %d", store.size())
+	}
+	for _, root := range [][32]byte{rootFromByte('A'), rootFromByte('C'), rootFromByte('E')} {
+		if store.hasBlock(root) {
+			t.Errorf("Expected block %#x to be pruned", root)
+		}
+	}
+
+	head, err := store.head(rootFromByte('B'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('F') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('F'), head)
+	}
+
+	// Blocks added after pruning are linked to the remaining blocks.
+	store.insertBlock(rootFromByte('G'), rootFromByte('F'), 4)
+	head, err = store.head(rootFromByte('B'), balances)
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootFromByte('G') {
+		t.Errorf("Expected head %#x, received %#x", rootFromByte('G'), head)
+	}
+}
+
+func TestForkChoiceStore_PruneUnknownBlock(t *testing.T) {
+	store := setupForkChoiceTree()
+
+	want := "is not in the block tree"
+	if err := store.prune(rootFromByte('Z')); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %s, received %v", want, err)
+	}
+}
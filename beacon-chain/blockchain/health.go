@@ -0,0 +1,189 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+)
+
+var healthCheckFailing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "blockchain_health_check_failing",
+	Help: "Whether a health check of the chain service is failing, by reason",
+}, []string{"reason"})
+
+// The reasons the chain service can be unhealthy for.
+const (
+	headSlotLagReason     = "head_slot_lag"
+	finalityStallReason   = "finality_stall"
+	fullBlockBufferReason = "full_block_buffer"
+	blockFailuresReason   = "block_failures"
+)
+
+// HealthConfig defines the thresholds of the chain service health checks.
+// A zero threshold disables the corresponding check.
+type HealthConfig struct {
+	// MaxHeadSlotLag is the number of slots the chain head can fall behind the current slot.
+	MaxHeadSlotLag uint64
+	// MaxFinalityEpochLag is the number of epochs the finalized epoch can fall behind the current epoch.
+	MaxFinalityEpochLag uint64
+	// MaxFullBlockBufferDuration is how long the incoming block buffer can stay full.
+	MaxFullBlockBufferDuration time.Duration
+	// MaxConsecutiveBlockFailures is the number of consecutive blocks which can fail processing.
+	MaxConsecutiveBlockFailures int
+}
+
+// healthTracker keeps track of the block processing outcomes the health checks are based on.
+type healthTracker struct {
+	lock                 sync.Mutex
+	consecutiveFailures  int
+	blockBufferFullSince time.Time
+}
+
+func (h *healthTracker) recordBlockFailure() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.consecutiveFailures++
+}
+
+func (h *healthTracker) recordBlockSuccess() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.consecutiveFailures = 0
+}
+
+// recordBlockBuffer records whether the incoming block buffer is full, and since when.
+func (h *healthTracker) recordBlockBuffer(full bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if !full {
+		h.blockBufferFullSince = time.Time{}
+		return
+	}
+	if h.blockBufferFullSince.IsZero() {
+		h.blockBufferFullSince = time.Now()
+	}
+}
+
+// Status runs the health checks of the chain service, and returns an error describing
+// every failing check. The result of each check is also exported as a metric.
+func (c *ChainService) Status() error {
+	checks := []struct {
+		reason string
+		check  func() error
+	}{
+		{reason: headSlotLagReason, check: c.checkHeadSlotLag},
+		{reason: finalityStallReason, check: c.checkFinalityStall},
+		{reason: fullBlockBufferReason, check: c.checkBlockBuffer},
+		{reason: blockFailuresReason, check: c.checkBlockFailures},
+	}
+
+	var failures []string
+	for _, check := range checks {
+		if err := check.check(); err != nil {
+			healthCheckFailing.WithLabelValues(check.reason).Set(1)
+			failures = append(failures, fmt.Sprintf("%s: %v", check.reason, err))
+			continue
+		}
+		healthCheckFailing.WithLabelValues(check.reason).Set(0)
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// checkHeadSlotLag fails if the slot of the chain head falls too far behind the current
+// slot, which happens when the node stops receiving or processing blocks.
+func (c *ChainService) checkHeadSlotLag() error {
+	genesisTime := c.genesisTimestamp()
+	if c.healthConfig.MaxHeadSlotLag == 0 || genesisTime.IsZero() {
+		return nil
+	}
+	head, err := c.beaconDB.ChainHead()
+	if err != nil {
+		return fmt.Errorf("could not retrieve chain head: %v", err)
+	}
+	if head == nil {
+		return nil
+	}
+	currentSlot := slotutil.CurrentSlot(genesisTime, params.BeaconConfig().SecondsPerSlot, time.Since)
+	if currentSlot > head.Slot+c.healthConfig.MaxHeadSlotLag {
+		return fmt.Errorf("head slot %d is %d slots behind the current slot %d",
+			head.Slot-params.BeaconConfig().GenesisSlot,
+			currentSlot-head.Slot,
+			currentSlot-params.BeaconConfig().GenesisSlot,
+		)
+	}
+	return nil
+}
+
+// checkFinalityStall fails if the finalized epoch of the canonical state falls too far
+// behind the current epoch.
+func (c *ChainService) checkFinalityStall() error {
+	genesisTime := c.genesisTimestamp()
+	if c.healthConfig.MaxFinalityEpochLag == 0 || genesisTime.IsZero() {
+		return nil
+	}
+	beaconState, err := c.beaconDB.State()
+	if err != nil {
+		return fmt.Errorf("could not retrieve beacon state: %v", err)
+	}
+	if beaconState == nil {
+		return nil
+	}
+	currentSlot := slotutil.CurrentSlot(genesisTime, params.BeaconConfig().SecondsPerSlot, time.Since)
+	currentEpoch := helpers.SlotToEpoch(currentSlot)
+	if currentEpoch > beaconState.FinalizedEpoch+c.healthConfig.MaxFinalityEpochLag {
+		return fmt.Errorf("finalized epoch %d is %d epochs behind the current epoch %d",
+			beaconState.FinalizedEpoch-params.BeaconConfig().GenesisEpoch,
+			currentEpoch-beaconState.FinalizedEpoch,
+			currentEpoch-params.BeaconConfig().GenesisEpoch,
+		)
+	}
+	return nil
+}
+
+// checkBlockBuffer fails if the incoming block buffer stays full for too long, which
+// means blocks arrive faster than they are processed. The buffer is sampled by the block
+// processing loop, see sampleBlockBuffer.
+func (c *ChainService) checkBlockBuffer() error {
+	c.health.lock.Lock()
+	defer c.health.lock.Unlock()
+	if c.healthConfig.MaxFullBlockBufferDuration == 0 || c.health.blockBufferFullSince.IsZero() {
+		return nil
+	}
+	if fullFor := time.Since(c.health.blockBufferFullSince); fullFor > c.healthConfig.MaxFullBlockBufferDuration {
+		return fmt.Errorf("incoming block buffer of size %d has been full for %v",
+			cap(c.incomingBlockChan), fullFor.Round(time.Second))
+	}
+	return nil
+}
+
+// sampleBlockBuffer records whether the incoming block buffer is full. The buffer is filled
+// by the incoming block feed on the goroutines of the senders, so it is sampled by the block
+// processing loop after every block it processes and at every slot.
+func (c *ChainService) sampleBlockBuffer() {
+	size := cap(c.incomingBlockChan)
+	c.health.recordBlockBuffer(size > 0 && len(c.incomingBlockChan) == size)
+}
+
+// checkBlockFailures fails if the most recent blocks all failed processing.
+func (c *ChainService) checkBlockFailures() error {
+	c.health.lock.Lock()
+	defer c.health.lock.Unlock()
+	if c.healthConfig.MaxConsecutiveBlockFailures == 0 {
+		return nil
+	}
+	if c.health.consecutiveFailures >= c.healthConfig.MaxConsecutiveBlockFailures {
+		return fmt.Errorf("the last %d blocks failed processing", c.health.consecutiveFailures)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package blockchain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestStatus_Healthy(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.healthConfig = HealthConfig{
+		MaxHeadSlotLag:              10,
+		MaxFinalityEpochLag:         4,
+		MaxFullBlockBufferDuration:  time.Minute,
+		MaxConsecutiveBlockFailures: 3,
+	}
+
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy, received %v", err)
+	}
+}
+
+func TestStatus_HeadSlotLag(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.healthConfig = HealthConfig{MaxHeadSlotLag: 10}
+
+	_, genesis := setupGenesisBlock(t, chainService, nil)
+	if err := db.UpdateChainHead(genesis, &pb.BeaconState{}); err != nil {
+		t.Fatal(err)
+	}
+	chainService.setGenesisTime(time.Now().Add(-5 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second))
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy, received %v", err)
+	}
+
+	chainService.setGenesisTime(time.Now().Add(-20 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second))
+	if err := chainService.Status(); err == nil || !strings.Contains(err.Error(), headSlotLagReason) {
+		t.Errorf("Expected error to contain %s, received %v", headSlotLagReason, err)
+	}
+}
+
+func TestStatus_FinalityStall(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.healthConfig = HealthConfig{MaxFinalityEpochLag: 4}
+
+	if err := db.SaveState(&pb.BeaconState{FinalizedEpoch: params.BeaconConfig().GenesisEpoch}); err != nil {
+		t.Fatal(err)
+	}
+	epochDuration := time.Duration(params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second
+	chainService.setGenesisTime(time.Now().Add(-2 * epochDuration))
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy, received %v", err)
+	}
+
+	chainService.setGenesisTime(time.Now().Add(-10 * epochDuration))
+	if err := chainService.Status(); err == nil || !strings.Contains(err.Error(), finalityStallReason) {
+		t.Errorf("Expected error to contain %s, received %v", finalityStallReason, err)
+	}
+}
+
+func TestStatus_FullBlockBuffer(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.healthConfig = HealthConfig{MaxFullBlockBufferDuration: 10 * time.Millisecond}
+	chainService.incomingBlockChan = make(chan *pb.BeaconBlock, 1)
+
+	chainService.incomingBlockChan <- &pb.BeaconBlock{}
+	chainService.sampleBlockBuffer()
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy, received %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := chainService.Status(); err == nil || !strings.Contains(err.Error(), fullBlockBufferReason) {
+		t.Errorf("Expected error to contain %s, received %v", fullBlockBufferReason, err)
+	}
+
+	<-chainService.incomingBlockChan
+	chainService.sampleBlockBuffer()
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy once the buffer drains, received %v", err)
+	}
+}
+
+func TestStatus_ConsecutiveBlockFailures(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.healthConfig = HealthConfig{MaxConsecutiveBlockFailures: 3}
+
+	for i := 0; i < 3; i++ {
+		chainService.health.recordBlockFailure()
+	}
+	if err := chainService.Status(); err == nil || !strings.Contains(err.Error(), blockFailuresReason) {
+		t.Errorf("Expected error to contain %s, received %v", blockFailuresReason, err)
+	}
+
+	chainService.health.recordBlockSuccess()
+	if err := chainService.Status(); err != nil {
+		t.Errorf("Expected chain service to be healthy after a processed block, received %v", err)
+	}
+}
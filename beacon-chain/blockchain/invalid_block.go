@@ -0,0 +1,20 @@
+package blockchain
+
+// InvalidBlockEvent is sent to the invalid block feed whenever a received block fails
+// validation, so that the peer which relayed the block can be penalized.
+type InvalidBlockEvent struct {
+	BlockRoot [32]byte
+	// BadSignature is set if the block failed because of an invalid signature.
+	BadSignature bool
+}
+
+// invalidBlockError is returned by ReceiveBlock when the block itself is invalid, as
+// opposed to a failure to process it.
+type invalidBlockError struct {
+	err          error
+	badSignature bool
+}
+
+func (e *invalidBlockError) Error() string {
+	return e.err.Error()
+}
@@ -0,0 +1,166 @@
+package blockchain
+
+import (
+	"sort"
+	"sync"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// maxPendingBlocks is the number of blocks the pending block pool holds
+// before it starts evicting the blocks with the highest slots.
+const maxPendingBlocks = 1024
+
+// pendingBlocks is a bounded pool of blocks which can not be processed yet, either
+// because their parent has not been processed or because their slot has not started.
+// Orphan blocks are indexed by their parent root so they can be retrieved as soon
+// as the parent lands, while future blocks are retrieved once their slot begins.
+type pendingBlocks struct {
+	lock     sync.Mutex
+	capacity int
+	blocks   map[[32]byte]*pb.BeaconBlock
+	orphans  map[[32]byte][][32]byte
+	future   map[[32]byte]bool
+}
+
+// newPendingBlocks creates an empty pool which holds at most capacity blocks.
+func newPendingBlocks(capacity int) *pendingBlocks {
+	return &pendingBlocks{
+		capacity: capacity,
+		blocks:   make(map[[32]byte]*pb.BeaconBlock),
+		orphans:  make(map[[32]byte][][32]byte),
+		future:   make(map[[32]byte]bool),
+	}
+}
+
+// size returns the number of blocks in the pool.
+func (p *pendingBlocks) size() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.blocks)
+}
+
+// has returns true if the block root is held in the pool.
+func (p *pendingBlocks) has(root [32]byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.blocks[root]
+	return ok
+}
+
+// addOrphan holds a block until its parent is processed. It returns
+// false if the block was not added because the pool is full.
+func (p *pendingBlocks) addOrphan(root [32]byte, block *pb.BeaconBlock) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.blocks[root]; ok {
+		return true
+	}
+	if !p.makeRoom(block.Slot) {
+		return false
+	}
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+	p.blocks[root] = block
+	p.orphans[parentRoot] = append(p.orphans[parentRoot], root)
+	return true
+}
+
+// addFuture holds a block until its slot begins. It returns
+// false if the block was not added because the pool is full.
+func (p *pendingBlocks) addFuture(root [32]byte, block *pb.BeaconBlock) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.blocks[root]; ok {
+		return true
+	}
+	if !p.makeRoom(block.Slot) {
+		return false
+	}
+	p.blocks[root] = block
+	p.future[root] = true
+	return true
+}
+
+// children removes and returns the blocks waiting for the given parent, sorted by slot.
+func (p *pendingBlocks) children(parentRoot [32]byte) []*pb.BeaconBlock {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	roots := p.orphans[parentRoot]
+	blocks := make([]*pb.BeaconBlock, 0, len(roots))
+	for _, root := range roots {
+		blocks = append(blocks, p.blocks[root])
+		delete(p.blocks, root)
+	}
+	delete(p.orphans, parentRoot)
+	sortBySlot(blocks)
+	return blocks
+}
+
+// ready removes and returns the future blocks whose slot
+// is not after the given slot, sorted by slot.
+func (p *pendingBlocks) ready(slot uint64) []*pb.BeaconBlock {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var blocks []*pb.BeaconBlock
+	for root := range p.future {
+		block := p.blocks[root]
+		if block.Slot > slot {
+			continue
+		}
+		blocks = append(blocks, block)
+		delete(p.blocks, root)
+		delete(p.future, root)
+	}
+	sortBySlot(blocks)
+	return blocks
+}
+
+// makeRoom ensures there is space in the pool for a block at the given slot by evicting
+// the block with the highest slot, as blocks closest to the chain head are the most likely
+// to become processable. It returns false if the incoming block would be the one evicted.
+func (p *pendingBlocks) makeRoom(slot uint64) bool {
+	if len(p.blocks) < p.capacity {
+		return true
+	}
+	var evictRoot [32]byte
+	var evict *pb.BeaconBlock
+	for root, block := range p.blocks {
+		if evict == nil || block.Slot > evict.Slot {
+			evictRoot, evict = root, block
+		}
+	}
+	if evict == nil || evict.Slot <= slot {
+		return false
+	}
+	p.remove(evictRoot, evict)
+	return true
+}
+
+// remove deletes a block from the pool along with its parent or future index entry.
+func (p *pendingBlocks) remove(root [32]byte, block *pb.BeaconBlock) {
+	delete(p.blocks, root)
+	if p.future[root] {
+		delete(p.future, root)
+		return
+	}
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+	siblings := p.orphans[parentRoot]
+	for i, r := range siblings {
+		if r == root {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	if len(siblings) == 0 {
+		delete(p.orphans, parentRoot)
+		return
+	}
+	p.orphans[parentRoot] = siblings
+}
+
+func sortBySlot(blocks []*pb.BeaconBlock) {
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+}
@@ -0,0 +1,140 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestPendingBlocks_ChildrenReturnedBySlot(t *testing.T) {
+	pool := newPendingBlocks(10)
+	parentRoot := [32]byte{'A'}
+	first := &pb.BeaconBlock{Slot: 2, ParentRootHash32: parentRoot[:]}
+	second := &pb.BeaconBlock{Slot: 1, ParentRootHash32: parentRoot[:]}
+	other := &pb.BeaconBlock{Slot: 1, ParentRootHash32: []byte{'B'}}
+	pool.addOrphan([32]byte{1}, first)
+	pool.addOrphan([32]byte{2}, second)
+	pool.addOrphan([32]byte{3}, other)
+
+	children := pool.children(parentRoot)
+	if len(children) != 2 {
+		t.Fatalf("Expected 2 children, received %d", len(children))
+	}
+	if children[0] != second || children[1] != first {
+		t.Error("Expected children to be sorted by slot")
+	}
+	if pool.size() != 1 {
+		t.Errorf("Expected 1 block left in the pool, received %d", pool.size())
+	}
+	if len(pool.children(parentRoot)) != 0 {
+		t.Error("Expected children to be removed from the pool")
+	}
+}
+
+func TestPendingBlocks_ReadyReturnsStartedSlots(t *testing.T) {
+	pool := newPendingBlocks(10)
+	pool.addFuture([32]byte{1}, &pb.BeaconBlock{Slot: 5})
+	pool.addFuture([32]byte{2}, &pb.BeaconBlock{Slot: 3})
+	pool.addFuture([32]byte{3}, &pb.BeaconBlock{Slot: 8})
+
+	ready := pool.ready(5)
+	if len(ready) != 2 {
+		t.Fatalf("Expected 2 ready blocks, received %d", len(ready))
+	}
+	if ready[0].Slot != 3 || ready[1].Slot != 5 {
+		t.Errorf("Expected ready blocks at slots 3 and 5, received %d and %d", ready[0].Slot, ready[1].Slot)
+	}
+	if !pool.has([32]byte{3}) {
+		t.Error("Expected block for a later slot to remain in the pool")
+	}
+}
+
+func TestPendingBlocks_EvictsHighestSlotWhenFull(t *testing.T) {
+	pool := newPendingBlocks(2)
+	pool.addOrphan([32]byte{1}, &pb.BeaconBlock{Slot: 4, ParentRootHash32: []byte{'A'}})
+	pool.addFuture([32]byte{2}, &pb.BeaconBlock{Slot: 9})
+
+	if pool.addOrphan([32]byte{3}, &pb.BeaconBlock{Slot: 10, ParentRootHash32: []byte{'A'}}) {
+		t.Error("Expected block with the highest slot to be rejected by a full pool")
+	}
+	if !pool.addOrphan([32]byte{4}, &pb.BeaconBlock{Slot: 5, ParentRootHash32: []byte{'A'}}) {
+		t.Fatal("Expected block to be added by evicting a block with a higher slot")
+	}
+	if pool.size() != 2 {
+		t.Errorf("Expected pool to stay at capacity, received %d blocks", pool.size())
+	}
+	if pool.has([32]byte{2}) {
+		t.Error("Expected the block with the highest slot to be evicted")
+	}
+	if len(pool.ready(9)) != 0 {
+		t.Error("Expected evicted future block to be removed from the future index")
+	}
+}
+
+func TestHoldPendingBlock_RequestsMissingParent(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+
+	requests := make(chan [32]byte, 1)
+	sub := chainService.BlockRequestFeed().Subscribe(requests)
+	defer sub.Unsubscribe()
+
+	parentRoot := [32]byte{'p'}
+	block := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: parentRoot[:],
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !chainService.holdPendingBlock(blockRoot, block) {
+		t.Fatal("Expected block with an unknown parent to be held")
+	}
+	if !chainService.pendingBlocks.has(blockRoot) {
+		t.Error("Expected block to be in the pending block pool")
+	}
+	if requested := <-requests; requested != parentRoot {
+		t.Errorf("Expected parent %#x to be requested, received %#x", parentRoot, requested)
+	}
+}
+
+func TestHoldPendingBlock_HoldsFutureBlock(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, false)
+	chainService.setGenesisTime(time.Now())
+
+	parentRoot, _ := setupGenesisBlock(t, chainService, nil)
+	current := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot,
+		ParentRootHash32: parentRoot[:],
+	}
+	currentRoot, err := hashutil.HashBeaconBlock(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainService.holdPendingBlock(currentRoot, current) {
+		t.Error("Expected block for the current slot not to be held")
+	}
+
+	future := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 10,
+		ParentRootHash32: parentRoot[:],
+	}
+	futureRoot, err := hashutil.HashBeaconBlock(future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !chainService.holdPendingBlock(futureRoot, future) {
+		t.Fatal("Expected block for a future slot to be held")
+	}
+	if ready := chainService.pendingBlocks.ready(params.BeaconConfig().GenesisSlot + 10); len(ready) != 1 {
+		t.Errorf("Expected future block to be released at its slot, received %d blocks", len(ready))
+	}
+}
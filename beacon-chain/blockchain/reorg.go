@@ -0,0 +1,64 @@
+package blockchain
+
+import (
+	"fmt"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+// ReorgEvent is sent to the reorg feed whenever the fork-choice rule selects a
+// chain head which does not descend from the previous chain head.
+type ReorgEvent struct {
+	OldHead        *pb.BeaconBlock
+	NewHead        *pb.BeaconBlock
+	CommonAncestor *pb.BeaconBlock
+	// Depth is the number of blocks of the previous canonical
+	// chain which were orphaned by the reorg.
+	Depth uint64
+}
+
+// commonAncestor walks back from the old and the new chain heads until both branches
+// meet, and returns the block they have in common along with the number of blocks of
+// the old branch which are not part of the new branch.
+func (c *ChainService) commonAncestor(oldHead *pb.BeaconBlock, newHead *pb.BeaconBlock) (*pb.BeaconBlock, uint64, error) {
+	oldBlock, newBlock := oldHead, newHead
+	depth := uint64(0)
+	for {
+		oldRoot, err := hashutil.HashBeaconBlock(oldBlock)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not tree hash block: %v", err)
+		}
+		newRoot, err := hashutil.HashBeaconBlock(newBlock)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not tree hash block: %v", err)
+		}
+		if oldRoot == newRoot {
+			return oldBlock, depth, nil
+		}
+
+		if oldBlock.Slot >= newBlock.Slot {
+			oldBlock, err = c.parentBlock(oldBlock)
+			depth++
+		} else {
+			newBlock, err = c.parentBlock(newBlock)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not find common ancestor: %v", err)
+		}
+	}
+}
+
+// parentBlock retrieves the parent of a block from the db.
+func (c *ChainService) parentBlock(block *pb.BeaconBlock) (*pb.BeaconBlock, error) {
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+	parent, err := c.beaconDB.Block(parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("parent block %#x does not exist", parentRoot)
+	}
+	return parent, nil
+}
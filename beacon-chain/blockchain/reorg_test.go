@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// saveChildBlock saves a block at the given slot which descends from the parent.
+func saveChildBlock(t *testing.T, chainService *ChainService, parent *pb.BeaconBlock, slot uint64, stateRoot []byte) *pb.BeaconBlock {
+	parentRoot, err := hashutil.HashBeaconBlock(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pb.BeaconBlock{
+		Slot:             slot,
+		ParentRootHash32: parentRoot[:],
+		StateRootHash32:  stateRoot,
+	}
+	if err := chainService.beaconDB.SaveBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestCommonAncestor_ReturnsForkPointAndDepth(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	_, genesis := setupGenesisBlock(t, chainService, &pb.BeaconState{})
+	genesisSlot := params.BeaconConfig().GenesisSlot
+
+	// Old branch: genesis - 1 - 2 - 3, new branch: genesis - 1 - 4.
+	block1 := saveChildBlock(t, chainService, genesis, genesisSlot+1, []byte("1"))
+	block2 := saveChildBlock(t, chainService, block1, genesisSlot+2, []byte("2"))
+	block3 := saveChildBlock(t, chainService, block2, genesisSlot+3, []byte("3"))
+	block4 := saveChildBlock(t, chainService, block1, genesisSlot+4, []byte("4"))
+
+	ancestor, depth, err := chainService.commonAncestor(block3, block4)
+	if err != nil {
+		t.Fatalf("Could not get common ancestor: %v", err)
+	}
+	if !proto.Equal(ancestor, block1) {
+		t.Errorf("Expected common ancestor %v, received %v", block1, ancestor)
+	}
+	if depth != 2 {
+		t.Errorf("Expected depth 2, received %d", depth)
+	}
+
+	// A descendant of the old head orphans no blocks.
+	ancestor, depth, err = chainService.commonAncestor(block2, block3)
+	if err != nil {
+		t.Fatalf("Could not get common ancestor: %v", err)
+	}
+	if !proto.Equal(ancestor, block2) {
+		t.Errorf("Expected common ancestor %v, received %v", block2, ancestor)
+	}
+	if depth != 0 {
+		t.Errorf("Expected depth 0, received %d", depth)
+	}
+}
+
+func TestCommonAncestor_MissingParent(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+
+	block1 := &pb.BeaconBlock{Slot: 1, ParentRootHash32: []byte{'a'}}
+	block2 := &pb.BeaconBlock{Slot: 1, ParentRootHash32: []byte{'b'}}
+	if _, _, err := chainService.commonAncestor(block1, block2); err == nil {
+		t.Error("Expected unrelated blocks to have no common ancestor")
+	}
+}
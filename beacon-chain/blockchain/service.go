@@ -4,20 +4,26 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,6 +33,11 @@ type operationService interface {
 	IncomingProcessedBlockFeed() *event.Feed
 }
 
+type attestationService interface {
+	IncomingAttestationFeed() *event.Feed
+	LatestAttestations(validators []*pb.Validator) map[uint64]*pb.Attestation
+}
+
 // ChainService represents a service that handles the internal
 // logic of managing the full PoS beacon chain.
 type ChainService struct {
@@ -35,14 +46,23 @@ type ChainService struct {
 	beaconDB             *db.BeaconDB
 	web3Service          *powchain.Web3Service
 	opsPoolService       operationService
+	attsService          attestationService
 	incomingBlockFeed    *event.Feed
 	incomingBlockChan    chan *pb.BeaconBlock
 	chainStartChan       chan time.Time
 	canonicalBlockFeed   *event.Feed
 	canonicalStateFeed   *event.Feed
+	reorgFeed            *event.Feed
+	blockRequestFeed     *event.Feed
+	invalidBlockFeed     *event.Feed
 	genesisTime          time.Time
+	genesisTimeLock      sync.RWMutex
 	enablePOWChain       bool
 	stateInitializedFeed *event.Feed
+	forkChoiceStore      *forkChoiceStore
+	pendingBlocks        *pendingBlocks
+	healthConfig         HealthConfig
+	health               *healthTracker
 }
 
 // Config options for the service.
@@ -52,8 +72,10 @@ type Config struct {
 	Web3Service      *powchain.Web3Service
 	BeaconDB         *db.BeaconDB
 	OpsPoolService   operationService
+	AttsService      attestationService
 	DevMode          bool
 	EnablePOWChain   bool
+	HealthConfig     HealthConfig
 }
 
 // NewChainService instantiates a new service instance that will
@@ -66,13 +88,21 @@ func NewChainService(ctx context.Context, cfg *Config) (*ChainService, error) {
 		beaconDB:             cfg.BeaconDB,
 		web3Service:          cfg.Web3Service,
 		opsPoolService:       cfg.OpsPoolService,
+		attsService:          cfg.AttsService,
 		incomingBlockChan:    make(chan *pb.BeaconBlock, cfg.IncomingBlockBuf),
 		chainStartChan:       make(chan time.Time),
 		incomingBlockFeed:    new(event.Feed),
 		canonicalBlockFeed:   new(event.Feed),
 		canonicalStateFeed:   new(event.Feed),
+		reorgFeed:            new(event.Feed),
+		blockRequestFeed:     new(event.Feed),
+		invalidBlockFeed:     new(event.Feed),
 		stateInitializedFeed: new(event.Feed),
+		forkChoiceStore:      newForkChoiceStore(),
+		pendingBlocks:        newPendingBlocks(maxPendingBlocks),
 		enablePOWChain:       cfg.EnablePOWChain,
+		healthConfig:         cfg.HealthConfig,
+		health:               &healthTracker{},
 	}, nil
 }
 
@@ -85,7 +115,10 @@ func (c *ChainService) Start() {
 	// If the chain has already been initialized, simply start the block processing routine.
 	if beaconState != nil {
 		log.Info("Beacon chain data already exists, starting service")
-		c.genesisTime = time.Unix(int64(beaconState.GenesisTime), 0)
+		c.setGenesisTime(time.Unix(int64(beaconState.GenesisTime), 0))
+		if err := c.loadForkChoiceStore(); err != nil {
+			log.Fatalf("Could not load blocks since the justified block: %v", err)
+		}
 		go c.blockProcessing()
 	} else {
 		log.Info("Waiting for ChainStart log from the Validator Deposit Contract to start the beacon chain...")
@@ -107,12 +140,27 @@ func (c *ChainService) Start() {
 	}
 }
 
+// setGenesisTime records the genesis time of the chain, once the chain has started.
+func (c *ChainService) setGenesisTime(genesisTime time.Time) {
+	c.genesisTimeLock.Lock()
+	defer c.genesisTimeLock.Unlock()
+	c.genesisTime = genesisTime
+}
+
+// genesisTimestamp returns the genesis time of the chain, or the zero time if the chain
+// has not started yet.
+func (c *ChainService) genesisTimestamp() time.Time {
+	c.genesisTimeLock.RLock()
+	defer c.genesisTimeLock.RUnlock()
+	return c.genesisTime
+}
+
 // initializes the state and genesis block of the beacon chain to persistent storage
 // based on a genesis timestamp value obtained from the ChainStart event emitted
 // by the ETH1.0 Deposit Contract and the POWChain service of the node.
 func (c *ChainService) initializeBeaconChain(genesisTime time.Time, deposits []*pb.Deposit) error {
 	log.Info("ChainStart time reached, starting the beacon chain!")
-	c.genesisTime = genesisTime
+	c.setGenesisTime(genesisTime)
 	unixTime := uint64(genesisTime.Unix())
 	if err := c.beaconDB.InitializeState(unixTime, deposits); err != nil {
 		return fmt.Errorf("could not initialize beacon state to disk: %v", err)
@@ -132,6 +180,89 @@ func (c *ChainService) initializeBeaconChain(genesisTime time.Time, deposits []*
 	if err := c.beaconDB.UpdateChainHead(genBlock, beaconState); err != nil {
 		return fmt.Errorf("could not set chain head, %v", err)
 	}
+	if err := c.beaconDB.SaveJustifiedBlock(genBlock); err != nil {
+		return fmt.Errorf("could not save genesis block as justified block: %v", err)
+	}
+	return nil
+}
+
+// loadForkChoiceStore populates the fork-choice block tree with the justified block and
+// every saved block descending from it, including the blocks of branches which are not
+// part of the main chain, and restores the votes of the attestations saved since the
+// justified block. A restarted node then selects its head from the same block tree it
+// was using before shutting down.
+func (c *ChainService) loadForkChoiceStore() error {
+	justifiedBlock, err := c.beaconDB.JustifiedBlock()
+	if err != nil {
+		return fmt.Errorf("could not retrieve justified block: %v", err)
+	}
+	if justifiedBlock == nil {
+		return nil
+	}
+	justifiedRoot, err := hashutil.HashBeaconBlock(justifiedBlock)
+	if err != nil {
+		return fmt.Errorf("could not tree hash justified block: %v", err)
+	}
+	c.forkChoiceStore.insertBlock(justifiedRoot, bytesutil.ToBytes32(justifiedBlock.ParentRootHash32), justifiedBlock.Slot)
+
+	// The blocks are visited breadth first, so every block is inserted after its parent.
+	queue := [][32]byte{justifiedRoot}
+	for len(queue) > 0 {
+		parentRoot := queue[0]
+		queue = queue[1:]
+		children, err := c.beaconDB.ChildrenOf(parentRoot)
+		if err != nil {
+			return fmt.Errorf("could not retrieve children of block %#x: %v", parentRoot, err)
+		}
+		for _, child := range children {
+			root, err := hashutil.HashBeaconBlock(child)
+			if err != nil {
+				return fmt.Errorf("could not tree hash block: %v", err)
+			}
+			if c.forkChoiceStore.hasBlock(root) {
+				continue
+			}
+			c.forkChoiceStore.insertBlock(root, parentRoot, child.Slot)
+			queue = append(queue, root)
+		}
+	}
+	return c.loadForkChoiceVotes(justifiedBlock.Slot)
+}
+
+// loadForkChoiceVotes records the saved attestations from the given slot onwards as votes
+// in the fork-choice block tree. Attestations whose committee can no longer be computed
+// from the current state are skipped.
+func (c *ChainService) loadForkChoiceVotes(fromSlot uint64) error {
+	beaconState, err := c.beaconDB.State()
+	if err != nil {
+		return fmt.Errorf("could not retrieve beacon state: %v", err)
+	}
+	attestations, err := c.beaconDB.AttestationsBySlotRange(fromSlot, beaconState.Slot)
+	if err != nil {
+		return fmt.Errorf("could not retrieve attestations since slot %d: %v", fromSlot, err)
+	}
+	for _, attestation := range attestations {
+		participants, err := helpers.AttestationParticipants(beaconState, attestation.Data, attestation.AggregationBitfield)
+		if err != nil {
+			log.Debugf("Could not restore vote of attestation at slot %d: %v",
+				attestation.Data.Slot-params.BeaconConfig().GenesisSlot, err)
+			continue
+		}
+		root := bytesutil.ToBytes32(attestation.Data.BeaconBlockRootHash32)
+		for _, index := range participants {
+			c.forkChoiceStore.processAttestation(index, root, attestation.Data.Slot)
+		}
+	}
+	return nil
+}
+
+// insertForkChoiceBlock adds a block to the fork-choice block tree.
+func (c *ChainService) insertForkChoiceBlock(block *pb.BeaconBlock) error {
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		return fmt.Errorf("could not tree hash block: %v", err)
+	}
+	c.forkChoiceStore.insertBlock(root, bytesutil.ToBytes32(block.ParentRootHash32), block.Slot)
 	return nil
 }
 
@@ -143,12 +274,6 @@ func (c *ChainService) Stop() error {
 	return nil
 }
 
-// Status always returns nil.
-// TODO(1202): Add service health checks.
-func (c *ChainService) Status() error {
-	return nil
-}
-
 // IncomingBlockFeed returns a feed that any service can send incoming p2p blocks into.
 // The chain service will subscribe to this feed in order to process incoming blocks.
 func (c *ChainService) IncomingBlockFeed() *event.Feed {
@@ -167,6 +292,26 @@ func (c *ChainService) CanonicalStateFeed() *event.Feed {
 	return c.canonicalStateFeed
 }
 
+// ReorgFeed returns a feed that is written to whenever the chain head switches
+// to a block which does not descend from the previous chain head. The feed
+// carries a *ReorgEvent describing the orphaned and the new branch.
+func (c *ChainService) ReorgFeed() *event.Feed {
+	return c.reorgFeed
+}
+
+// BlockRequestFeed returns a feed that is written to whenever a received block
+// references a parent which has not been processed yet. The feed carries the
+// [32]byte root of the missing parent block, which sync requests from peers.
+func (c *ChainService) BlockRequestFeed() *event.Feed {
+	return c.blockRequestFeed
+}
+
+// InvalidBlockFeed returns a feed that is written to whenever a received block
+// fails validation. The feed carries an *InvalidBlockEvent.
+func (c *ChainService) InvalidBlockFeed() *event.Feed {
+	return c.invalidBlockFeed
+}
+
 // StateInitializedFeed returns a feed that is written to
 // when the beacon state is first initialized.
 func (c *ChainService) StateInitializedFeed() *event.Feed {
@@ -200,70 +345,301 @@ func (c *ChainService) doesPoWBlockExist(hash [32]byte) bool {
 }
 
 // blockProcessing subscribes to incoming blocks, processes them if possible, and then applies
-// the fork-choice rule to update the beacon chain's head.
+// the fork-choice rule to update the beacon chain's head. At the start of every slot, the
+// pending blocks held for that slot are processed.
 func (c *ChainService) blockProcessing() {
 	subBlock := c.incomingBlockFeed.Subscribe(c.incomingBlockChan)
 	defer subBlock.Unsubscribe()
+	ticker := slotutil.GetSlotTicker(c.genesisTimestamp(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
 	for {
 		select {
 		case <-c.ctx.Done():
 			log.Debug("Chain service context closed, exiting goroutine")
 			return
 
+		case slot := <-ticker.C():
+			c.sampleBlockBuffer()
+			for _, block := range c.pendingBlocks.ready(slot) {
+				c.processBlock(block)
+			}
+
 		// Listen for a newly received incoming block from the feed. Blocks
 		// can be received either from the sync service, the RPC service,
 		// or via p2p.
 		case block := <-c.incomingBlockChan:
-			beaconState, err := c.beaconDB.State()
-			if err != nil {
-				log.Errorf("Unable to retrieve beacon state %v", err)
-				continue
-			}
+			c.processBlock(block)
+			c.sampleBlockBuffer()
+		}
+	}
+}
+
+// processBlock runs the state transition and the fork-choice rule on a block. Once the
+// block is processed, the pending blocks which were waiting for it as their parent are
+// processed as well.
+func (c *ChainService) processBlock(block *pb.BeaconBlock) {
+	queue := []*pb.BeaconBlock{block}
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
 
-			if block.Slot > beaconState.Slot {
-				computedState, err := c.ReceiveBlock(block, beaconState)
-				if err != nil {
-					log.Errorf("Could not process received block: %v", err)
-					continue
-				}
-				if err := c.ApplyForkChoiceRule(block, computedState); err != nil {
-					log.Errorf("Could not update chain head: %v", err)
-					continue
-				}
+		blockRoot, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			log.Errorf("Could not tree hash incoming block: %v", err)
+			continue
+		}
+		if c.holdPendingBlock(blockRoot, block) {
+			continue
+		}
+
+		// The block is applied to the post-state of its parent, which is not
+		// the canonical state if the block extends a different branch.
+		parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+		beaconState, err := c.beaconDB.BlockState(parentRoot)
+		if err != nil {
+			log.Errorf("Unable to retrieve parent block state %v", err)
+			continue
+		}
+		if beaconState == nil {
+			log.Errorf("No state found for parent block %#x", parentRoot)
+			continue
+		}
+		computedState, err := c.ReceiveBlock(block, beaconState)
+		if err != nil {
+			log.Errorf("Could not process received block: %v", err)
+			c.health.recordBlockFailure()
+			if invalid, ok := err.(*invalidBlockError); ok {
+				c.invalidBlockFeed.Send(&InvalidBlockEvent{BlockRoot: blockRoot, BadSignature: invalid.badSignature})
 			}
+			continue
 		}
+		if err := c.ApplyForkChoiceRule(block, computedState); err != nil {
+			log.Errorf("Could not update chain head: %v", err)
+			c.health.recordBlockFailure()
+			continue
+		}
+		c.health.recordBlockSuccess()
+		queue = append(queue, c.pendingBlocks.children(blockRoot)...)
 	}
 }
 
+// holdPendingBlock adds a block to the pending block pool if it can not be processed yet
+// and returns true if it did. Blocks whose parent has not been processed are held until the
+// parent lands, and the parent is requested from the network unless it is pending itself.
+// Blocks for future slots are held until their slot begins.
+func (c *ChainService) holdPendingBlock(blockRoot [32]byte, block *pb.BeaconBlock) bool {
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+	if !c.beaconDB.HasBlock(parentRoot) {
+		if !c.pendingBlocks.addOrphan(blockRoot, block) {
+			log.WithField("blockRoot", fmt.Sprintf("%#x", blockRoot)).Warn("Pending block pool is full, dropping block")
+			return true
+		}
+		log.WithFields(logrus.Fields{
+			"blockRoot":  fmt.Sprintf("%#x", blockRoot),
+			"parentRoot": fmt.Sprintf("%#x", parentRoot),
+		}).Debug("Holding block until its parent is processed")
+		if !c.pendingBlocks.has(parentRoot) {
+			c.blockRequestFeed.Send(parentRoot)
+		}
+		return true
+	}
+
+	currentSlot := slotutil.CurrentSlot(c.genesisTimestamp(), params.BeaconConfig().SecondsPerSlot, time.Since)
+	if block.Slot > currentSlot {
+		if !c.pendingBlocks.addFuture(blockRoot, block) {
+			log.WithField("blockRoot", fmt.Sprintf("%#x", blockRoot)).Warn("Pending block pool is full, dropping block")
+			return true
+		}
+		log.WithFields(logrus.Fields{
+			"blockRoot":   fmt.Sprintf("%#x", blockRoot),
+			"slot":        block.Slot - params.BeaconConfig().GenesisSlot,
+			"currentSlot": currentSlot - params.BeaconConfig().GenesisSlot,
+		}).Debug("Holding block until its slot begins")
+		return true
+	}
+	return false
+}
+
 // ApplyForkChoiceRule determines the current beacon chain head using LMD GHOST as a block-vote
-// weighted function to select a canonical head in Ethereum Serenity.
+// weighted function to select a canonical head in Ethereum Serenity. The fork choice starts
+// from the justified block and weighs every block in the fork-choice block tree by the latest
+// attestations of the active validators. If the selected head is on a different branch than
+// the previous head, the main chain is rewritten to follow the new branch.
 func (c *ChainService) ApplyForkChoiceRule(block *pb.BeaconBlock, computedState *pb.BeaconState) error {
 	h, err := hashutil.HashBeaconBlock(block)
 	if err != nil {
 		return fmt.Errorf("could not tree hash incoming block: %v", err)
 	}
-	// TODO(#1307): Use LMD GHOST as the fork-choice rule for Ethereum Serenity.
-	// TODO(#674): Handle chain reorgs.
-	if err := c.beaconDB.UpdateChainHead(block, computedState); err != nil {
+
+	if err := c.updateJustifiedBlock(computedState); err != nil {
+		return fmt.Errorf("could not update justified block: %v", err)
+	}
+	justifiedBlock, err := c.beaconDB.JustifiedBlock()
+	if err != nil {
+		return fmt.Errorf("could not retrieve justified block: %v", err)
+	}
+	if justifiedBlock == nil {
+		return errors.New("no justified block recorded to start the fork choice from")
+	}
+	justifiedRoot, err := hashutil.HashBeaconBlock(justifiedBlock)
+	if err != nil {
+		return fmt.Errorf("could not tree hash justified block: %v", err)
+	}
+	if !c.forkChoiceStore.hasBlock(justifiedRoot) {
+		if err := c.insertForkChoiceBlock(justifiedBlock); err != nil {
+			return err
+		}
+	}
+	c.forkChoiceStore.insertBlock(h, bytesutil.ToBytes32(block.ParentRootHash32), block.Slot)
+
+	c.updateVotes(computedState)
+	headRoot, err := c.forkChoiceStore.head(justifiedRoot, effectiveBalances(computedState))
+	if err != nil {
+		return fmt.Errorf("could not run fork choice: %v", err)
+	}
+	if err := c.pruneForkChoiceStore(computedState); err != nil {
+		log.Errorf("Could not prune fork choice store: %v", err)
+	}
+
+	prevHead, err := c.beaconDB.ChainHead()
+	if err != nil {
+		return fmt.Errorf("could not retrieve chain head: %v", err)
+	}
+	prevHeadRoot, err := hashutil.HashBeaconBlock(prevHead)
+	if err != nil {
+		return fmt.Errorf("could not tree hash previous head block: %v", err)
+	}
+	if headRoot == prevHeadRoot {
+		log.WithField("blockRoot", fmt.Sprintf("%#x", h)).Info("Block processed but chain head unchanged")
+		return nil
+	}
+
+	head := block
+	headState := computedState
+	if headRoot != h {
+		head, err = c.beaconDB.Block(headRoot)
+		if err != nil {
+			return fmt.Errorf("could not retrieve head block: %v", err)
+		}
+		if head == nil {
+			return fmt.Errorf("head block %#x has not been saved", headRoot)
+		}
+		headState, err = c.beaconDB.BlockState(headRoot)
+		if err != nil {
+			return fmt.Errorf("could not retrieve head state: %v", err)
+		}
+		if headState == nil {
+			return fmt.Errorf("no state found for head block %#x", headRoot)
+		}
+	}
+
+	var reorg *ReorgEvent
+	if !bytes.Equal(head.ParentRootHash32, prevHeadRoot[:]) {
+		ancestor, depth, err := c.commonAncestor(prevHead, head)
+		if err != nil {
+			log.Errorf("Could not determine common ancestor of previous and new head: %v", err)
+		} else if depth > 0 {
+			reorg = &ReorgEvent{
+				OldHead:        prevHead,
+				NewHead:        head,
+				CommonAncestor: ancestor,
+				Depth:          depth,
+			}
+		}
+	}
+	if err := c.beaconDB.UpdateChainHead(head, headState); err != nil {
 		return fmt.Errorf("failed to update chain: %v", err)
 	}
-	log.WithField("blockRoot", fmt.Sprintf("0x%x", h)).Info("Chain head block and state updated")
+	if reorg != nil {
+		log.WithFields(logrus.Fields{
+			"previousHead": fmt.Sprintf("%#x", prevHeadRoot),
+			"newHead":      fmt.Sprintf("%#x", headRoot),
+			"depth":        reorg.Depth,
+		}).Warn("Chain reorg occurred")
+		c.reorgFeed.Send(reorg)
+	}
+	log.WithField("blockRoot", fmt.Sprintf("0x%x", headRoot)).Info("Chain head block and state updated")
 	// We fire events that notify listeners of a new block in
 	// the case of a state transition. This is useful for the beacon node's gRPC
 	// server to stream these events to beacon clients.
 	// When the transition is a cycle transition, we stream the state containing the new validator
 	// assignments to clients.
-	if block.Slot%params.BeaconConfig().SlotsPerEpoch == 0 {
-		c.canonicalStateFeed.Send(computedState)
+	if head.Slot%params.BeaconConfig().SlotsPerEpoch == 0 {
+		c.canonicalStateFeed.Send(headState)
 	}
-	c.canonicalBlockFeed.Send(block)
+	c.canonicalBlockFeed.Send(head)
 	return nil
 }
 
+// updateJustifiedBlock records the block at the justified epoch boundary of the given
+// state if the state has justified a more recent epoch than the current justified block.
+func (c *ChainService) updateJustifiedBlock(beaconState *pb.BeaconState) error {
+	justifiedBlock, err := c.beaconDB.JustifiedBlock()
+	if err != nil {
+		return fmt.Errorf("could not retrieve justified block: %v", err)
+	}
+	justifiedSlot := helpers.StartSlot(beaconState.JustifiedEpoch)
+	if justifiedBlock != nil && justifiedBlock.Slot >= justifiedSlot {
+		return nil
+	}
+	root, err := b.BlockRoot(beaconState, justifiedSlot)
+	if err != nil {
+		return fmt.Errorf("could not retrieve justified block root: %v", err)
+	}
+	block, err := c.beaconDB.Block(bytesutil.ToBytes32(root))
+	if err != nil {
+		return fmt.Errorf("could not retrieve justified block: %v", err)
+	}
+	if block == nil {
+		return fmt.Errorf("justified block %#x has not been saved", root)
+	}
+	return c.beaconDB.SaveJustifiedBlock(block)
+}
+
+// updateVotes records the latest attestation of every validator in the
+// given state's registry as its vote in the fork-choice block tree.
+func (c *ChainService) updateVotes(beaconState *pb.BeaconState) {
+	attestations := c.attsService.LatestAttestations(beaconState.ValidatorRegistry)
+	for index, attestation := range attestations {
+		c.forkChoiceStore.processAttestation(
+			index,
+			bytesutil.ToBytes32(attestation.Data.BeaconBlockRootHash32),
+			attestation.Data.Slot,
+		)
+	}
+}
+
+// pruneForkChoiceStore removes the blocks which conflict with the finalized
+// block of the given state from the fork-choice block tree.
+func (c *ChainService) pruneForkChoiceStore(beaconState *pb.BeaconState) error {
+	finalizedRoot, err := b.BlockRoot(beaconState, helpers.StartSlot(beaconState.FinalizedEpoch))
+	if err != nil {
+		return fmt.Errorf("could not retrieve finalized block root: %v", err)
+	}
+	root := bytesutil.ToBytes32(finalizedRoot)
+	// The finalized block may precede the blocks the tree was loaded with.
+	if !c.forkChoiceStore.hasBlock(root) {
+		return nil
+	}
+	return c.forkChoiceStore.prune(root)
+}
+
+// effectiveBalances returns the effective balance of every active validator in the
+// given state, indexed by validator index. Inactive validators have no weight.
+func effectiveBalances(beaconState *pb.BeaconState) []uint64 {
+	balances := make([]uint64, len(beaconState.ValidatorRegistry))
+	activeIndices := helpers.ActiveValidatorIndices(beaconState.ValidatorRegistry, helpers.CurrentEpoch(beaconState))
+	for _, index := range activeIndices {
+		balances[index] = helpers.EffectiveBalance(beaconState, index)
+	}
+	return balances
+}
+
 // ReceiveBlock is a function that defines the operations that are preformed on
 // any block that is received from p2p layer or rpc. It checks the block to see
 // if it passes the pre-processing conditions, if it does then the per slot
-// state transition function is carried out on the block.
+// state transition function is carried out on the block. The given state
+// must be the post-state of the block's parent.
 // spec:
 //  def process_block(block):
 //      if not block_pre_processing_conditions(block):
@@ -294,26 +670,67 @@ func (c *ChainService) ReceiveBlock(block *pb.BeaconBlock, beaconState *pb.Beaco
 			block.Slot-params.BeaconConfig().GenesisSlot)
 	}
 
-	// Save blocks with higher slot numbers in cache.
+	// Pre-checks, which reject an invalid block before any state transition is run.
+	if block.Body == nil {
+		return nil, &invalidBlockError{err: fmt.Errorf("block with root %#x has no body", blockRoot)}
+	}
+	// A block which is not ready for processing is not reported as invalid, as the check
+	// depends on local data such as the ETH1 chain reference of the state.
 	if err := c.isBlockReadyForProcessing(block, beaconState); err != nil {
 		return nil, fmt.Errorf("block with root %#x is not ready for processing: %v", blockRoot, err)
 	}
 
-	// Retrieve the last processed beacon block's hash root.
-	headRoot, err := c.ChainHeadRoot()
+	beaconState, err = c.runStateTransition(block, beaconState)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve chain head root: %v", err)
+		return nil, err
+	}
+
+	// if there exists a block for the slot being processed.
+	if err := c.beaconDB.SaveBlock(block); err != nil {
+		return nil, fmt.Errorf("failed to save block: %v", err)
+	}
+
+	// Save the post state of the block so it can be used as the canonical
+	// state if the block is later selected as the chain head.
+	if err := c.beaconDB.SaveUnfinalizedBlockState(blockRoot, beaconState); err != nil {
+		return nil, fmt.Errorf("could not save block state: %v", err)
+	}
+
+	// Forward processed block to operation pool to remove individual operation from DB.
+	c.opsPoolService.IncomingProcessedBlockFeed().Send(block)
+
+	// Forward the block's attestations to the attestation service, these
+	// serve as the latest votes for the fork-choice rule.
+	for _, attestation := range block.Body.Attestations {
+		c.attsService.IncomingAttestationFeed().Send(attestation)
+	}
+
+	// Remove pending deposits from the deposit queue.
+	for _, dep := range block.Body.Deposits {
+		c.beaconDB.RemovePendingDeposit(c.ctx, dep)
 	}
 
+	log.WithField("hash", fmt.Sprintf("%#x", blockRoot)).Debug("Processed beacon block")
+	return beaconState, nil
+}
+
+// runStateTransition applies the block on top of the post-state of its parent. The skipped
+// slots are processed first, then the block's signatures are verified, and only then is the
+// block and epoch transition run.
+func (c *ChainService) runStateTransition(block *pb.BeaconBlock, beaconState *pb.BeaconState) (*pb.BeaconState, error) {
+	// The block is applied on top of its parent, which is not necessarily the chain head.
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+
 	log.WithField("slotNumber", block.Slot-params.BeaconConfig().GenesisSlot).Info(
 		"Executing state transition")
 
 	// Check for skipped slots.
+	var err error
 	for beaconState.Slot < block.Slot-1 {
 		beaconState, err = state.ExecuteStateTransition(
 			beaconState,
 			nil,
-			headRoot,
+			parentRoot,
 			true, /* sig verify */
 		)
 		if err != nil {
@@ -324,14 +741,19 @@ func (c *ChainService) ReceiveBlock(block *pb.BeaconBlock, beaconState *pb.Beaco
 		).Info("Slot transition successfully processed")
 	}
 
+	// The block's signatures are verified before the block transition.
 	beaconState, err = state.ExecuteStateTransition(
 		beaconState,
 		block,
-		headRoot,
-		true, /* no sig verify */
+		parentRoot,
+		true, /* sig verify */
 	)
 	if err != nil {
-		return nil, fmt.Errorf("could not execute state transition with block %v", err)
+		_, badSignature := err.(*state.SignatureError)
+		return nil, &invalidBlockError{
+			err:          fmt.Errorf("could not execute state transition with block %v", err),
+			badSignature: badSignature,
+		}
 	}
 	log.WithField(
 		"slotsSinceGenesis", beaconState.Slot-params.BeaconConfig().GenesisSlot,
@@ -344,21 +766,6 @@ func (c *ChainService) ReceiveBlock(block *pb.BeaconBlock, beaconState *pb.Beaco
 			"SlotsSinceGenesis", beaconState.Slot-params.BeaconConfig().GenesisSlot,
 		).Info("Epoch transition successfully processed")
 	}
-
-	// if there exists a block for the slot being processed.
-	if err := c.beaconDB.SaveBlock(block); err != nil {
-		return nil, fmt.Errorf("failed to save block: %v", err)
-	}
-
-	// Forward processed block to operation pool to remove individual operation from DB.
-	c.opsPoolService.IncomingProcessedBlockFeed().Send(block)
-
-	// Remove pending deposits from the deposit queue.
-	for _, dep := range block.Body.Deposits {
-		c.beaconDB.RemovePendingDeposit(c.ctx, dep)
-	}
-
-	log.WithField("hash", fmt.Sprintf("%#x", blockRoot)).Debug("Processed beacon block")
 	return beaconState, nil
 }
 
@@ -368,7 +775,7 @@ func (c *ChainService) isBlockReadyForProcessing(block *pb.BeaconBlock, beaconSt
 		powBlockFetcher = c.web3Service.Client().BlockByHash
 	}
 	if err := b.IsValidBlock(c.ctx, beaconState, block, c.enablePOWChain,
-		c.beaconDB.HasBlock, powBlockFetcher, c.genesisTime); err != nil {
+		c.beaconDB.HasBlock, powBlockFetcher, c.genesisTimestamp()); err != nil {
 		return fmt.Errorf("block does not fulfill pre-processing conditions %v", err)
 	}
 	return nil
@@ -1,20 +1,26 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"math/big"
+	"os"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 
+	"github.com/boltdb/bolt"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
@@ -44,6 +50,29 @@ func (ms *mockOperationService) IncomingProcessedBlockFeed() *event.Feed {
 	return new(event.Feed)
 }
 
+type mockAttestationService struct {
+	targets map[uint64]*pb.BeaconBlock
+}
+
+func (ma *mockAttestationService) IncomingAttestationFeed() *event.Feed {
+	return new(event.Feed)
+}
+
+func (ma *mockAttestationService) LatestAttestations(validators []*pb.Validator) map[uint64]*pb.Attestation {
+	attestations := make(map[uint64]*pb.Attestation)
+	for index, target := range ma.targets {
+		// #nosec G104
+		root, _ := hashutil.HashBeaconBlock(target)
+		attestations[index] = &pb.Attestation{
+			Data: &pb.AttestationData{
+				Slot:                  target.Slot,
+				BeaconBlockRootHash32: root[:],
+			},
+		}
+	}
+	return attestations
+}
+
 type mockClient struct{}
 
 func (m *mockClient) SubscribeNewHead(ctx context.Context, ch chan<- *gethTypes.Header) (ethereum.Subscription, error) {
@@ -182,10 +211,18 @@ func setupGenesisBlock(t *testing.T, cs *ChainService, beaconState *pb.BeaconSta
 	if err := cs.beaconDB.SaveBlock(genesis); err != nil {
 		t.Fatalf("could not save block to db: %v", err)
 	}
+	if err := cs.beaconDB.SaveJustifiedBlock(genesis); err != nil {
+		t.Fatalf("could not save justified block to db: %v", err)
+	}
 	parentHash, err := hashutil.HashBeaconBlock(genesis)
 	if err != nil {
 		t.Fatalf("unable to get tree hash root of canonical head: %v", err)
 	}
+	if beaconState != nil {
+		if err := cs.beaconDB.SaveUnfinalizedBlockState(parentHash, beaconState); err != nil {
+			t.Fatalf("could not save genesis block state to db: %v", err)
+		}
+	}
 	return parentHash, genesis
 }
 
@@ -224,6 +261,7 @@ func setupBeaconChain(t *testing.T, faultyPoWClient bool, beaconDB *db.BeaconDB,
 		BeaconDB:       beaconDB,
 		Web3Service:    web3Service,
 		OpsPoolService: &mockOperationService{},
+		AttsService:    &mockAttestationService{},
 		EnablePOWChain: enablePOWChain,
 	}
 	if err != nil {
@@ -358,6 +396,13 @@ func TestChainService_FaultyPOWChain(t *testing.T) {
 	if err := chainService.beaconDB.SaveBlock(parentBlock); err != nil {
 		t.Fatalf("Unable to save block %v", err)
 	}
+	parentState, err := db.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chainService.beaconDB.SaveUnfinalizedBlockState(parentRoot, parentState); err != nil {
+		t.Fatalf("Unable to save block state %v", err)
+	}
 
 	block := &pb.BeaconBlock{
 		Slot:             2,
@@ -440,6 +485,145 @@ func TestChainService_Starts(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Processed beacon block")
 }
 
+func TestProcessBlock_AppliesForkBlockToParentState(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	deposits, privKeys := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	genesisRoot, genesisBlock := setupGenesisBlock(t, chainService, beaconState)
+	if err := chainService.beaconDB.UpdateChainHead(genesisBlock, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	newBlock := func(slot uint64) *pb.BeaconBlock {
+		slotState := proto.Clone(beaconState).(*pb.BeaconState)
+		slotState.Slot = slot
+		return &pb.BeaconBlock{
+			Slot:             slot,
+			ParentRootHash32: genesisRoot[:],
+			RandaoReveal:     createRandaoReveal(t, slotState, privKeys),
+			Eth1Data: &pb.Eth1Data{
+				DepositRootHash32: []byte("a"),
+				BlockHash32:       []byte("b"),
+			},
+			Body: &pb.BeaconBlockBody{},
+		}
+	}
+
+	// Both blocks build on the genesis block, so the second block
+	// must not be applied to the post-state of the first one.
+	blockA := newBlock(params.BeaconConfig().GenesisSlot + 1)
+	chainService.processBlock(blockA)
+	blockB := newBlock(params.BeaconConfig().GenesisSlot + 2)
+	chainService.processBlock(blockB)
+
+	var postState *pb.BeaconState
+	for _, block := range []*pb.BeaconBlock{blockA, blockB} {
+		root, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		postState, err = chainService.beaconDB.BlockState(root)
+		if err != nil {
+			t.Fatalf("Could not get block state: %v", err)
+		}
+		if postState == nil {
+			t.Fatalf("Expected post-state for block at slot %d to be saved", block.Slot)
+		}
+		if postState.Slot != block.Slot {
+			t.Errorf("Expected post-state slot %d, received %d", block.Slot, postState.Slot)
+		}
+	}
+
+	// The slot skipped by the fork block should reference the genesis block rather than block A.
+	skippedRoot, err := b.BlockRoot(postState, params.BeaconConfig().GenesisSlot+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(skippedRoot, genesisRoot[:]) {
+		t.Errorf("Expected block root %#x at skipped slot, received %#x", genesisRoot, skippedRoot)
+	}
+}
+
+func TestProcessBlock_ReportsInvalidBlock(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	deposits, _ := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	genesisRoot, genesisBlock := setupGenesisBlock(t, chainService, beaconState)
+	if err := chainService.beaconDB.UpdateChainHead(genesisBlock, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(chan *InvalidBlockEvent, 1)
+	sub := chainService.InvalidBlockFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	// A block without a body fails the pre-processing checks.
+	block := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainService.processBlock(block)
+
+	select {
+	case event := <-events:
+		if event.BlockRoot != blockRoot {
+			t.Errorf("Expected invalid block %#x, received %#x", blockRoot, event.BlockRoot)
+		}
+		if event.BadSignature {
+			t.Error("Expected the block not to be reported for a bad signature")
+		}
+	default:
+		t.Fatal("Expected the invalid block to be reported")
+	}
+}
+
+func TestProcessBlock_DoesNotReportLocalFailures(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, true, db, true)
+	deposits, _ := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	genesisRoot, genesisBlock := setupGenesisBlock(t, chainService, beaconState)
+	if err := chainService.beaconDB.UpdateChainHead(genesisBlock, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(chan *InvalidBlockEvent, 1)
+	sub := chainService.InvalidBlockFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	// The POW chain reference block of the state can not be retrieved from the faulty client.
+	block := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		Body:             &pb.BeaconBlockBody{},
+	}
+	chainService.processBlock(block)
+
+	select {
+	case event := <-events:
+		t.Errorf("Expected a local failure not to be reported, received invalid block %#x", event.BlockRoot)
+	default:
+	}
+}
+
 func TestReceiveBlock_RemovesPendingDeposits(t *testing.T) {
 	hook := logTest.NewGlobal()
 	db := internal.SetupDB(t)
@@ -541,16 +725,6 @@ func TestUpdateHead_SavesBlock(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Cannot create genesis beacon state: %v", err)
 	}
-	stateRoot, err := hashutil.HashProto(beaconState)
-	if err != nil {
-		t.Fatalf("Could not tree hash state: %v", err)
-	}
-
-	genesis := b.NewGenesisBlock(stateRoot[:])
-	genesisRoot, err := hashutil.HashProto(genesis)
-	if err != nil {
-		t.Fatalf("Could not get genesis block root: %v", err)
-	}
 	// Table driven tests for various fork choice scenarios.
 	tests := []struct {
 		blockSlot uint64
@@ -590,6 +764,14 @@ func TestUpdateHead_SavesBlock(t *testing.T) {
 		if err := db.InitializeState(unixTime, deposits); err != nil {
 			t.Fatalf("Could not initialize beacon state to disk: %v", err)
 		}
+		genesis, err := db.ChainHead()
+		if err != nil {
+			t.Fatalf("Could not get genesis block: %v", err)
+		}
+		genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+		if err != nil {
+			t.Fatalf("Could not get genesis block root: %v", err)
+		}
 
 		stateRoot, err := hashutil.HashProto(tt.state)
 		if err != nil {
@@ -692,3 +874,340 @@ func TestIsBlockReadyForProcessing_ValidBlock(t *testing.T) {
 		t.Fatalf("block processing failed despite being a valid block: %v", err)
 	}
 }
+
+func TestApplyForkChoiceRule_ReorgsToHeavierBranch(t *testing.T) {
+	hook := logTest.NewGlobal()
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	deposits, _ := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	parentHash, genesisBlock := setupGenesisBlock(t, chainService, beaconState)
+	if err := chainService.beaconDB.UpdateChainHead(genesisBlock, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	blockA := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: parentHash[:],
+		StateRootHash32:  []byte("A"),
+	}
+	blockB := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: parentHash[:],
+		StateRootHash32:  []byte("B"),
+	}
+	if err := chainService.beaconDB.SaveBlock(blockA); err != nil {
+		t.Fatal(err)
+	}
+	if err := chainService.ApplyForkChoiceRule(blockA, beaconState); err != nil {
+		t.Fatalf("Could not apply fork choice rule: %v", err)
+	}
+
+	reorgChan := make(chan *ReorgEvent, 1)
+	sub := chainService.ReorgFeed().Subscribe(reorgChan)
+	defer sub.Unsubscribe()
+
+	// Validator 0 votes for the competing block, which should make it the new head.
+	chainService.attsService = &mockAttestationService{
+		targets: map[uint64]*pb.BeaconBlock{0: blockB},
+	}
+	if err := chainService.beaconDB.SaveBlock(blockB); err != nil {
+		t.Fatal(err)
+	}
+	if err := chainService.ApplyForkChoiceRule(blockB, beaconState); err != nil {
+		t.Fatalf("Could not apply fork choice rule: %v", err)
+	}
+
+	head, err := chainService.beaconDB.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(head, blockB) {
+		t.Errorf("Expected head to equal %v, received %v", blockB, head)
+	}
+	canonical, err := chainService.beaconDB.BlockBySlot(blockB.Slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(canonical, blockB) {
+		t.Errorf("Expected canonical block at slot %d to be %v, received %v", blockB.Slot, blockB, canonical)
+	}
+	testutil.AssertLogsContain(t, hook, "Chain reorg occurred")
+
+	reorg := <-reorgChan
+	if !proto.Equal(reorg.OldHead, blockA) {
+		t.Errorf("Expected old head %v, received %v", blockA, reorg.OldHead)
+	}
+	if !proto.Equal(reorg.NewHead, blockB) {
+		t.Errorf("Expected new head %v, received %v", blockB, reorg.NewHead)
+	}
+	if !proto.Equal(reorg.CommonAncestor, genesisBlock) {
+		t.Errorf("Expected common ancestor %v, received %v", genesisBlock, reorg.CommonAncestor)
+	}
+	if reorg.Depth != 1 {
+		t.Errorf("Expected reorg depth 1, received %d", reorg.Depth)
+	}
+}
+
+func TestApplyForkChoiceRule_LighterBranchDoesNotUpdateHead(t *testing.T) {
+	hook := logTest.NewGlobal()
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	deposits, _ := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	parentHash, genesisBlock := setupGenesisBlock(t, chainService, beaconState)
+	if err := chainService.beaconDB.UpdateChainHead(genesisBlock, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	blockA := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: parentHash[:],
+		StateRootHash32:  []byte("A"),
+	}
+	blockB := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: parentHash[:],
+		StateRootHash32:  []byte("B"),
+	}
+	chainService.attsService = &mockAttestationService{
+		targets: map[uint64]*pb.BeaconBlock{0: blockA, 1: blockA},
+	}
+	if err := chainService.beaconDB.SaveBlock(blockA); err != nil {
+		t.Fatal(err)
+	}
+	if err := chainService.ApplyForkChoiceRule(blockA, beaconState); err != nil {
+		t.Fatalf("Could not apply fork choice rule: %v", err)
+	}
+	if err := chainService.beaconDB.SaveBlock(blockB); err != nil {
+		t.Fatal(err)
+	}
+	if err := chainService.ApplyForkChoiceRule(blockB, beaconState); err != nil {
+		t.Fatalf("Could not apply fork choice rule: %v", err)
+	}
+
+	head, err := chainService.beaconDB.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(head, blockA) {
+		t.Errorf("Expected head to equal %v, received %v", blockA, head)
+	}
+	testutil.AssertLogsContain(t, hook, "Block processed but chain head unchanged")
+	testutil.AssertLogsDoNotContain(t, hook, "Chain reorg occurred")
+}
+
+func TestLoadForkChoiceStore_RestoresBranchesAndVotes(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	chainService := setupBeaconChain(t, false, db, true)
+	deposits, _ := setupInitialDeposits(t, 100)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("Could not initialize beacon state to disk: %v", err)
+	}
+	genesisBlock, err := db.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesisBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Block B is on a fork, and C extends the canonical block A.
+	blockA := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		StateRootHash32:  []byte("A"),
+	}
+	blockB := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		StateRootHash32:  []byte("B"),
+	}
+	rootA, err := hashutil.HashBeaconBlock(blockA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootB, err := hashutil.HashBeaconBlock(blockB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockC := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 2,
+		ParentRootHash32: rootA[:],
+		StateRootHash32:  []byte("C"),
+	}
+	for _, block := range []*pb.BeaconBlock{blockA, blockB, blockC} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	beaconState, err := db.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconState.Slot = blockC.Slot
+	if err := db.UpdateChainHead(blockC, beaconState); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first committee of the fork's slot votes for B.
+	committees, err := helpers.CrosslinkCommitteesAtSlot(beaconState, blockB.Slot, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committee := committees[0].Committee
+	bitfield := make([]byte, (len(committee)+7)/8)
+	for i := range committee {
+		bitfield[i/8] |= 1 << uint(7-i%8)
+	}
+	if err := db.SaveAttestation(&pb.Attestation{
+		Data: &pb.AttestationData{
+			Slot:                  blockB.Slot,
+			Shard:                 committees[0].Shard,
+			BeaconBlockRootHash32: rootB[:],
+		},
+		AggregationBitfield: bitfield,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := chainService.loadForkChoiceStore(); err != nil {
+		t.Fatalf("Could not load fork choice store: %v", err)
+	}
+	if size := chainService.forkChoiceStore.size(); size != 4 {
+		t.Errorf("Expected 4 blocks in the block tree, received %d", size)
+	}
+	head, err := chainService.forkChoiceStore.head(genesisRoot, effectiveBalances(beaconState))
+	if err != nil {
+		t.Fatalf("Could not get head: %v", err)
+	}
+	if head != rootB {
+		t.Errorf("Expected the voted fork %#x to be the head, received %#x", rootB, head)
+	}
+}
+
+// writeBaselineDB writes a DB in the format of the first release of the beacon node, which
+// only kept the canonical state and recorded the genesis block under the slot 0 key.
+func writeBaselineDB(t *testing.T, dirPath string, genesisBlock *pb.BeaconBlock, beaconState *pb.BeaconState) {
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	boltDB, err := bolt.Open(path.Join(dirPath, "beaconchain.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("Could not open bolt DB: %v", err)
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(genesisBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockEnc, err := proto.Marshal(genesisBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateEnc, err := proto.Marshal(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := boltDB.Update(func(tx *bolt.Tx) error {
+		blockBkt, err := tx.CreateBucketIfNotExists([]byte("block-bucket"))
+		if err != nil {
+			return err
+		}
+		mainChain, err := tx.CreateBucketIfNotExists([]byte("main-chain-bucket"))
+		if err != nil {
+			return err
+		}
+		chainInfo, err := tx.CreateBucketIfNotExists([]byte("chain-info"))
+		if err != nil {
+			return err
+		}
+		if err := blockBkt.Put(blockRoot[:], blockEnc); err != nil {
+			return err
+		}
+		if err := mainChain.Put(bytesutil.Bytes8(0), blockRoot[:]); err != nil {
+			return err
+		}
+		if err := chainInfo.Put([]byte("chain-height"), bytesutil.Bytes8(0)); err != nil {
+			return err
+		}
+		return chainInfo.Put([]byte("state"), stateEnc)
+	}); err != nil {
+		t.Fatalf("Could not write baseline DB: %v", err)
+	}
+	if err := boltDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProcessBlock_ExtendsHeadOfUpgradedDB(t *testing.T) {
+	randPath, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		t.Fatalf("Could not generate random file path: %v", err)
+	}
+	dirPath := path.Join(testutil.TempDir(), fmt.Sprintf("/%d", randPath))
+	deposits, privKeys := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, 0, nil)
+	if err != nil {
+		t.Fatalf("Can't generate genesis state: %v", err)
+	}
+	stateRoot, err := hashutil.HashProto(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisBlock := b.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := hashutil.HashBeaconBlock(genesisBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeBaselineDB(t, dirPath, genesisBlock, beaconState)
+
+	beaconDB, err := db.NewDB(dirPath)
+	if err != nil {
+		t.Fatalf("Could not open baseline DB: %v", err)
+	}
+	defer internal.TeardownDB(t, beaconDB)
+	chainService := setupBeaconChain(t, false, beaconDB, true)
+
+	slotState := proto.Clone(beaconState).(*pb.BeaconState)
+	slotState.Slot = params.BeaconConfig().GenesisSlot + 1
+	block := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		RandaoReveal:     createRandaoReveal(t, slotState, privKeys),
+		Eth1Data: &pb.Eth1Data{
+			DepositRootHash32: []byte("a"),
+			BlockHash32:       []byte("b"),
+		},
+		Body: &pb.BeaconBlockBody{},
+	}
+	chainService.processBlock(block)
+
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postState, err := beaconDB.BlockState(blockRoot)
+	if err != nil {
+		t.Fatalf("Could not get block state: %v", err)
+	}
+	if postState == nil || postState.Slot != block.Slot {
+		t.Fatalf("Expected post-state at slot %d to be saved, received %v", block.Slot, postState)
+	}
+	head, err := beaconDB.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(head, block) {
+		t.Errorf("Expected the block to extend the head, received head %v", head)
+	}
+}
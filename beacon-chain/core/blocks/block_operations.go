@@ -10,31 +10,16 @@ import (
 	"fmt"
 	"reflect"
 
-	"github.com/sirupsen/logrus"
-
 	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state/stateutils"
 	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
-	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	"github.com/prysmaticlabs/prysm/shared/forkutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
-// VerifyProposerSignature uses BLS signature verification to ensure
-// the correct proposer created an incoming beacon block during state
-// transition processing.
-//
-// WIP - this is stubbed out until BLS is integrated into Prysm.
-func VerifyProposerSignature(
-	_ *pb.BeaconBlock,
-) error {
-	return nil
-}
-
 // ProcessEth1Data is an operation performed on each
 // beacon block to ensure the ETH1 data votes are processed
 // into the beacon state.
@@ -77,19 +62,8 @@ func ProcessEth1Data(beaconState *pb.BeaconState, block *pb.BeaconBlock) *pb.Bea
 //     signature=block.randao_reveal, domain=get_domain(state.fork, get_current_epoch(state), DOMAIN_RANDAO)).
 //   Set state.latest_randao_mixes[get_current_epoch(state) % LATEST_RANDAO_MIXES_LENGTH] =
 //     xor(get_randao_mix(state, get_current_epoch(state)), hash(block.randao_reveal))
-func ProcessBlockRandao(beaconState *pb.BeaconState, block *pb.BeaconBlock, verifySignatures bool) (*pb.BeaconState, error) {
-	proposerIdx, err := helpers.BeaconProposerIndex(beaconState, beaconState.Slot)
-	if err != nil {
-		return nil, fmt.Errorf("could not get beacon proposer index: %v", err)
-	}
-	log.WithField("proposerIndex", proposerIdx).Info("Verifying randao")
-	proposer := beaconState.ValidatorRegistry[proposerIdx]
-	if verifySignatures {
-		if err := verifyBlockRandao(beaconState, block, proposer); err != nil {
-			return nil, fmt.Errorf("could not verify block randao: %v", err)
-		}
-	}
-	// If block randao passed verification, we XOR the state's latest randao mix with the block's
+func ProcessBlockRandao(beaconState *pb.BeaconState, block *pb.BeaconBlock) (*pb.BeaconState, error) {
+	// The block randao is verified beforehand, we XOR the state's latest randao mix with the block's
 	// randao and update the state's corresponding latest randao mix value.
 	latestMixesLength := params.BeaconConfig().LatestRandaoMixesLength
 	currentEpoch := helpers.CurrentEpoch(beaconState)
@@ -101,32 +75,6 @@ func ProcessBlockRandao(beaconState *pb.BeaconState, block *pb.BeaconBlock, veri
 	return beaconState, nil
 }
 
-// Verify that bls_verify(pubkey=proposer.pubkey, message_hash=hash_tree_root(get_current_epoch(state)),
-//   signature=block.randao_reveal, domain=get_domain(state.fork, get_current_epoch(state), DOMAIN_RANDAO))
-func verifyBlockRandao(beaconState *pb.BeaconState, block *pb.BeaconBlock, proposer *pb.Validator) error {
-	pub, err := bls.PublicKeyFromBytes(proposer.Pubkey)
-	if err != nil {
-		return fmt.Errorf("could not deserialize proposer public key: %v", err)
-	}
-	currentEpoch := helpers.CurrentEpoch(beaconState)
-	buf := make([]byte, 32)
-	binary.LittleEndian.PutUint64(buf, currentEpoch)
-	domain := forkutils.DomainVersion(beaconState.Fork, currentEpoch, params.BeaconConfig().DomainRandao)
-	sig, err := bls.SignatureFromBytes(block.RandaoReveal)
-	if err != nil {
-		return fmt.Errorf("could not deserialize block randao reveal: %v", err)
-	}
-	log.WithFields(logrus.Fields{
-		"epoch":    helpers.CurrentEpoch(beaconState) - params.BeaconConfig().GenesisEpoch,
-		"pubkey":   fmt.Sprintf("%#x", proposer.Pubkey),
-		"epochSig": fmt.Sprintf("%#x", sig.Marshal()),
-	}).Info("Verifying randao")
-	if !sig.Verify(buf, pub, domain) {
-		return fmt.Errorf("block randao reveal signature did not verify")
-	}
-	return nil
-}
-
 // ProcessProposerSlashings is one of the operations performed
 // on each processed beacon block to slash proposers based on
 // slashing conditions if any slashable events occurred.
@@ -150,7 +98,6 @@ func verifyBlockRandao(beaconState *pb.BeaconState, block *pb.BeaconBlock, propo
 func ProcessProposerSlashings(
 	beaconState *pb.BeaconState,
 	block *pb.BeaconBlock,
-	verifySignatures bool,
 ) (*pb.BeaconState, error) {
 	body := block.Body
 	registry := beaconState.ValidatorRegistry
@@ -163,7 +110,7 @@ func ProcessProposerSlashings(
 	}
 	var err error
 	for idx, slashing := range body.ProposerSlashings {
-		if err = verifyProposerSlashing(slashing, verifySignatures); err != nil {
+		if err = verifyProposerSlashing(slashing); err != nil {
 			return nil, fmt.Errorf("could not verify proposer slashing #%d: %v", idx, err)
 		}
 		proposer := registry[slashing.ProposerIndex]
@@ -180,7 +127,6 @@ func ProcessProposerSlashings(
 
 func verifyProposerSlashing(
 	slashing *pb.ProposerSlashing,
-	verifySignatures bool,
 ) error {
 	// section of block operations.
 	slot1 := slashing.ProposalData_1.Slot
@@ -205,10 +151,6 @@ func verifyProposerSlashing(
 	if !bytes.Equal(root1, root2) {
 		return fmt.Errorf("slashing proposal data block roots do not match: %#x, %#x", root1, root2)
 	}
-	if verifySignatures {
-		// TODO(#258): Verify BLS according to the specification in the "Proposer Slashings"
-		return nil
-	}
 	return nil
 }
 
@@ -236,7 +178,6 @@ func verifyProposerSlashing(
 func ProcessAttesterSlashings(
 	beaconState *pb.BeaconState,
 	block *pb.BeaconBlock,
-	verifySignatures bool,
 ) (*pb.BeaconState, error) {
 	body := block.Body
 	if uint64(len(body.AttesterSlashings)) > params.BeaconConfig().MaxAttesterSlashings {
@@ -247,7 +188,7 @@ func ProcessAttesterSlashings(
 		)
 	}
 	for idx, slashing := range body.AttesterSlashings {
-		if err := verifyAttesterSlashing(slashing, verifySignatures); err != nil {
+		if err := verifyAttesterSlashing(slashing); err != nil {
 			return nil, fmt.Errorf("could not verify attester slashing #%d: %v", idx, err)
 		}
 		slashableIndices, err := attesterSlashableIndices(beaconState, slashing)
@@ -265,7 +206,7 @@ func ProcessAttesterSlashings(
 	return beaconState, nil
 }
 
-func verifyAttesterSlashing(slashing *pb.AttesterSlashing, verifySignatures bool) error {
+func verifyAttesterSlashing(slashing *pb.AttesterSlashing) error {
 	slashableAttestation1 := slashing.SlashableAttestation_1
 	slashableAttestation2 := slashing.SlashableAttestation_2
 	data1 := slashableAttestation1.Data
@@ -287,10 +228,10 @@ func verifyAttesterSlashing(slashing *pb.AttesterSlashing, verifySignatures bool
 	if !(isSameTarget || isSurroundVote(data1, data2)) {
 		return errors.New("attester slashing is not a double vote nor surround vote")
 	}
-	if err := verifySlashableAttestation(slashableAttestation1, verifySignatures); err != nil {
+	if err := verifySlashableAttestation(slashableAttestation1); err != nil {
 		return fmt.Errorf("could not verify attester slashable attestation data 1: %v", err)
 	}
-	if err := verifySlashableAttestation(slashableAttestation2, verifySignatures); err != nil {
+	if err := verifySlashableAttestation(slashableAttestation2); err != nil {
 		return fmt.Errorf("could not verify attester slashable attestation data 2: %v", err)
 	}
 	return nil
@@ -319,7 +260,7 @@ func attesterSlashableIndices(beaconState *pb.BeaconState, slashing *pb.Attester
 	return slashableIndices, nil
 }
 
-func verifySlashableAttestation(att *pb.SlashableAttestation, verifySignatures bool) error {
+func verifySlashableAttestation(att *pb.SlashableAttestation) error {
 	emptyCustody := make([]byte, len(att.CustodyBitfield))
 	if bytes.Equal(att.CustodyBitfield, emptyCustody) {
 		return errors.New("custody bit field can't all be 0s")
@@ -345,11 +286,6 @@ func verifySlashableAttestation(att *pb.SlashableAttestation, verifySignatures b
 		return fmt.Errorf("validator indices length (%d) exceeded max indices per slashable vote(%d)",
 			len(att.ValidatorIndices), params.BeaconConfig().MaxIndicesPerSlashableVote)
 	}
-
-	if verifySignatures {
-		// TODO(#258): Implement BLS verify multiple.
-		return nil
-	}
 	return nil
 }
 
@@ -389,7 +325,6 @@ func isSurroundVote(data1 *pb.AttestationData, data2 *pb.AttestationData) bool {
 func ProcessBlockAttestations(
 	beaconState *pb.BeaconState,
 	block *pb.BeaconBlock,
-	verifySignatures bool,
 ) (*pb.BeaconState, error) {
 	atts := block.Body.Attestations
 	if uint64(len(atts)) > params.BeaconConfig().MaxAttestations {
@@ -401,7 +336,7 @@ func ProcessBlockAttestations(
 	}
 
 	for idx, attestation := range atts {
-		if err := verifyAttestation(beaconState, attestation, verifySignatures); err != nil {
+		if err := verifyAttestation(beaconState, attestation); err != nil {
 			return nil, fmt.Errorf("could not verify attestation at index %d in block: %v", idx, err)
 		}
 		beaconState.LatestAttestations = append(beaconState.LatestAttestations, &pb.PendingAttestation{
@@ -415,7 +350,7 @@ func ProcessBlockAttestations(
 	return beaconState, nil
 }
 
-func verifyAttestation(beaconState *pb.BeaconState, att *pb.Attestation, verifySignatures bool) error {
+func verifyAttestation(beaconState *pb.BeaconState, att *pb.Attestation) error {
 	if att.Data.Slot < params.BeaconConfig().GenesisSlot {
 		return fmt.Errorf(
 			"attestation slot (slot %d) less than genesis slot (%d)",
@@ -506,22 +441,6 @@ func verifyAttestation(beaconState *pb.BeaconState, att *pb.Attestation, verifyS
 			att.Data.ShardBlockRootHash32,
 		)
 	}
-	if verifySignatures {
-		// TODO(#258): Integrate BLS signature verification for attestation.
-		// assert bls_verify_multiple(
-		//   pubkeys=[
-		//	 bls_aggregate_pubkeys([state.validator_registry[i].pubkey for i in custody_bit_0_participants]),
-		//   bls_aggregate_pubkeys([state.validator_registry[i].pubkey for i in custody_bit_1_participants]),
-		//   ],
-		//   message_hash=[
-		//   hash_tree_root(AttestationDataAndCustodyBit(data=attestation.data, custody_bit=0b0)),
-		//   hash_tree_root(AttestationDataAndCustodyBit(data=attestation.data, custody_bit=0b1)),
-		//   ],
-		//   signature=attestation.aggregate_signature,
-		//   domain=get_domain(state.fork, slot_to_epoch(attestation.data.slot), DOMAIN_ATTESTATION),
-		// )
-		return nil
-	}
 	return nil
 }
 
@@ -632,7 +551,6 @@ func verifyDeposit(beaconState *pb.BeaconState, deposit *pb.Deposit) error {
 func ProcessValidatorExits(
 	beaconState *pb.BeaconState,
 	block *pb.BeaconBlock,
-	verifySignatures bool,
 ) (*pb.BeaconState, error) {
 	exits := block.Body.VoluntaryExits
 	if uint64(len(exits)) > params.BeaconConfig().MaxVoluntaryExits {
@@ -645,7 +563,7 @@ func ProcessValidatorExits(
 
 	validatorRegistry := beaconState.ValidatorRegistry
 	for idx, exit := range exits {
-		if err := verifyExit(beaconState, exit, verifySignatures); err != nil {
+		if err := verifyExit(beaconState, exit); err != nil {
 			return nil, fmt.Errorf("could not verify exit #%d: %v", idx, err)
 		}
 		beaconState = v.InitiateValidatorExit(beaconState, exit.ValidatorIndex)
@@ -654,7 +572,7 @@ func ProcessValidatorExits(
 	return beaconState, nil
 }
 
-func verifyExit(beaconState *pb.BeaconState, exit *pb.VoluntaryExit, verifySignatures bool) error {
+func verifyExit(beaconState *pb.BeaconState, exit *pb.VoluntaryExit) error {
 	validator := beaconState.ValidatorRegistry[exit.ValidatorIndex]
 	currentEpoch := helpers.CurrentEpoch(beaconState)
 	entryExitEffectEpoch := helpers.EntryExitEffectEpoch(currentEpoch)
@@ -672,14 +590,5 @@ func verifyExit(beaconState *pb.BeaconState, exit *pb.VoluntaryExit, verifySigna
 			exit.Epoch,
 		)
 	}
-	if verifySignatures {
-		// TODO(#258): Verify using BLS signature verification below:
-		// Let exit_message = hash_tree_root(
-		//   Exit(epoch=exit.epoch, validator_index=exit.validator_index, signature=EMPTY_SIGNATURE)
-		// )
-		// Verify that bls_verify(pubkey=validator.pubkey, message_hash=exit_message,
-		//   signature=exit.signature, domain=get_domain(state.fork, exit.epoch, DOMAIN_EXIT)).
-		return nil
-	}
 	return nil
 }
@@ -43,39 +43,7 @@ func setupInitialDeposits(t *testing.T, numDeposits int) ([]*pb.Deposit, []*bls.
 	return deposits, privKeys
 }
 
-func TestProcessBlockRandao_IncorrectProposerFailsVerification(t *testing.T) {
-	deposits, privKeys := setupInitialDeposits(t, 100)
-	beaconState, err := state.GenesisBeaconState(deposits, uint64(0), []byte{})
-	if err != nil {
-		t.Fatal(err)
-	}
-	// We fetch the proposer's index as that is whom the RANDAO will be verified against.
-	proposerIdx, err := helpers.BeaconProposerIndex(beaconState, params.BeaconConfig().GenesisSlot)
-	if err != nil {
-		t.Fatal(err)
-	}
-	epoch := helpers.SlotToEpoch(params.BeaconConfig().GenesisSlot)
-	buf := make([]byte, 32)
-	binary.LittleEndian.PutUint64(buf, epoch)
-	domain := forkutils.DomainVersion(beaconState.Fork, epoch, params.BeaconConfig().DomainRandao)
-
-	// We make the previous validator's index sign the message instead of the proposer.
-	epochSignature := privKeys[proposerIdx-1].Sign(buf, domain)
-	block := &pb.BeaconBlock{
-		RandaoReveal: epochSignature.Marshal(),
-	}
-
-	want := "block randao reveal signature did not verify"
-	if _, err := blocks.ProcessBlockRandao(
-		beaconState,
-		block,
-		true,
-	); !strings.Contains(err.Error(), want) {
-		t.Errorf("Expected %v, received %v", want, err)
-	}
-}
-
-func TestProcessBlockRandao_SignatureVerifiesAndUpdatesLatestStateMixes(t *testing.T) {
+func TestProcessBlockRandao_UpdatesLatestStateMixes(t *testing.T) {
 	deposits, privKeys := setupInitialDeposits(t, 100)
 	beaconState, err := state.GenesisBeaconState(deposits, uint64(0), []byte{})
 	if err != nil {
@@ -99,7 +67,6 @@ func TestProcessBlockRandao_SignatureVerifiesAndUpdatesLatestStateMixes(t *testi
 	newState, err := blocks.ProcessBlockRandao(
 		beaconState,
 		block,
-		true,
 	)
 	if err != nil {
 		t.Errorf("Unexpected error processing block randao: %v", err)
@@ -202,7 +169,6 @@ func TestProcessProposerSlashings_ThresholdReached(t *testing.T) {
 	if _, err := blocks.ProcessProposerSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -236,7 +202,6 @@ func TestProcessProposerSlashings_UnmatchedSlotNumbers(t *testing.T) {
 	if _, err := blocks.ProcessProposerSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -272,7 +237,6 @@ func TestProcessProposerSlashings_UnmatchedShards(t *testing.T) {
 	if _, err := blocks.ProcessProposerSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -314,7 +278,6 @@ func TestProcessProposerSlashings_UnmatchedBlockRoots(t *testing.T) {
 	if _, err := blocks.ProcessProposerSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -368,7 +331,6 @@ func TestProcessProposerSlashings_AppliesCorrectStatus(t *testing.T) {
 	newState, err := blocks.ProcessProposerSlashings(
 		beaconState,
 		block,
-		false,
 	)
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err)
@@ -404,7 +366,6 @@ func TestProcessAttesterSlashings_ThresholdReached(t *testing.T) {
 	if _, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -452,7 +413,6 @@ func TestProcessAttesterSlashings_EmptyCustodyFields(t *testing.T) {
 	if _, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -494,7 +454,6 @@ func TestProcessAttesterSlashings_EmptyCustodyFields(t *testing.T) {
 	if _, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -539,7 +498,6 @@ func TestProcessAttesterSlashings_UnmatchedAttestations(t *testing.T) {
 	if _, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -584,7 +542,6 @@ func TestProcessAttesterSlashings_EmptyVoteIndexIntersection(t *testing.T) {
 	if _, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -644,7 +601,6 @@ func TestProcessAttesterSlashings_AppliesCorrectStatus(t *testing.T) {
 	newState, err := blocks.ProcessAttesterSlashings(
 		beaconState,
 		block,
-		false,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -683,7 +639,6 @@ func TestProcessBlockAttestations_ThresholdReached(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -715,7 +670,6 @@ func TestProcessBlockAttestations_InclusionDelayFailure(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -747,7 +701,6 @@ func TestProcessBlockAttestations_EpochDistanceFailure(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -780,7 +733,6 @@ func TestProcessBlockAttestations_JustifiedEpochVerificationFailure(t *testing.T
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -813,7 +765,6 @@ func TestProcessBlockAttestations_PreviousJustifiedEpochVerificationFailure(t *t
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -849,7 +800,6 @@ func TestProcessBlockAttestations_BlockRootOutOfBounds(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -889,7 +839,6 @@ func TestProcessBlockAttestations_BlockRootFailure(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -940,7 +889,6 @@ func TestProcessBlockAttestations_CrosslinkRootFailure(t *testing.T) {
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -987,7 +935,6 @@ func TestProcessBlockAttestations_ShardBlockRootEqualZeroHashFailure(t *testing.
 	if _, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -1030,7 +977,6 @@ func TestProcessBlockAttestations_CreatePendingAttestations(t *testing.T) {
 	newState, err := blocks.ProcessBlockAttestations(
 		state,
 		block,
-		false,
 	)
 	pendingAttestations := newState.LatestAttestations
 	if err != nil {
@@ -1343,7 +1289,6 @@ func TestProcessValidatorExits_ThresholdReached(t *testing.T) {
 	if _, err := blocks.ProcessValidatorExits(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -1374,7 +1319,6 @@ func TestProcessValidatorExits_ValidatorNotActive(t *testing.T) {
 	if _, err := blocks.ProcessValidatorExits(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -1406,7 +1350,6 @@ func TestProcessValidatorExits_InvalidExitEpoch(t *testing.T) {
 	if _, err := blocks.ProcessValidatorExits(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -1438,7 +1381,6 @@ func TestProcessValidatorExits_InvalidStatusChangeSlot(t *testing.T) {
 	if _, err := blocks.ProcessValidatorExits(
 		state,
 		block,
-		false,
 	); !strings.Contains(err.Error(), want) {
 		t.Errorf("Expected %s, received %v", want, err)
 	}
@@ -1465,7 +1407,7 @@ func TestProcessValidatorExits_AppliesCorrectStatus(t *testing.T) {
 			VoluntaryExits: exits,
 		},
 	}
-	newState, err := blocks.ProcessValidatorExits(state, block, false)
+	newState, err := blocks.ProcessValidatorExits(state, block)
 	if err != nil {
 		t.Fatalf("Could not process exits: %v", err)
 	}
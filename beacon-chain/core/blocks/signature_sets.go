@@ -0,0 +1,305 @@
+package blocks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/forkutils"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// SignatureSet is a BLS signature carried by a block, along with the public keys
+// and the message it commits to. The signature is verified against the aggregate
+// of the public keys.
+type SignatureSet struct {
+	Name       string
+	PublicKeys [][]byte
+	Message    []byte
+	Signature  []byte
+	Domain     uint64
+	// Stubbed sets are collected but not verified, as validators do not sign
+	// them yet.
+	Stubbed bool
+}
+
+// Verify the signature of the set. Stubbed sets always verify.
+func (s *SignatureSet) Verify() error {
+	if s.Stubbed {
+		// TODO(#258): Verify every set once validators sign blocks and operations.
+		return nil
+	}
+	sig, err := bls.SignatureFromBytes(s.Signature)
+	if err != nil {
+		return fmt.Errorf("could not deserialize %s signature: %v", s.Name, err)
+	}
+	pubKeys := make([]*bls.PublicKey, len(s.PublicKeys))
+	for i, pubKey := range s.PublicKeys {
+		pubKeys[i], err = bls.PublicKeyFromBytes(pubKey)
+		if err != nil {
+			return fmt.Errorf("could not deserialize %s public key: %v", s.Name, err)
+		}
+	}
+
+	var verified bool
+	if len(pubKeys) == 1 {
+		verified = sig.Verify(s.Message, pubKeys[0], s.Domain)
+	} else {
+		verified = sig.VerifyAggregate(pubKeys, s.Message, s.Domain)
+	}
+	if !verified {
+		return fmt.Errorf("%s signature did not verify", s.Name)
+	}
+	return nil
+}
+
+// BlockSignatureSets collects the signatures of a block and of its operations. The beacon
+// state must have gone through the per-slot transition of the block's slot, as it is the
+// state the block is processed against.
+//
+// Only the RANDAO reveal is verified for now. The proposer, slashing, attestation and exit
+// sets are stubbed, as the validator client does not produce those signatures yet.
+func BlockSignatureSets(beaconState *pb.BeaconState, block *pb.BeaconBlock) ([]*SignatureSet, error) {
+	proposerIdx, err := helpers.BeaconProposerIndex(beaconState, beaconState.Slot)
+	if err != nil {
+		return nil, fmt.Errorf("could not get beacon proposer index: %v", err)
+	}
+	proposer := beaconState.ValidatorRegistry[proposerIdx]
+
+	proposerSet, err := proposerSignatureSet(beaconState, block, proposer)
+	if err != nil {
+		return nil, err
+	}
+	sets := []*SignatureSet{proposerSet, randaoSignatureSet(beaconState, block, proposer)}
+	if block.Body == nil {
+		return sets, nil
+	}
+
+	for idx, slashing := range block.Body.ProposerSlashings {
+		slashingSets, err := proposerSlashingSignatureSets(beaconState, slashing)
+		if err != nil {
+			return nil, fmt.Errorf("could not collect proposer slashing #%d signatures: %v", idx, err)
+		}
+		sets = append(sets, slashingSets...)
+	}
+	for idx, slashing := range block.Body.AttesterSlashings {
+		for _, att := range []*pb.SlashableAttestation{slashing.SlashableAttestation_1, slashing.SlashableAttestation_2} {
+			set, err := slashableAttestationSignatureSet(beaconState, att)
+			if err != nil {
+				return nil, fmt.Errorf("could not collect attester slashing #%d signatures: %v", idx, err)
+			}
+			sets = append(sets, set)
+		}
+	}
+	for idx, att := range block.Body.Attestations {
+		set, err := attestationSignatureSet(beaconState, att)
+		if err != nil {
+			return nil, fmt.Errorf("could not collect attestation #%d signature: %v", idx, err)
+		}
+		sets = append(sets, set)
+	}
+	for idx, exit := range block.Body.VoluntaryExits {
+		set, err := exitSignatureSet(beaconState, exit)
+		if err != nil {
+			return nil, fmt.Errorf("could not collect exit #%d signature: %v", idx, err)
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// VerifySignatureSets verifies the signature sets concurrently across the available
+// CPU cores. It returns the error of the first set, in order, which did not verify.
+func VerifySignatureSets(sets []*SignatureSet) error {
+	workers := runtime.NumCPU()
+	if workers > len(sets) {
+		workers = len(sets)
+	}
+
+	indices := make(chan int, len(sets))
+	for i := range sets {
+		indices <- i
+	}
+	close(indices)
+
+	errs := make([]error, len(sets))
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = sets[i].Verify()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyBlockSignatures verifies the signature sets of a block, so the block can then be
+// processed without verifying signatures. See BlockSignatureSets for the signatures which
+// are covered.
+func VerifyBlockSignatures(beaconState *pb.BeaconState, block *pb.BeaconBlock) error {
+	sets, err := BlockSignatureSets(beaconState, block)
+	if err != nil {
+		return fmt.Errorf("could not collect block signatures: %v", err)
+	}
+	return VerifySignatureSets(sets)
+}
+
+// randaoSignatureSet returns the signature set of the block's RANDAO reveal, which
+// is the proposer's signature of the current epoch.
+func randaoSignatureSet(beaconState *pb.BeaconState, block *pb.BeaconBlock, proposer *pb.Validator) *SignatureSet {
+	currentEpoch := helpers.CurrentEpoch(beaconState)
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint64(buf, currentEpoch)
+	return &SignatureSet{
+		Name:       "block randao reveal",
+		PublicKeys: [][]byte{proposer.Pubkey},
+		Message:    buf,
+		Signature:  block.RandaoReveal,
+		Domain:     forkutils.DomainVersion(beaconState.Fork, currentEpoch, params.BeaconConfig().DomainRandao),
+	}
+}
+
+// proposerSignatureSet returns the signature set of the block proposal, which is the
+// proposer's signature of the block root without the signature.
+//
+// TODO(#781): Verify the proposer signature once validators sign their blocks.
+func proposerSignatureSet(beaconState *pb.BeaconState, block *pb.BeaconBlock, proposer *pb.Validator) (*SignatureSet, error) {
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash block: %v", err)
+	}
+	msg, err := hashutil.HashProto(&pb.ProposalSignedData{
+		Slot:            block.Slot,
+		Shard:           params.BeaconConfig().BeaconChainShardNumber,
+		BlockRootHash32: blockRoot[:],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not hash proposal: %v", err)
+	}
+	return &SignatureSet{
+		Name:       "block proposer",
+		PublicKeys: [][]byte{proposer.Pubkey},
+		Message:    msg[:],
+		Signature:  block.Signature,
+		Domain:     forkutils.DomainVersion(beaconState.Fork, helpers.SlotToEpoch(block.Slot), params.BeaconConfig().DomainProposal),
+		Stubbed:    true,
+	}, nil
+}
+
+// proposerSlashingSignatureSets returns the signature sets of both proposals of a
+// proposer slashing, each signed by the slashed proposer.
+func proposerSlashingSignatureSets(beaconState *pb.BeaconState, slashing *pb.ProposerSlashing) ([]*SignatureSet, error) {
+	pubKeys, err := validatorPubkeys(beaconState, []uint64{slashing.ProposerIndex})
+	if err != nil {
+		return nil, err
+	}
+	proposals := []*pb.ProposalSignedData{slashing.ProposalData_1, slashing.ProposalData_2}
+	signatures := [][]byte{slashing.ProposalSignature_1, slashing.ProposalSignature_2}
+	sets := make([]*SignatureSet, len(proposals))
+	for i, proposal := range proposals {
+		msg, err := hashutil.HashProto(proposal)
+		if err != nil {
+			return nil, fmt.Errorf("could not hash proposal: %v", err)
+		}
+		sets[i] = &SignatureSet{
+			Name:       "proposer slashing",
+			PublicKeys: pubKeys,
+			Message:    msg[:],
+			Signature:  signatures[i],
+			Domain:     forkutils.DomainVersion(beaconState.Fork, helpers.SlotToEpoch(proposal.Slot), params.BeaconConfig().DomainProposal),
+			Stubbed:    true,
+		}
+	}
+	return sets, nil
+}
+
+// slashableAttestationSignatureSet returns the signature set of a slashable attestation,
+// which is aggregated from the signatures of its validators.
+func slashableAttestationSignatureSet(beaconState *pb.BeaconState, att *pb.SlashableAttestation) (*SignatureSet, error) {
+	pubKeys, err := validatorPubkeys(beaconState, att.ValidatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := hashutil.HashProto(&pb.AttestationDataAndCustodyBit{Data: att.Data})
+	if err != nil {
+		return nil, fmt.Errorf("could not hash attestation data: %v", err)
+	}
+	return &SignatureSet{
+		Name:       "slashable attestation",
+		PublicKeys: pubKeys,
+		Message:    msg[:],
+		Signature:  att.AggregateSignature,
+		Domain:     forkutils.DomainVersion(beaconState.Fork, helpers.SlotToEpoch(att.Data.Slot), params.BeaconConfig().DomainAttestation),
+		Stubbed:    true,
+	}, nil
+}
+
+// attestationSignatureSet returns the signature set of an attestation. Its public keys
+// are those of the committee members in the aggregation bitfield, which are not looked
+// up until the set is verified, as the committee of an invalid attestation can not be
+// computed before the attestation is checked against the state.
+func attestationSignatureSet(beaconState *pb.BeaconState, att *pb.Attestation) (*SignatureSet, error) {
+	msg, err := hashutil.HashProto(&pb.AttestationDataAndCustodyBit{Data: att.Data})
+	if err != nil {
+		return nil, fmt.Errorf("could not hash attestation data: %v", err)
+	}
+	return &SignatureSet{
+		Name:      "attestation",
+		Message:   msg[:],
+		Signature: att.AggregateSignature,
+		Domain:    forkutils.DomainVersion(beaconState.Fork, helpers.SlotToEpoch(att.Data.Slot), params.BeaconConfig().DomainAttestation),
+		Stubbed:   true,
+	}, nil
+}
+
+// exitSignatureSet returns the signature set of a voluntary exit, which is the exiting
+// validator's signature of the exit with an empty signature.
+func exitSignatureSet(beaconState *pb.BeaconState, exit *pb.VoluntaryExit) (*SignatureSet, error) {
+	pubKeys, err := validatorPubkeys(beaconState, []uint64{exit.ValidatorIndex})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := hashutil.HashProto(&pb.VoluntaryExit{
+		Epoch:          exit.Epoch,
+		ValidatorIndex: exit.ValidatorIndex,
+		Signature:      params.BeaconConfig().EmptySignature[:],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not hash exit: %v", err)
+	}
+	return &SignatureSet{
+		Name:       "voluntary exit",
+		PublicKeys: pubKeys,
+		Message:    msg[:],
+		Signature:  exit.Signature,
+		Domain:     forkutils.DomainVersion(beaconState.Fork, exit.Epoch, params.BeaconConfig().DomainExit),
+		Stubbed:    true,
+	}, nil
+}
+
+// validatorPubkeys returns the public keys of the validators at the given registry indices.
+func validatorPubkeys(beaconState *pb.BeaconState, indices []uint64) ([][]byte, error) {
+	pubKeys := make([][]byte, len(indices))
+	for i, idx := range indices {
+		if idx >= uint64(len(beaconState.ValidatorRegistry)) {
+			return nil, fmt.Errorf("validator index %d is not in the registry", idx)
+		}
+		pubKeys[i] = beaconState.ValidatorRegistry[idx].Pubkey
+	}
+	return pubKeys, nil
+}
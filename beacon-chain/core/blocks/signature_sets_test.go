@@ -0,0 +1,187 @@
+package blocks_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/forkutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func signatureSets(t *testing.T, count int) []*blocks.SignatureSet {
+	sets := make([]*blocks.SignatureSet, count)
+	for i := 0; i < count; i++ {
+		priv, err := bls.RandKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sets[i] = &blocks.SignatureSet{
+			Name:       fmt.Sprintf("set %d", i),
+			PublicKeys: [][]byte{priv.PublicKey().Marshal()},
+			Message:    msg,
+			Signature:  priv.Sign(msg, params.BeaconConfig().DomainRandao).Marshal(),
+			Domain:     params.BeaconConfig().DomainRandao,
+		}
+	}
+	return sets
+}
+
+func TestVerifySignatureSets_OK(t *testing.T) {
+	if err := blocks.VerifySignatureSets(signatureSets(t, 16)); err != nil {
+		t.Errorf("Unexpected error verifying signature sets: %v", err)
+	}
+	if err := blocks.VerifySignatureSets(nil); err != nil {
+		t.Errorf("Unexpected error verifying no signature sets: %v", err)
+	}
+}
+
+func TestVerifySignatureSets_InvalidSignature(t *testing.T) {
+	sets := signatureSets(t, 16)
+	sets[7].Message = []byte("another message")
+
+	want := "set 7 signature did not verify"
+	if err := blocks.VerifySignatureSets(sets); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %v, received %v", want, err)
+	}
+}
+
+func TestVerifySignatureSets_AggregateSignature(t *testing.T) {
+	msg := []byte("message")
+	domain := params.BeaconConfig().DomainAttestation
+	var pubKeys [][]byte
+	var sigs []*bls.Signature
+	for i := 0; i < 4; i++ {
+		priv, err := bls.RandKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys = append(pubKeys, priv.PublicKey().Marshal())
+		sigs = append(sigs, priv.Sign(msg, domain))
+	}
+	set := &blocks.SignatureSet{
+		Name:       "aggregate",
+		PublicKeys: pubKeys,
+		Message:    msg,
+		Signature:  bls.AggregateSignatures(sigs).Marshal(),
+		Domain:     domain,
+	}
+	if err := blocks.VerifySignatureSets([]*blocks.SignatureSet{set}); err != nil {
+		t.Errorf("Unexpected error verifying aggregate signature: %v", err)
+	}
+}
+
+func TestBlockSignatureSets_RandaoReveal(t *testing.T) {
+	deposits, privKeys := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, uint64(0), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposerIdx, err := helpers.BeaconProposerIndex(beaconState, beaconState.Slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	epoch := helpers.CurrentEpoch(beaconState)
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint64(buf, epoch)
+	domain := forkutils.DomainVersion(beaconState.Fork, epoch, params.BeaconConfig().DomainRandao)
+	block := &pb.BeaconBlock{
+		RandaoReveal: privKeys[proposerIdx].Sign(buf, domain).Marshal(),
+	}
+
+	sets, err := blocks.BlockSignatureSets(beaconState, block)
+	if err != nil {
+		t.Fatalf("Could not collect block signatures: %v", err)
+	}
+	if len(sets) != 2 || !bytes.Equal(sets[1].Signature, block.RandaoReveal) || sets[1].Stubbed {
+		t.Fatalf("Expected the proposer and randao reveal signature sets, received %v", sets)
+	}
+	if err := blocks.VerifyBlockSignatures(beaconState, block); err != nil {
+		t.Errorf("Unexpected error verifying block signatures: %v", err)
+	}
+
+	block.RandaoReveal = privKeys[(proposerIdx+1)%100].Sign(buf, domain).Marshal()
+	want := "block randao reveal signature did not verify"
+	if err := blocks.VerifyBlockSignatures(beaconState, block); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %v, received %v", want, err)
+	}
+}
+
+func TestBlockSignatureSets_CollectsOperations(t *testing.T) {
+	deposits, _ := setupInitialDeposits(t, 100)
+	beaconState, err := state.GenesisBeaconState(deposits, uint64(0), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pb.BeaconBlock{
+		Slot: beaconState.Slot,
+		Body: &pb.BeaconBlockBody{
+			ProposerSlashings: []*pb.ProposerSlashing{
+				{
+					ProposerIndex:  1,
+					ProposalData_1: &pb.ProposalSignedData{Slot: beaconState.Slot},
+					ProposalData_2: &pb.ProposalSignedData{Slot: beaconState.Slot},
+				},
+			},
+			AttesterSlashings: []*pb.AttesterSlashing{
+				{
+					SlashableAttestation_1: &pb.SlashableAttestation{
+						ValidatorIndices: []uint64{1, 2},
+						Data:             &pb.AttestationData{Slot: beaconState.Slot},
+					},
+					SlashableAttestation_2: &pb.SlashableAttestation{
+						ValidatorIndices: []uint64{1, 2},
+						Data:             &pb.AttestationData{Slot: beaconState.Slot},
+					},
+				},
+			},
+			Attestations: []*pb.Attestation{
+				{Data: &pb.AttestationData{Slot: beaconState.Slot}},
+			},
+			VoluntaryExits: []*pb.VoluntaryExit{
+				{ValidatorIndex: 3},
+			},
+		},
+	}
+
+	sets, err := blocks.BlockSignatureSets(beaconState, block)
+	if err != nil {
+		t.Fatalf("Could not collect block signatures: %v", err)
+	}
+	var names []string
+	for _, set := range sets {
+		names = append(names, set.Name)
+	}
+	want := []string{
+		"block proposer",
+		"block randao reveal",
+		"proposer slashing",
+		"proposer slashing",
+		"slashable attestation",
+		"slashable attestation",
+		"attestation",
+		"voluntary exit",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected signature sets %v, received %v", want, names)
+	}
+	if !bytes.Equal(sets[7].PublicKeys[0], beaconState.ValidatorRegistry[3].Pubkey) {
+		t.Errorf("Expected the exit to be signed by validator 3, received pubkey %#x", sets[7].PublicKeys[0])
+	}
+
+	block.Body.VoluntaryExits[0].ValidatorIndex = 100
+	wantErr := "validator index 100 is not in the registry"
+	if _, err := blocks.BlockSignatureSets(beaconState, block); err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("Expected %v, received %v", wantErr, err)
+	}
+}
@@ -21,6 +21,16 @@ import (
 
 var log = logrus.WithField("prefix", "core/state")
 
+// SignatureError is returned by ExecuteStateTransition when the signatures of the block
+// fail verification.
+type SignatureError struct {
+	err error
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("could not verify block signatures: %v", e.err)
+}
+
 // ExecuteStateTransition defines the procedure for a state transition function.
 // Spec pseudocode definition:
 //  We now define the state transition function. At a high level the state transition is made up of three parts:
@@ -42,9 +52,15 @@ func ExecuteStateTransition(
 	// Execute per slot transition.
 	state = ProcessSlot(state, headRoot)
 
-	// Execute per block transition.
+	// Execute per block transition. The block's signatures are verified beforehand,
+	// instead of while processing the block.
 	if block != nil {
-		state, err = ProcessBlock(state, block, verifySignatures)
+		if verifySignatures {
+			if err := b.VerifyBlockSignatures(state, block); err != nil {
+				return nil, &SignatureError{err: err}
+			}
+		}
+		state, err = ProcessBlock(state, block)
 		if err != nil {
 			return nil, fmt.Errorf("could not process block: %v", err)
 		}
@@ -78,8 +94,9 @@ func ProcessSlot(state *pb.BeaconState, headRoot [32]byte) *pb.BeaconState {
 
 // ProcessBlock creates a new, modified beacon state by applying block operation
 // transformations as defined in the Ethereum Serenity specification, including processing proposer slashings,
-// processing block attestations, and more.
-func ProcessBlock(state *pb.BeaconState, block *pb.BeaconBlock, verifySignatures bool) (*pb.BeaconState, error) {
+// processing block attestations, and more. The block's signatures are not verified here,
+// see blocks.VerifyBlockSignatures.
+func ProcessBlock(state *pb.BeaconState, block *pb.BeaconBlock) (*pb.BeaconState, error) {
 	r, err := hashutil.HashProto(block)
 	if err != nil {
 		return nil, fmt.Errorf("could not hash block: %v", err)
@@ -96,36 +113,27 @@ func ProcessBlock(state *pb.BeaconState, block *pb.BeaconBlock, verifySignatures
 	}
 	log.WithField("blockRoot", fmt.Sprintf("%#x", r)).Debugf("Verified block slot == state slot")
 
-	// Verify block signature.
-	if verifySignatures {
-		// TODO(#781): Verify Proposer Signature.
-		if err := b.VerifyProposerSignature(block); err != nil {
-			return nil, fmt.Errorf("could not verify proposer signature: %v", err)
-		}
-	}
-	log.WithField("blockRoot", fmt.Sprintf("%#x", r)).Debugf("Verified block signature")
-
-	// Verify block RANDAO.
-	state, err = b.ProcessBlockRandao(state, block, verifySignatures)
+	// Process block RANDAO.
+	state, err = b.ProcessBlockRandao(state, block)
 	if err != nil {
-		return nil, fmt.Errorf("could not verify and process block randao: %v", err)
+		return nil, fmt.Errorf("could not process block randao: %v", err)
 	}
-	log.WithField("blockRoot", fmt.Sprintf("%#x", r)).Debugf("Verified and processed block RANDAO")
+	log.WithField("blockRoot", fmt.Sprintf("%#x", r)).Debugf("Processed block RANDAO")
 
 	// Process ETH1 data.
 	state = b.ProcessEth1Data(state, block)
-	state, err = b.ProcessAttesterSlashings(state, block, verifySignatures)
+	state, err = b.ProcessAttesterSlashings(state, block)
 	if err != nil {
 		return nil, fmt.Errorf("could not verify block attester slashings: %v", err)
 	}
 	log.WithField("blockRoot", fmt.Sprintf("%#x", r)).Debugf("Processed ETH1 data")
 
-	state, err = b.ProcessProposerSlashings(state, block, verifySignatures)
+	state, err = b.ProcessProposerSlashings(state, block)
 	if err != nil {
 		return nil, fmt.Errorf("could not verify block proposer slashings: %v", err)
 	}
 
-	state, err = b.ProcessBlockAttestations(state, block, verifySignatures)
+	state, err = b.ProcessBlockAttestations(state, block)
 	if err != nil {
 		return nil, fmt.Errorf("could not process block attestations: %v", err)
 	}
@@ -134,7 +142,7 @@ func ProcessBlock(state *pb.BeaconState, block *pb.BeaconBlock, verifySignatures
 	if err != nil {
 		return nil, fmt.Errorf("could not process block validator deposits: %v", err)
 	}
-	state, err = b.ProcessValidatorExits(state, block, verifySignatures)
+	state, err = b.ProcessValidatorExits(state, block)
 	if err != nil {
 		return nil, fmt.Errorf("could not process validator exits: %v", err)
 	}
@@ -3,8 +3,8 @@ package db
 import (
 	"fmt"
 
-	"github.com/boltdb/bolt"
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
@@ -17,10 +17,13 @@ func (db *BeaconDB) SaveAttestation(attestation *pb.Attestation) error {
 	}
 	hash := hashutil.Hash(encodedState)
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		a := tx.Bucket(attestationBucket)
 
-		return a.Put(hash[:], encodedState)
+		if err := a.Put(hash[:], encodedState); err != nil {
+			return err
+		}
+		return indexAttestation(tx.Bucket(attestationSlotIndexBucket), tx.Bucket(attestationBlockIndexBucket), attestation, hash[:])
 	})
 }
 
@@ -31,17 +34,20 @@ func (db *BeaconDB) DeleteAttestation(attestation *pb.Attestation) error {
 		return err
 	}
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		a := tx.Bucket(attestationBucket)
 
-		return a.Delete(hash[:])
+		if err := a.Delete(hash[:]); err != nil {
+			return err
+		}
+		return unindexAttestation(tx.Bucket(attestationSlotIndexBucket), tx.Bucket(attestationBlockIndexBucket), attestation, hash[:])
 	})
 }
 
 // Attestation retrieves an attestation record from the db using its hash.
 func (db *BeaconDB) Attestation(hash [32]byte) (*pb.Attestation, error) {
 	var attestation *pb.Attestation
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		a := tx.Bucket(attestationBucket)
 
 		enc := a.Get(hash[:])
@@ -61,7 +67,7 @@ func (db *BeaconDB) Attestation(hash [32]byte) (*pb.Attestation, error) {
 // These are the attestations that have not been seen on the beacon chain.
 func (db *BeaconDB) Attestations() ([]*pb.Attestation, error) {
 	var attestations []*pb.Attestation
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		a := tx.Bucket(attestationBucket)
 
 		if err := a.ForEach(func(k, v []byte) error {
@@ -84,7 +90,7 @@ func (db *BeaconDB) Attestations() ([]*pb.Attestation, error) {
 func (db *BeaconDB) HasAttestation(hash [32]byte) bool {
 	exists := false
 	// #nosec G104
-	db.view(func(tx *bolt.Tx) error {
+	db.view(func(tx storage.Tx) error {
 		a := tx.Bucket(attestationBucket)
 
 		exists = a.Get(hash[:]) != nil
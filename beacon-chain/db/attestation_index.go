@@ -0,0 +1,162 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// The attestation indexes map the slot and shard, or the beacon block root, of the
+// attestation data to the hashes of the saved attestations. The slot and shard are encoded
+// big-endian, unlike the slot keys of the main chain, so that the slot index is ordered by
+// slot and then by shard, and a slot range is read with a single seek.
+
+func attestationSlotPrefix(slot uint64) []byte {
+	prefix := make([]byte, 8)
+	binary.BigEndian.PutUint64(prefix, slot)
+	return prefix
+}
+
+func attestationShardPrefix(slot uint64, shard uint64) []byte {
+	prefix := make([]byte, 16)
+	binary.BigEndian.PutUint64(prefix, slot)
+	binary.BigEndian.PutUint64(prefix[8:], shard)
+	return prefix
+}
+
+func attestationBlockPrefix(blockRoot []byte) []byte {
+	root := bytesutil.ToBytes32(blockRoot)
+	return root[:]
+}
+
+// indexAttestation records the attestation in the slot and block indexes.
+func indexAttestation(slotIndex storage.Bucket, blockIndex storage.Bucket, attestation *pb.Attestation, hash []byte) error {
+	data := attestation.GetData()
+	if err := slotIndex.Put(blockIndexKey(attestationShardPrefix(data.GetSlot(), data.GetShard()), hash), []byte{}); err != nil {
+		return fmt.Errorf("failed to index attestation by slot: %v", err)
+	}
+	if err := blockIndex.Put(blockIndexKey(attestationBlockPrefix(data.GetBeaconBlockRootHash32()), hash), []byte{}); err != nil {
+		return fmt.Errorf("failed to index attestation by block: %v", err)
+	}
+	return nil
+}
+
+// unindexAttestation removes the attestation from the slot and block indexes.
+func unindexAttestation(slotIndex storage.Bucket, blockIndex storage.Bucket, attestation *pb.Attestation, hash []byte) error {
+	data := attestation.GetData()
+	if err := slotIndex.Delete(blockIndexKey(attestationShardPrefix(data.GetSlot(), data.GetShard()), hash)); err != nil {
+		return fmt.Errorf("failed to remove attestation from slot index: %v", err)
+	}
+	if err := blockIndex.Delete(blockIndexKey(attestationBlockPrefix(data.GetBeaconBlockRootHash32()), hash)); err != nil {
+		return fmt.Errorf("failed to remove attestation from block index: %v", err)
+	}
+	return nil
+}
+
+// indexedAttestations returns the attestations recorded in the index from the seek key
+// onwards, for as long as their index key satisfies the condition. At most limit attestations
+// are returned, unless the limit is 0.
+func indexedAttestations(index storage.Bucket, attestationBkt storage.Bucket, seek []byte, prefixLen int, limit int, cond func(k []byte) bool) ([]*pb.Attestation, error) {
+	var attestations []*pb.Attestation
+	c := index.Cursor()
+	for k, _ := c.Seek(seek); k != nil && cond(k); k, _ = c.Next() {
+		if limit > 0 && len(attestations) == limit {
+			break
+		}
+		hash := k[prefixLen:]
+		enc := attestationBkt.Get(hash)
+		if enc == nil {
+			return nil, fmt.Errorf("indexed attestation not found: %#x", hash)
+		}
+		attestation, err := createAttestation(enc)
+		if err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, attestation)
+	}
+	return attestations, nil
+}
+
+// AttestationsBySlotRange returns the saved attestations whose data slot is within the
+// given range, bounds included. The attestations are ordered by slot, then by shard.
+func (db *BeaconDB) AttestationsBySlotRange(startSlot uint64, endSlot uint64) ([]*pb.Attestation, error) {
+	var attestations []*pb.Attestation
+	if startSlot > endSlot {
+		return attestations, nil
+	}
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		attestations, err = indexedAttestations(tx.Bucket(attestationSlotIndexBucket), tx.Bucket(attestationBucket),
+			attestationSlotPrefix(startSlot), 16, 0, func(k []byte) bool {
+				return binary.BigEndian.Uint64(k[:8]) <= endSlot
+			})
+		return err
+	})
+	return attestations, err
+}
+
+// AttestationsFromSlot returns up to limit saved attestations whose data slot is at or after
+// the given slot. The attestations are ordered by slot, then by shard.
+func (db *BeaconDB) AttestationsFromSlot(startSlot uint64, limit int) ([]*pb.Attestation, error) {
+	var attestations []*pb.Attestation
+	if limit <= 0 {
+		return attestations, nil
+	}
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		attestations, err = indexedAttestations(tx.Bucket(attestationSlotIndexBucket), tx.Bucket(attestationBucket),
+			attestationSlotPrefix(startSlot), 16, limit, func(k []byte) bool {
+				return true
+			})
+		return err
+	})
+	return attestations, err
+}
+
+// AttestationsForShard returns the saved attestations of the given slot and shard.
+func (db *BeaconDB) AttestationsForShard(slot uint64, shard uint64) ([]*pb.Attestation, error) {
+	var attestations []*pb.Attestation
+	prefix := attestationShardPrefix(slot, shard)
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		attestations, err = indexedAttestations(tx.Bucket(attestationSlotIndexBucket), tx.Bucket(attestationBucket),
+			prefix, len(prefix), 0, func(k []byte) bool {
+				return bytes.HasPrefix(k, prefix)
+			})
+		return err
+	})
+	return attestations, err
+}
+
+// AttestationsForBlock returns the saved attestations which vote for the block with the
+// given root as the head of the beacon chain.
+func (db *BeaconDB) AttestationsForBlock(blockRoot [32]byte) ([]*pb.Attestation, error) {
+	var attestations []*pb.Attestation
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		attestations, err = indexedAttestations(tx.Bucket(attestationBlockIndexBucket), tx.Bucket(attestationBucket),
+			blockRoot[:], len(blockRoot), 0, func(k []byte) bool {
+				return bytes.HasPrefix(k, blockRoot[:])
+			})
+		return err
+	})
+	return attestations, err
+}
+
+// migrateAttestationIndexes builds the slot and block indexes of the attestations saved
+// before the indexes existed.
+func migrateAttestationIndexes(tx storage.Tx) error {
+	slotIndex := tx.Bucket(attestationSlotIndexBucket)
+	blockIndex := tx.Bucket(attestationBlockIndexBucket)
+	return tx.Bucket(attestationBucket).ForEach(func(hash, enc []byte) error {
+		attestation, err := createAttestation(enc)
+		if err != nil {
+			return err
+		}
+		return indexAttestation(slotIndex, blockIndex, attestation, hash)
+	})
+}
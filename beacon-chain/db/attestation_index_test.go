@@ -0,0 +1,151 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestAttestationsBySlotRange_OrderedBySlotAndShard(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	// Save the attestations out of order, with slots whose little-endian encodings
+	// would not sort by slot.
+	attestations := []*pb.Attestation{
+		{Data: &pb.AttestationData{Slot: 256, Shard: 1}},
+		{Data: &pb.AttestationData{Slot: 1, Shard: 2}},
+		{Data: &pb.AttestationData{Slot: 256, Shard: 0}},
+		{Data: &pb.AttestationData{Slot: 2, Shard: 0}},
+		{Data: &pb.AttestationData{Slot: 300, Shard: 0}},
+	}
+	for _, attestation := range attestations {
+		if err := db.SaveAttestation(attestation); err != nil {
+			t.Fatalf("failed to save attestation: %v", err)
+		}
+	}
+
+	retrieved, err := db.AttestationsBySlotRange(2, 256)
+	if err != nil {
+		t.Fatalf("failed to get attestations by slot range: %v", err)
+	}
+	want := []struct{ slot, shard uint64 }{{2, 0}, {256, 0}, {256, 1}}
+	if len(retrieved) != len(want) {
+		t.Fatalf("expected %d attestations, received %d", len(want), len(retrieved))
+	}
+	for i, w := range want {
+		if retrieved[i].Data.Slot != w.slot || retrieved[i].Data.Shard != w.shard {
+			t.Errorf("expected attestation %d at slot %d and shard %d, received slot %d and shard %d",
+				i, w.slot, w.shard, retrieved[i].Data.Slot, retrieved[i].Data.Shard)
+		}
+	}
+
+	retrieved, err = db.AttestationsForShard(256, 1)
+	if err != nil {
+		t.Fatalf("failed to get attestations for shard: %v", err)
+	}
+	if len(retrieved) != 1 || retrieved[0].Data.Slot != 256 || retrieved[0].Data.Shard != 1 {
+		t.Errorf("expected the attestation at slot 256 and shard 1, received %v", retrieved)
+	}
+}
+
+func TestAttestationsFromSlot_StopsAtLimit(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	for _, slot := range []uint64{300, 1, 256, 2} {
+		if err := db.SaveAttestation(&pb.Attestation{Data: &pb.AttestationData{Slot: slot}}); err != nil {
+			t.Fatalf("failed to save attestation: %v", err)
+		}
+	}
+
+	retrieved, err := db.AttestationsFromSlot(2, 2)
+	if err != nil {
+		t.Fatalf("failed to get attestations from slot: %v", err)
+	}
+	if len(retrieved) != 2 || retrieved[0].Data.Slot != 2 || retrieved[1].Data.Slot != 256 {
+		t.Errorf("expected the attestations at slots 2 and 256, received %v", retrieved)
+	}
+}
+
+func TestAttestationsForBlock_ReturnsVotesForTheBlock(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	blockRoot := [32]byte{'A'}
+	vote1 := &pb.Attestation{Data: &pb.AttestationData{Slot: 1, BeaconBlockRootHash32: blockRoot[:]}}
+	vote2 := &pb.Attestation{Data: &pb.AttestationData{Slot: 2, BeaconBlockRootHash32: blockRoot[:]}}
+	other := &pb.Attestation{Data: &pb.AttestationData{Slot: 1, BeaconBlockRootHash32: []byte{'B'}}}
+	for _, attestation := range []*pb.Attestation{vote1, vote2, other} {
+		if err := db.SaveAttestation(attestation); err != nil {
+			t.Fatalf("failed to save attestation: %v", err)
+		}
+	}
+
+	retrieved, err := db.AttestationsForBlock(blockRoot)
+	if err != nil {
+		t.Fatalf("failed to get attestations for block: %v", err)
+	}
+	if len(retrieved) != 2 {
+		t.Fatalf("expected 2 attestations for the block, received %d", len(retrieved))
+	}
+
+	if err := db.DeleteAttestation(vote1); err != nil {
+		t.Fatalf("failed to delete attestation: %v", err)
+	}
+	retrieved, err = db.AttestationsForBlock(blockRoot)
+	if err != nil {
+		t.Fatalf("failed to get attestations for block: %v", err)
+	}
+	if len(retrieved) != 1 || retrieved[0].Data.Slot != 2 {
+		t.Errorf("expected the remaining attestation for the block, received %v", retrieved)
+	}
+	retrieved, err = db.AttestationsBySlotRange(1, 1)
+	if err != nil {
+		t.Fatalf("failed to get attestations by slot range: %v", err)
+	}
+	if len(retrieved) != 1 {
+		t.Errorf("expected deleted attestation to be removed from the slot index, received %d attestations", len(retrieved))
+	}
+}
+
+func TestMigrateAttestationIndexes_IndexesExistingAttestations(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	blockRoot := [32]byte{'A'}
+	attestation := &pb.Attestation{Data: &pb.AttestationData{Slot: 5, Shard: 3, BeaconBlockRootHash32: blockRoot[:]}}
+	if err := db.SaveAttestation(attestation); err != nil {
+		t.Fatalf("failed to save attestation: %v", err)
+	}
+	// Drop the indexes, as a DB written before they existed would not have them.
+	if err := db.update(func(tx storage.Tx) error {
+		for _, bucket := range [][]byte{attestationSlotIndexBucket, attestationBlockIndexBucket} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return migrateAttestationIndexes(tx)
+	}); err != nil {
+		t.Fatalf("failed to migrate attestation indexes: %v", err)
+	}
+
+	retrieved, err := db.AttestationsForShard(5, 3)
+	if err != nil {
+		t.Fatalf("failed to get attestations for shard: %v", err)
+	}
+	if len(retrieved) != 1 {
+		t.Errorf("expected the attestation to be indexed by slot, received %d attestations", len(retrieved))
+	}
+	retrieved, err = db.AttestationsForBlock(blockRoot)
+	if err != nil {
+		t.Fatalf("failed to get attestations for block: %v", err)
+	}
+	if len(retrieved) != 1 {
+		t.Errorf("expected the attestation to be indexed by block, received %d attestations", len(retrieved))
+	}
+}
@@ -1,13 +1,15 @@
 package db
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 
-	"github.com/boltdb/bolt"
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
@@ -24,7 +26,7 @@ func createBlock(enc []byte) (*pb.BeaconBlock, error) {
 // Returns nil if the block does not exist.
 func (db *BeaconDB) Block(root [32]byte) (*pb.BeaconBlock, error) {
 	var block *pb.BeaconBlock
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		bucket := tx.Bucket(blockBucket)
 
 		enc := bucket.Get(root[:])
@@ -44,7 +46,7 @@ func (db *BeaconDB) Block(root [32]byte) (*pb.BeaconBlock, error) {
 func (db *BeaconDB) HasBlock(root [32]byte) bool {
 	hasBlock := false
 	// #nosec G104
-	_ = db.view(func(tx *bolt.Tx) error {
+	_ = db.view(func(tx storage.Tx) error {
 		bucket := tx.Bucket(blockBucket)
 
 		hasBlock = bucket.Get(root[:]) != nil
@@ -55,7 +57,8 @@ func (db *BeaconDB) HasBlock(root [32]byte) bool {
 	return hasBlock
 }
 
-// SaveBlock accepts a block and writes it to disk.
+// SaveBlock accepts a block and writes it to disk, along with its entries in the
+// slot and parent indexes.
 func (db *BeaconDB) SaveBlock(block *pb.BeaconBlock) error {
 	root, err := hashutil.HashBeaconBlock(block)
 	if err != nil {
@@ -66,17 +69,20 @@ func (db *BeaconDB) SaveBlock(block *pb.BeaconBlock) error {
 		return fmt.Errorf("failed to encode block: %v", err)
 	}
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		bucket := tx.Bucket(blockBucket)
 
-		return bucket.Put(root[:], enc)
+		if err := bucket.Put(root[:], enc); err != nil {
+			return err
+		}
+		return indexBlock(tx.Bucket(blockSlotIndexBucket), tx.Bucket(blockParentIndexBucket), block, root[:])
 	})
 }
 
 // ChainHead returns the head of the main chain.
 func (db *BeaconDB) ChainHead() (*pb.BeaconBlock, error) {
 	var block *pb.BeaconBlock
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
 		mainChain := tx.Bucket(mainChainBucket)
 		blockBkt := tx.Bucket(blockBucket)
@@ -105,21 +111,17 @@ func (db *BeaconDB) ChainHead() (*pb.BeaconBlock, error) {
 }
 
 // UpdateChainHead atomically updates the head of the chain as well as the corresponding state changes
-// Including a new crystallized state is optional.
+// Including a new crystallized state is optional. If the new head is not a descendant of the
+// previous head, the canonical slot to block root index is rewritten to reflect the new branch.
 func (db *BeaconDB) UpdateChainHead(block *pb.BeaconBlock, beaconState *pb.BeaconState) error {
 	blockRoot, err := hashutil.HashBeaconBlock(block)
 	if err != nil {
 		return fmt.Errorf("unable to tree hash block: %v", err)
 	}
 
-	beaconStateEnc, err := proto.Marshal(beaconState)
-	if err != nil {
-		return fmt.Errorf("unable to encode beacon state: %v", err)
-	}
-
 	slotBinary := encodeSlotNumber(block.Slot)
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		blockBucket := tx.Bucket(blockBucket)
 		chainInfo := tx.Bucket(chainInfoBucket)
 		mainChain := tx.Bucket(mainChainBucket)
@@ -128,7 +130,7 @@ func (db *BeaconDB) UpdateChainHead(block *pb.BeaconBlock, beaconState *pb.Beaco
 			return fmt.Errorf("expected block %#x to have already been saved before updating head: %v", blockRoot, err)
 		}
 
-		if err := mainChain.Put(slotBinary, blockRoot[:]); err != nil {
+		if err := updateMainChain(blockBucket, chainInfo, mainChain, block, blockRoot); err != nil {
 			return fmt.Errorf("failed to include the block in the main chain bucket: %v", err)
 		}
 
@@ -136,20 +138,95 @@ func (db *BeaconDB) UpdateChainHead(block *pb.BeaconBlock, beaconState *pb.Beaco
 			return fmt.Errorf("failed to record the block as the head of the main chain: %v", err)
 		}
 
-		if err := chainInfo.Put(stateLookupKey, beaconStateEnc); err != nil {
+		beaconStateEnc, err := saveStateChunks(tx, beaconState)
+		if err != nil {
+			return fmt.Errorf("unable to encode beacon state: %v", err)
+		}
+		if err := putStoredState(tx, chainInfo, stateLookupKey, beaconStateEnc); err != nil {
 			return fmt.Errorf("failed to save beacon state as canonical: %v", err)
 		}
+
+		if err := syncValidatorIndices(tx, beaconState.ValidatorRegistry); err != nil {
+			return fmt.Errorf("failed to update validator indices: %v", err)
+		}
+
+		if err := saveStateSnapshot(tx, block, blockRoot, beaconStateEnc); err != nil {
+			return fmt.Errorf("failed to save state snapshot: %v", err)
+		}
 		return nil
 	})
 }
 
+// updateMainChain records the new head block in the main chain bucket. When the new head
+// does not directly extend the previous head, it walks back through the new head's ancestors
+// until it reaches a block which is already canonical, and replaces every main chain entry
+// above that common ancestor with the blocks of the new branch.
+func updateMainChain(blockBkt storage.Bucket, chainInfo storage.Bucket, mainChain storage.Bucket, head *pb.BeaconBlock, headRoot [32]byte) error {
+	var prevHeadRoot []byte
+	if height := chainInfo.Get(mainChainHeightKey); height != nil {
+		prevHeadRoot = mainChain.Get(height)
+	}
+	// The common case, the new head simply extends the previous head.
+	if prevHeadRoot != nil && bytes.Equal(prevHeadRoot, head.ParentRootHash32) {
+		return mainChain.Put(encodeSlotNumber(head.Slot), headRoot[:])
+	}
+
+	branch := map[uint64][]byte{head.Slot: headRoot[:]}
+	ancestorSlot := head.Slot
+	current := head
+	for {
+		parentEnc := blockBkt.Get(current.ParentRootHash32)
+		if parentEnc == nil {
+			break
+		}
+		parent, err := createBlock(parentEnc)
+		if err != nil {
+			return err
+		}
+		if parent.Slot >= current.Slot {
+			return fmt.Errorf("parent block at slot %d is not lower than child slot %d", parent.Slot, current.Slot)
+		}
+		ancestorSlot = parent.Slot
+		if bytes.Equal(mainChain.Get(encodeSlotNumber(parent.Slot)), current.ParentRootHash32) {
+			break
+		}
+		branch[parent.Slot] = current.ParentRootHash32
+		current = parent
+	}
+
+	// Remove the entries of the previous branch which are not part of the new one.
+	var staleKeys [][]byte
+	if err := mainChain.ForEach(func(k, v []byte) error {
+		slot := decodeToSlotNumber(k)
+		if _, ok := branch[slot]; ok || slot <= ancestorSlot {
+			return nil
+		}
+		staleKeys = append(staleKeys, k)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, k := range staleKeys {
+		if err := mainChain.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	for slot, root := range branch {
+		if err := mainChain.Put(encodeSlotNumber(slot), root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // BlockBySlot accepts a slot number and returns the corresponding block in the main chain.
 // Returns nil if a block was not recorded for the given slot.
 func (db *BeaconDB) BlockBySlot(slot uint64) (*pb.BeaconBlock, error) {
 	var block *pb.BeaconBlock
 	slotEnc := encodeSlotNumber(slot)
 
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		mainChain := tx.Bucket(mainChainBucket)
 		blockBkt := tx.Bucket(blockBucket)
 
@@ -170,3 +247,107 @@ func (db *BeaconDB) BlockBySlot(slot uint64) (*pb.BeaconBlock, error) {
 
 	return block, err
 }
+
+// DeleteNonCanonicalBlocks removes every block within the given slot range, bounds included,
+// which is not part of the main chain, as such blocks conflict with the finalized chain and
+// can never become canonical. The blocks are looked up in the slot index, so only the slots
+// of the range are visited. The genesis block is always kept. Returns the number of deleted
+// blocks.
+func (db *BeaconDB) DeleteNonCanonicalBlocks(startSlot uint64, endSlot uint64) (int, error) {
+	var deleted int
+	err := db.update(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		mainChain := tx.Bucket(mainChainBucket)
+		snapshots := tx.Bucket(stateSnapshotBucket)
+		slotIndex := tx.Bucket(blockSlotIndexBucket)
+		parentIndex := tx.Bucket(blockParentIndexBucket)
+
+		var stale [][]byte
+		var staleBlocks []*pb.BeaconBlock
+		for slot := startSlot; slot <= endSlot; slot++ {
+			if slot == params.BeaconConfig().GenesisSlot {
+				continue
+			}
+			prefix := encodeSlotNumber(slot)
+			canonicalRoot := mainChain.Get(prefix)
+			c := slotIndex.Cursor()
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				root := k[len(prefix):]
+				if bytes.Equal(canonicalRoot, root) {
+					continue
+				}
+				enc := blockBkt.Get(root)
+				if enc == nil {
+					return fmt.Errorf("indexed block not found: %#x", root)
+				}
+				block, err := createBlock(enc)
+				if err != nil {
+					return err
+				}
+				stale = append(stale, append([]byte{}, root...))
+				staleBlocks = append(staleBlocks, block)
+			}
+		}
+
+		for i, root := range stale {
+			if err := blockBkt.Delete(root); err != nil {
+				return fmt.Errorf("failed to delete block %#x: %v", root, err)
+			}
+			if err := unindexBlock(slotIndex, parentIndex, staleBlocks[i], root); err != nil {
+				return err
+			}
+			if err := deleteStoredState(tx, snapshots, root); err != nil {
+				return fmt.Errorf("failed to delete state snapshot of block %#x: %v", root, err)
+			}
+		}
+		deleted = len(stale)
+		return nil
+	})
+	return deleted, err
+}
+
+// JustifiedBlock retrieves the block at the most recently justified epoch boundary,
+// which is the starting point of the fork-choice rule.
+// Returns nil if no justified block has been recorded.
+func (db *BeaconDB) JustifiedBlock() (*pb.BeaconBlock, error) {
+	var block *pb.BeaconBlock
+	err := db.view(func(tx storage.Tx) error {
+		chainInfo := tx.Bucket(chainInfoBucket)
+		blockBkt := tx.Bucket(blockBucket)
+
+		blockRoot := chainInfo.Get(justifiedBlockLookupKey)
+		if blockRoot == nil {
+			return nil
+		}
+
+		enc := blockBkt.Get(blockRoot)
+		if enc == nil {
+			return fmt.Errorf("justified block not found: %#x", blockRoot)
+		}
+
+		var err error
+		block, err = createBlock(enc)
+		return err
+	})
+
+	return block, err
+}
+
+// SaveJustifiedBlock records the root of the block at the most recently justified epoch boundary.
+// The block itself is expected to have already been saved.
+func (db *BeaconDB) SaveJustifiedBlock(block *pb.BeaconBlock) error {
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		return fmt.Errorf("unable to tree hash block: %v", err)
+	}
+
+	return db.update(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		chainInfo := tx.Bucket(chainInfoBucket)
+
+		if blockBkt.Get(blockRoot[:]) == nil {
+			return fmt.Errorf("expected justified block %#x to have already been saved", blockRoot)
+		}
+		return chainInfo.Put(justifiedBlockLookupKey, blockRoot[:])
+	})
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// The block indexes map a slot, or a parent block root, to the roots of the saved blocks
+// with that slot or parent. Each indexed block is recorded under the indexed value followed
+// by the block root, so the blocks sharing a value are adjacent and found with a single seek.
+
+func blockIndexKey(prefix []byte, root []byte) []byte {
+	return append(append([]byte{}, prefix...), root...)
+}
+
+func parentIndexPrefix(parentRoot []byte) []byte {
+	root := bytesutil.ToBytes32(parentRoot)
+	return root[:]
+}
+
+// indexBlock records the block in the slot and parent indexes.
+func indexBlock(slotIndex storage.Bucket, parentIndex storage.Bucket, block *pb.BeaconBlock, root []byte) error {
+	if err := slotIndex.Put(blockIndexKey(encodeSlotNumber(block.Slot), root), []byte{}); err != nil {
+		return fmt.Errorf("failed to index block by slot: %v", err)
+	}
+	if err := parentIndex.Put(blockIndexKey(parentIndexPrefix(block.ParentRootHash32), root), []byte{}); err != nil {
+		return fmt.Errorf("failed to index block by parent: %v", err)
+	}
+	return nil
+}
+
+// unindexBlock removes the block from the slot and parent indexes.
+func unindexBlock(slotIndex storage.Bucket, parentIndex storage.Bucket, block *pb.BeaconBlock, root []byte) error {
+	if err := slotIndex.Delete(blockIndexKey(encodeSlotNumber(block.Slot), root)); err != nil {
+		return fmt.Errorf("failed to remove block from slot index: %v", err)
+	}
+	if err := parentIndex.Delete(blockIndexKey(parentIndexPrefix(block.ParentRootHash32), root)); err != nil {
+		return fmt.Errorf("failed to remove block from parent index: %v", err)
+	}
+	return nil
+}
+
+// indexedBlocks returns the blocks recorded in the index under the given prefix, in block
+// root order.
+func indexedBlocks(index storage.Bucket, blockBkt storage.Bucket, prefix []byte) ([]*pb.BeaconBlock, error) {
+	var blocks []*pb.BeaconBlock
+	c := index.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		root := k[len(prefix):]
+		enc := blockBkt.Get(root)
+		if enc == nil {
+			return nil, fmt.Errorf("indexed block not found: %#x", root)
+		}
+		block, err := createBlock(enc)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// BlocksBySlot returns every saved block with the given slot, whether or not it is part of
+// the main chain. Returns an empty list if no block was saved for the slot.
+func (db *BeaconDB) BlocksBySlot(slot uint64) ([]*pb.BeaconBlock, error) {
+	var blocks []*pb.BeaconBlock
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		blocks, err = indexedBlocks(tx.Bucket(blockSlotIndexBucket), tx.Bucket(blockBucket), encodeSlotNumber(slot))
+		return err
+	})
+	return blocks, err
+}
+
+// ChildrenOf returns every saved block which has the block with the given root as its parent.
+// Returns an empty list if the block has no known children.
+func (db *BeaconDB) ChildrenOf(root [32]byte) ([]*pb.BeaconBlock, error) {
+	var blocks []*pb.BeaconBlock
+	err := db.view(func(tx storage.Tx) error {
+		var err error
+		blocks, err = indexedBlocks(tx.Bucket(blockParentIndexBucket), tx.Bucket(blockBucket), root[:])
+		return err
+	})
+	return blocks, err
+}
+
+// migrateBlockIndexes builds the slot and parent indexes of the blocks saved before the
+// indexes existed.
+func migrateBlockIndexes(tx storage.Tx) error {
+	slotIndex := tx.Bucket(blockSlotIndexBucket)
+	parentIndex := tx.Bucket(blockParentIndexBucket)
+	return tx.Bucket(blockBucket).ForEach(func(root, enc []byte) error {
+		block, err := createBlock(enc)
+		if err != nil {
+			return err
+		}
+		return indexBlock(slotIndex, parentIndex, block, root)
+	})
+}
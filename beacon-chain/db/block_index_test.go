@@ -0,0 +1,195 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func blockRoots(t *testing.T, blocks []*pb.BeaconBlock) map[[32]byte]bool {
+	roots := make(map[[32]byte]bool, len(blocks))
+	for _, block := range blocks {
+		root, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[root] = true
+	}
+	return roots
+}
+
+func TestBlocksBySlot_ReturnsEveryBlockOfTheSlot(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	slot := params.BeaconConfig().GenesisSlot + 1
+	block1 := &pb.BeaconBlock{Slot: slot, RandaoReveal: []byte("A")}
+	block2 := &pb.BeaconBlock{Slot: slot, RandaoReveal: []byte("B")}
+	other := &pb.BeaconBlock{Slot: slot + 1}
+	for _, block := range []*pb.BeaconBlock{block1, block2, other} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+	}
+
+	blocks, err := db.BlocksBySlot(slot)
+	if err != nil {
+		t.Fatalf("failed to get blocks by slot: %v", err)
+	}
+	roots := blockRoots(t, blocks)
+	want := blockRoots(t, []*pb.BeaconBlock{block1, block2})
+	if len(blocks) != 2 || len(roots) != 2 {
+		t.Fatalf("expected 2 blocks at slot %d, received %d", slot, len(blocks))
+	}
+	for root := range want {
+		if !roots[root] {
+			t.Errorf("expected block %#x at slot %d", root, slot)
+		}
+	}
+
+	blocks, err = db.BlocksBySlot(slot + 2)
+	if err != nil {
+		t.Fatalf("failed to get blocks by slot: %v", err)
+	}
+	if len(blocks) != 0 {
+		t.Errorf("expected no block at slot %d, received %d", slot+2, len(blocks))
+	}
+}
+
+func TestChildrenOf_ReturnsEveryChild(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	parent := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot}
+	parentRoot, err := hashutil.HashBeaconBlock(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child1 := &pb.BeaconBlock{Slot: parent.Slot + 1, ParentRootHash32: parentRoot[:]}
+	child2 := &pb.BeaconBlock{Slot: parent.Slot + 2, ParentRootHash32: parentRoot[:]}
+	child1Root, err := hashutil.HashBeaconBlock(child1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grandchild := &pb.BeaconBlock{Slot: parent.Slot + 3, ParentRootHash32: child1Root[:]}
+	for _, block := range []*pb.BeaconBlock{parent, child1, child2, grandchild} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+	}
+
+	children, err := db.ChildrenOf(parentRoot)
+	if err != nil {
+		t.Fatalf("failed to get children: %v", err)
+	}
+	roots := blockRoots(t, children)
+	if len(children) != 2 || len(roots) != 2 {
+		t.Fatalf("expected 2 children, received %d", len(children))
+	}
+	for root := range blockRoots(t, []*pb.BeaconBlock{child1, child2}) {
+		if !roots[root] {
+			t.Errorf("expected block %#x to be a child", root)
+		}
+	}
+
+	grandchildRoot, err := hashutil.HashBeaconBlock(grandchild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	children, err = db.ChildrenOf(grandchildRoot)
+	if err != nil {
+		t.Fatalf("failed to get children: %v", err)
+	}
+	if len(children) != 0 {
+		t.Errorf("expected no children, received %d", len(children))
+	}
+}
+
+func TestDeleteNonCanonicalBlocks_RemovesIndexEntries(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	genesis := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot}
+	if err := db.SaveBlock(genesis); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+	if err := db.UpdateChainHead(genesis, &pb.BeaconState{Slot: genesis.Slot}); err != nil {
+		t.Fatalf("failed to update chain head: %v", err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan := &pb.BeaconBlock{Slot: genesis.Slot + 1, ParentRootHash32: genesisRoot[:]}
+	if err := db.SaveBlock(orphan); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+
+	if _, err := db.DeleteNonCanonicalBlocks(orphan.Slot, orphan.Slot); err != nil {
+		t.Fatalf("failed to delete non-canonical blocks: %v", err)
+	}
+
+	blocks, err := db.BlocksBySlot(orphan.Slot)
+	if err != nil {
+		t.Fatalf("failed to get blocks by slot: %v", err)
+	}
+	if len(blocks) != 0 {
+		t.Errorf("expected deleted block to be removed from the slot index, received %d blocks", len(blocks))
+	}
+	children, err := db.ChildrenOf(genesisRoot)
+	if err != nil {
+		t.Fatalf("failed to get children: %v", err)
+	}
+	if len(children) != 0 {
+		t.Errorf("expected deleted block to be removed from the parent index, received %d children", len(children))
+	}
+}
+
+func TestMigrateBlockIndexes_IndexesExistingBlocks(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	parent := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot}
+	parentRoot, err := hashutil.HashBeaconBlock(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := &pb.BeaconBlock{Slot: parent.Slot + 1, ParentRootHash32: parentRoot[:]}
+	for _, block := range []*pb.BeaconBlock{parent, child} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+	}
+	// Drop the indexes, as a DB written before they existed would not have them.
+	if err := db.update(func(tx storage.Tx) error {
+		for _, bucket := range [][]byte{blockSlotIndexBucket, blockParentIndexBucket} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return migrateBlockIndexes(tx)
+	}); err != nil {
+		t.Fatalf("failed to migrate block indexes: %v", err)
+	}
+
+	blocks, err := db.BlocksBySlot(child.Slot)
+	if err != nil {
+		t.Fatalf("failed to get blocks by slot: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Slot != child.Slot {
+		t.Errorf("expected the child block at slot %d, received %v", child.Slot, blocks)
+	}
+	children, err := db.ChildrenOf(parentRoot)
+	if err != nil {
+		t.Fatalf("failed to get children: %v", err)
+	}
+	if len(children) != 1 || children[0].Slot != child.Slot {
+		t.Errorf("expected the child block, received %v", children)
+	}
+}
@@ -1,32 +1,88 @@
 package db
 
 import (
-	"github.com/boltdb/bolt"
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
 // SaveExit puts the exit request into the beacon chain db.
 func (db *BeaconDB) SaveExit(exit *pb.VoluntaryExit) error {
-	hash, err := hashutil.HashProto(exit)
+	return db.saveBlockOperation(exit)
+}
+
+// HasExit checks if the exit request exists.
+func (db *BeaconDB) HasExit(hash [32]byte) bool {
+	return db.hasBlockOperation(hash)
+}
+
+// Exit retrieves the exit request by its hash, or nil if it does not exist.
+func (db *BeaconDB) Exit(hash [32]byte) (*pb.VoluntaryExit, error) {
+	exit := &pb.VoluntaryExit{}
+	if ok, err := db.blockOperation(hash, exit); !ok || err != nil {
+		return nil, err
+	}
+	return exit, nil
+}
+
+// SaveProposerSlashing puts the proposer slashing into the beacon chain db.
+func (db *BeaconDB) SaveProposerSlashing(slashing *pb.ProposerSlashing) error {
+	return db.saveBlockOperation(slashing)
+}
+
+// HasProposerSlashing checks if the proposer slashing exists.
+func (db *BeaconDB) HasProposerSlashing(hash [32]byte) bool {
+	return db.hasBlockOperation(hash)
+}
+
+// ProposerSlashing retrieves the proposer slashing by its hash, or nil if it does not exist.
+func (db *BeaconDB) ProposerSlashing(hash [32]byte) (*pb.ProposerSlashing, error) {
+	slashing := &pb.ProposerSlashing{}
+	if ok, err := db.blockOperation(hash, slashing); !ok || err != nil {
+		return nil, err
+	}
+	return slashing, nil
+}
+
+// SaveAttesterSlashing puts the attester slashing into the beacon chain db.
+func (db *BeaconDB) SaveAttesterSlashing(slashing *pb.AttesterSlashing) error {
+	return db.saveBlockOperation(slashing)
+}
+
+// HasAttesterSlashing checks if the attester slashing exists.
+func (db *BeaconDB) HasAttesterSlashing(hash [32]byte) bool {
+	return db.hasBlockOperation(hash)
+}
+
+// AttesterSlashing retrieves the attester slashing by its hash, or nil if it does not exist.
+func (db *BeaconDB) AttesterSlashing(hash [32]byte) (*pb.AttesterSlashing, error) {
+	slashing := &pb.AttesterSlashing{}
+	if ok, err := db.blockOperation(hash, slashing); !ok || err != nil {
+		return nil, err
+	}
+	return slashing, nil
+}
+
+// saveBlockOperation puts the operation into the block operations bucket, keyed by its hash.
+func (db *BeaconDB) saveBlockOperation(op proto.Message) error {
+	hash, err := hashutil.HashProto(op)
 	if err != nil {
 		return err
 	}
-	encodedExit, err := proto.Marshal(exit)
+	enc, err := proto.Marshal(op)
 	if err != nil {
 		return err
 	}
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		a := tx.Bucket(blockOperationsBucket)
-		return a.Put(hash[:], encodedExit)
+		return a.Put(hash[:], enc)
 	})
 }
 
-// HasExit checks if the exit request exists.
-func (db *BeaconDB) HasExit(hash [32]byte) bool {
+func (db *BeaconDB) hasBlockOperation(hash [32]byte) bool {
 	exists := false
-	if err := db.view(func(tx *bolt.Tx) error {
+	if err := db.view(func(tx storage.Tx) error {
 		b := tx.Bucket(blockOperationsBucket)
 		exists = b.Get(hash[:]) != nil
 		return nil
@@ -35,3 +91,18 @@ func (db *BeaconDB) HasExit(hash [32]byte) bool {
 	}
 	return exists
 }
+
+// blockOperation decodes the operation with the given hash into op. It returns false if
+// the operation does not exist.
+func (db *BeaconDB) blockOperation(hash [32]byte, op proto.Message) (bool, error) {
+	exists := false
+	err := db.view(func(tx storage.Tx) error {
+		enc := tx.Bucket(blockOperationsBucket).Get(hash[:])
+		if enc == nil {
+			return nil
+		}
+		exists = true
+		return proto.Unmarshal(enc, op)
+	})
+	return exists, err
+}
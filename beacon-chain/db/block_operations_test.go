@@ -3,6 +3,7 @@ package db
 import (
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
@@ -30,3 +31,78 @@ func TestBeaconDB_HasExit(t *testing.T) {
 		t.Fatal("Expected HasExit to return true")
 	}
 }
+
+func TestBeaconDB_Exit(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	exit := &pb.VoluntaryExit{Epoch: 100, ValidatorIndex: 3}
+	hash, err := hashutil.HashProto(exit)
+	if err != nil {
+		t.Fatalf("could not hash exit request: %v", err)
+	}
+	retrieved, err := db.Exit(hash)
+	if err != nil {
+		t.Fatalf("failed to get exit request: %v", err)
+	}
+	if retrieved != nil {
+		t.Fatalf("expected no exit request, received %v", retrieved)
+	}
+
+	if err := db.SaveExit(exit); err != nil {
+		t.Fatalf("failed to save exit request: %v", err)
+	}
+	retrieved, err = db.Exit(hash)
+	if err != nil {
+		t.Fatalf("failed to get exit request: %v", err)
+	}
+	if !proto.Equal(exit, retrieved) {
+		t.Errorf("expected exit request %v, received %v", exit, retrieved)
+	}
+}
+
+func TestBeaconDB_Slashings(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	proposerSlashing := &pb.ProposerSlashing{ProposerIndex: 5, ProposalSignature_1: []byte("sig")}
+	proposerHash, err := hashutil.HashProto(proposerSlashing)
+	if err != nil {
+		t.Fatalf("could not hash proposer slashing: %v", err)
+	}
+	attesterSlashing := &pb.AttesterSlashing{
+		SlashableAttestation_1: &pb.SlashableAttestation{ValidatorIndices: []uint64{1, 2}},
+	}
+	attesterHash, err := hashutil.HashProto(attesterSlashing)
+	if err != nil {
+		t.Fatalf("could not hash attester slashing: %v", err)
+	}
+
+	if db.HasProposerSlashing(proposerHash) || db.HasAttesterSlashing(attesterHash) {
+		t.Fatal("expected the slashings not to exist")
+	}
+	if err := db.SaveProposerSlashing(proposerSlashing); err != nil {
+		t.Fatalf("failed to save proposer slashing: %v", err)
+	}
+	if err := db.SaveAttesterSlashing(attesterSlashing); err != nil {
+		t.Fatalf("failed to save attester slashing: %v", err)
+	}
+	if !db.HasProposerSlashing(proposerHash) || !db.HasAttesterSlashing(attesterHash) {
+		t.Fatal("expected the slashings to exist")
+	}
+
+	retrievedProposer, err := db.ProposerSlashing(proposerHash)
+	if err != nil {
+		t.Fatalf("failed to get proposer slashing: %v", err)
+	}
+	if !proto.Equal(proposerSlashing, retrievedProposer) {
+		t.Errorf("expected proposer slashing %v, received %v", proposerSlashing, retrievedProposer)
+	}
+	retrievedAttester, err := db.AttesterSlashing(attesterHash)
+	if err != nil {
+		t.Fatalf("failed to get attester slashing: %v", err)
+	}
+	if !proto.Equal(attesterSlashing, retrievedAttester) {
+		t.Errorf("expected attester slashing %v, received %v", attesterSlashing, retrievedAttester)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -118,7 +119,7 @@ func TestUpdateChainHead_OK(t *testing.T) {
 		t.Fatalf("failed to initialize state: %v", err)
 	}
 
-	block, err := db.BlockBySlot(0)
+	block, err := db.BlockBySlot(params.BeaconConfig().GenesisSlot)
 	if err != nil {
 		t.Fatalf("failed to get genesis block: %v", err)
 	}
@@ -235,3 +236,162 @@ func TestChainProgress_OK(t *testing.T) {
 		t.Fatalf("expected height to equal %d, got %d", block3.Slot, heighestBlock.Slot)
 	}
 }
+
+func TestUpdateChainHead_Reorg(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := &pb.BeaconState{}
+	genesis := &pb.BeaconBlock{Slot: 0}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockA1 := &pb.BeaconBlock{Slot: 1, ParentRootHash32: genesisRoot[:]}
+	blockA1Root, err := hashutil.HashBeaconBlock(blockA1)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockA2 := &pb.BeaconBlock{Slot: 2, ParentRootHash32: blockA1Root[:]}
+	blockB2 := &pb.BeaconBlock{Slot: 2, ParentRootHash32: genesisRoot[:], StateRootHash32: []byte{'B'}}
+	blockB2Root, err := hashutil.HashBeaconBlock(blockB2)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockB3 := &pb.BeaconBlock{Slot: 3, ParentRootHash32: blockB2Root[:]}
+
+	for _, block := range []*pb.BeaconBlock{genesis, blockA1, blockA2, blockB2, blockB3} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+	}
+	for _, block := range []*pb.BeaconBlock{genesis, blockA1, blockA2} {
+		if err := db.UpdateChainHead(block, beaconState); err != nil {
+			t.Fatalf("failed to update head: %v", err)
+		}
+	}
+	if err := db.UpdateChainHead(blockB3, beaconState); err != nil {
+		t.Fatalf("failed to update head: %v", err)
+	}
+
+	tests := []struct {
+		slot uint64
+		want *pb.BeaconBlock
+	}{
+		{slot: 0, want: genesis},
+		{slot: 1, want: nil},
+		{slot: 2, want: blockB2},
+		{slot: 3, want: blockB3},
+	}
+	for _, tt := range tests {
+		block, err := db.BlockBySlot(tt.slot)
+		if err != nil {
+			t.Fatalf("failed to retrieve block at slot %d: %v", tt.slot, err)
+		}
+		if !proto.Equal(block, tt.want) {
+			t.Errorf("expected block at slot %d to be %v, received %v", tt.slot, tt.want, block)
+		}
+	}
+	head, err := db.ChainHead()
+	if err != nil {
+		t.Fatalf("failed to get chain head: %v", err)
+	}
+	if !proto.Equal(head, blockB3) {
+		t.Errorf("expected head to be %v, received %v", blockB3, head)
+	}
+}
+
+func TestJustifiedBlock_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	genesisTime := uint64(time.Now().Unix())
+	deposits, _ := setupInitialDeposits(t, 10)
+	if err := db.InitializeState(genesisTime, deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	genesis, err := db.ChainHead()
+	if err != nil {
+		t.Fatalf("failed to get genesis block: %v", err)
+	}
+	justified, err := db.JustifiedBlock()
+	if err != nil {
+		t.Fatalf("failed to get justified block: %v", err)
+	}
+	if !proto.Equal(justified, genesis) {
+		t.Errorf("expected genesis to be the justified block, received %v", justified)
+	}
+
+	block := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot + 64}
+	if err := db.SaveJustifiedBlock(block); err == nil {
+		t.Error("expected saving an unknown justified block to fail")
+	}
+	if err := db.SaveBlock(block); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+	if err := db.SaveJustifiedBlock(block); err != nil {
+		t.Fatalf("failed to save justified block: %v", err)
+	}
+	justified, err = db.JustifiedBlock()
+	if err != nil {
+		t.Fatalf("failed to get justified block: %v", err)
+	}
+	if !proto.Equal(justified, block) {
+		t.Errorf("expected justified block to be %v, received %v", block, justified)
+	}
+}
+
+func TestDeleteNonCanonicalBlocks_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := &pb.BeaconState{}
+	genesis := &pb.BeaconBlock{Slot: 0}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockA1 := &pb.BeaconBlock{Slot: 1, ParentRootHash32: genesisRoot[:]}
+	blockA1Root, err := hashutil.HashBeaconBlock(blockA1)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockA2 := &pb.BeaconBlock{Slot: 2, ParentRootHash32: blockA1Root[:]}
+	blockB2 := &pb.BeaconBlock{Slot: 2, ParentRootHash32: genesisRoot[:], StateRootHash32: []byte{'B'}}
+	blockB2Root, err := hashutil.HashBeaconBlock(blockB2)
+	if err != nil {
+		t.Fatalf("failed to hash block: %v", err)
+	}
+	blockB3 := &pb.BeaconBlock{Slot: 3, ParentRootHash32: blockB2Root[:]}
+
+	for _, block := range []*pb.BeaconBlock{genesis, blockA1, blockA2, blockB2, blockB3} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+	}
+	for _, block := range []*pb.BeaconBlock{genesis, blockA1, blockA2} {
+		if err := db.UpdateChainHead(block, beaconState); err != nil {
+			t.Fatalf("failed to update head: %v", err)
+		}
+	}
+
+	deleted, err := db.DeleteNonCanonicalBlocks(1, 2)
+	if err != nil {
+		t.Fatalf("failed to delete non-canonical blocks: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted block, received %d", deleted)
+	}
+	if db.HasBlock(blockB2Root) {
+		t.Error("expected non-canonical block below the finalized slot to be deleted")
+	}
+	for _, block := range []*pb.BeaconBlock{genesis, blockA1, blockA2, blockB3} {
+		root, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			t.Fatalf("failed to hash block: %v", err)
+		}
+		if !db.HasBlock(root) {
+			t.Errorf("expected block at slot %d to be kept", block.Slot)
+		}
+	}
+}
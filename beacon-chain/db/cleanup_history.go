@@ -3,14 +3,14 @@ package db
 import (
 	"errors"
 
-	"github.com/boltdb/bolt"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 )
 
 // CleanedFinalizedSlot returns the most recent finalized slot when we did a DB clean up.
 func (db *BeaconDB) CleanedFinalizedSlot() (uint64, error) {
 	var lastFinalizedSlot uint64
 
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		cleanupHistory := tx.Bucket(cleanupHistoryBucket)
 
 		slotEnc := cleanupHistory.Get(cleanedFinalizedSlotKey)
@@ -30,7 +30,7 @@ func (db *BeaconDB) CleanedFinalizedSlot() (uint64, error) {
 func (db *BeaconDB) SaveCleanedFinalizedSlot(slot uint64) error {
 	slotEnc := encodeSlotNumber(slot)
 
-	err := db.update(func(tx *bolt.Tx) error {
+	err := db.update(func(tx storage.Tx) error {
 		cleanupHistory := tx.Bucket(cleanupHistoryBucket)
 
 		if err := cleanupHistory.Put(cleanedFinalizedSlotKey, slotEnc); err != nil {
@@ -1,13 +1,12 @@
 package db
 
 import (
-	"errors"
-	"os"
-	"path"
+	"fmt"
 	"sync"
 	"time"
 
-	"github.com/boltdb/bolt"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,28 +18,38 @@ var log = logrus.WithField("prefix", "beacondb")
 // For example, instead of defining get, put, remove
 // This defines methods such as getBlock, saveBlocksAndAttestations, etc.
 type BeaconDB struct {
-	db           *bolt.DB
+	db           storage.Backend
 	DatabasePath string
 
 	// Beacon chain deposits in memory.
 	deposits     []*depositContainer
 	depositsLock sync.RWMutex
+
+	// Recently regenerated historical states.
+	stateCache *lru.Cache
 }
 
-// Close closes the underlying leveldb database.
+// Close closes the underlying storage engine.
 func (db *BeaconDB) Close() error {
 	return db.db.Close()
 }
 
-func (db *BeaconDB) update(fn func(*bolt.Tx) error) error {
+// EnableWriteBatching buffers the writes to the DB in memory, and writes them to disk in a
+// single transaction once maxPendingBytes were buffered, or once the oldest buffered write
+// is older than maxDelay. It must be called before the DB is used.
+func (db *BeaconDB) EnableWriteBatching(maxPendingBytes int, maxDelay time.Duration) {
+	db.db = storage.NewBatchingBackend(db.db, maxPendingBytes, maxDelay)
+}
+
+func (db *BeaconDB) update(fn func(storage.Tx) error) error {
 	return db.db.Update(fn)
 }
 
-func (db *BeaconDB) view(fn func(*bolt.Tx) error) error {
+func (db *BeaconDB) view(fn func(storage.Tx) error) error {
 	return db.db.View(fn)
 }
 
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+func createBuckets(tx storage.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
@@ -50,29 +59,57 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	return nil
 }
 
-// NewDB initializes a new DB. If the genesis block and states do not exist, this method creates it.
+// NewDB initializes a new DB stored in a bolt file. If the genesis block and states do not
+// exist, this method creates it.
 func NewDB(dirPath string) (*BeaconDB, error) {
-	if err := os.MkdirAll(dirPath, 0700); err != nil {
+	return NewDBWithEngine(dirPath, storage.BoltEngine)
+}
+
+// NewDBWithEngine initializes a new DB stored with the given storage engine.
+func NewDBWithEngine(dirPath string, engine string) (*BeaconDB, error) {
+	backend, err := storage.Open(engine, dirPath)
+	if err != nil {
 		return nil, err
 	}
-	datafile := path.Join(dirPath, "beaconchain.db")
-	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	return newDB(backend, dirPath)
+}
+
+// NewInMemoryDB initializes a new DB which keeps its content in memory.
+func NewInMemoryDB() (*BeaconDB, error) {
+	return newDB(storage.NewMemoryBackend(), "")
+}
+
+func newDB(backend storage.Backend, dirPath string) (*BeaconDB, error) {
+	stateCache, err := lru.New(stateCacheSize)
 	if err != nil {
-		if err == bolt.ErrTimeout {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
-		}
 		return nil, err
 	}
+	db := &BeaconDB{db: backend, DatabasePath: dirPath, stateCache: stateCache}
 
-	db := &BeaconDB{db: boltDB, DatabasePath: dirPath}
-
-	if err := db.update(func(tx *bolt.Tx) error {
+	if err := db.update(func(tx storage.Tx) error {
 		return createBuckets(tx, blockBucket, attestationBucket, mainChainBucket,
-			chainInfoBucket, cleanupHistoryBucket, blockOperationsBucket, validatorBucket)
+			chainInfoBucket, cleanupHistoryBucket, blockOperationsBucket, validatorBucket,
+			blockStateRootBucket, stateSnapshotBucket, schemaBucket, blockSlotIndexBucket,
+			blockParentIndexBucket, pendingDepositsBucket, powchainBucket, attestationSlotIndexBucket,
+			attestationBlockIndexBucket, stateChunkBucket, stateChunkRefsBucket, validatorPubkeyBucket)
 
 	}); err != nil {
 		return nil, err
 	}
 
+	if err := db.migrate(); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Errorf("Failed to close database: %v", closeErr)
+		}
+		return nil, err
+	}
+
+	if err := db.loadPendingDeposits(); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Errorf("Failed to close database: %v", closeErr)
+		}
+		return nil, fmt.Errorf("could not load pending deposits: %v", err)
+	}
+
 	return db, err
 }
@@ -7,7 +7,11 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
@@ -37,3 +41,71 @@ func teardownDB(t *testing.T, db *BeaconDB) {
 		t.Fatalf("Failed to remove directory: %v", err)
 	}
 }
+
+func TestNewDBWithEngine_PersistsAcrossRestarts(t *testing.T) {
+	for _, engine := range []string{storage.BoltEngine, storage.LevelDBEngine} {
+		dbPath := path.Join(testutil.TempDir(), fmt.Sprintf("dbengine-%s", engine))
+		if err := os.RemoveAll(dbPath); err != nil {
+			t.Fatalf("Failed to remove directory: %v", err)
+		}
+		db, err := NewDBWithEngine(dbPath, engine)
+		if err != nil {
+			t.Fatalf("Failed to instantiate %s DB: %v", engine, err)
+		}
+		block := &pb.BeaconBlock{Slot: 1}
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("Failed to save block: %v", err)
+		}
+		if err := db.Close(); err != nil {
+			t.Fatalf("Failed to close database: %v", err)
+		}
+
+		db, err = NewDBWithEngine(dbPath, engine)
+		if err != nil {
+			t.Fatalf("Failed to reopen %s DB: %v", engine, err)
+		}
+		root, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !db.HasBlock(root) {
+			t.Errorf("Expected block saved with the %s engine to persist", engine)
+		}
+		teardownDB(t, db)
+	}
+}
+
+func TestEnableWriteBatching_FlushesOnClose(t *testing.T) {
+	dbPath := path.Join(testutil.TempDir(), "dbbatching")
+	if err := os.RemoveAll(dbPath); err != nil {
+		t.Fatalf("Failed to remove directory: %v", err)
+	}
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to instantiate DB: %v", err)
+	}
+	db.EnableWriteBatching(1<<20, time.Hour)
+	block := &pb.BeaconBlock{Slot: 1}
+	if err := db.SaveBlock(block); err != nil {
+		t.Fatalf("Failed to save block: %v", err)
+	}
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasBlock(root) {
+		t.Error("Expected buffered block to be visible before it is flushed")
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Failed to close database: %v", err)
+	}
+
+	db, err = NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen DB: %v", err)
+	}
+	if !db.HasBlock(root) {
+		t.Error("Expected buffered block to be flushed when the DB is closed")
+	}
+	teardownDB(t, db)
+}
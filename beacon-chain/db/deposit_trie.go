@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+// SaveDepositTrie records the branch and deposit count of the deposit trie, along with the
+// number of the last ETH1.0 block whose deposit logs are included in the trie.
+func (db *BeaconDB) SaveDepositTrie(ctx context.Context, trie *trieutil.DepositTrie, lastProcessedBlock *big.Int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BeaconDB.SaveDepositTrie")
+	defer span.Finish()
+
+	var branch []byte
+	for _, h := range trie.Branch() {
+		branch = append(branch, h...)
+	}
+
+	return db.update(func(tx storage.Tx) error {
+		powchain := tx.Bucket(powchainBucket)
+
+		if err := powchain.Put(depositTrieBranchKey, branch); err != nil {
+			return fmt.Errorf("failed to save deposit trie branch: %v", err)
+		}
+		if err := powchain.Put(depositCountKey, bytesutil.Bytes8(trie.DepositCount())); err != nil {
+			return fmt.Errorf("failed to save deposit count: %v", err)
+		}
+		return powchain.Put(lastProcessedETH1BlockKey, bytesutil.Bytes8(lastProcessedBlock.Uint64()))
+	})
+}
+
+// DepositTrie returns the deposit trie saved in the DB and the number of the last ETH1.0
+// block whose deposit logs are included in it. Returns nil if no deposit trie was saved.
+func (db *BeaconDB) DepositTrie(ctx context.Context) (*trieutil.DepositTrie, *big.Int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "BeaconDB.DepositTrie")
+	defer span.Finish()
+
+	var trie *trieutil.DepositTrie
+	var lastProcessedBlock *big.Int
+	err := db.view(func(tx storage.Tx) error {
+		powchain := tx.Bucket(powchainBucket)
+
+		enc := powchain.Get(depositTrieBranchKey)
+		count := powchain.Get(depositCountKey)
+		block := powchain.Get(lastProcessedETH1BlockKey)
+		if enc == nil || count == nil || block == nil {
+			return nil
+		}
+		if len(enc) != 32*32 {
+			return fmt.Errorf("expected deposit trie branch of %d bytes, received %d", 32*32, len(enc))
+		}
+
+		branch := make([][]byte, 32)
+		for i := range branch {
+			branch[i] = enc[i*32 : (i+1)*32]
+		}
+		var err error
+		trie, err = trieutil.NewDepositTrieFromBranch(branch, bytesutil.FromBytes8(count))
+		if err != nil {
+			return fmt.Errorf("failed to restore deposit trie: %v", err)
+		}
+		lastProcessedBlock = new(big.Int).SetUint64(bytesutil.FromBytes8(block))
+		return nil
+	})
+	return trie, lastProcessedBlock, err
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestDepositTrie_NilWhenNotSaved(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	trie, block, err := db.DepositTrie(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get deposit trie: %v", err)
+	}
+	if trie != nil || block != nil {
+		t.Errorf("Expected no deposit trie, received %v at block %v", trie, block)
+	}
+}
+
+func TestSaveDepositTrie_RoundTrip(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	trie := trieutil.NewDepositTrie()
+	trie.UpdateDepositTrie([]byte{'A'})
+	trie.UpdateDepositTrie([]byte{'B'})
+	if err := db.SaveDepositTrie(context.Background(), trie, big.NewInt(1000)); err != nil {
+		t.Fatalf("Failed to save deposit trie: %v", err)
+	}
+
+	saved, block, err := db.DepositTrie(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get deposit trie: %v", err)
+	}
+	if saved == nil {
+		t.Fatal("Expected a saved deposit trie")
+	}
+	if saved.Root() != trie.Root() || saved.DepositCount() != trie.DepositCount() {
+		t.Errorf("Expected deposit trie with root %#x and %d deposits, received root %#x and %d deposits",
+			trie.Root(), trie.DepositCount(), saved.Root(), saved.DepositCount())
+	}
+	if block.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("Expected last processed block 1000, received %v", block)
+	}
+}
@@ -0,0 +1,281 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// IntegrityReport lists the inconsistencies found in the DB by VerifyIntegrity.
+type IntegrityReport struct {
+	// Problems describes each inconsistency. The DB is consistent if there is none.
+	Problems []string
+	// HeadSlot is the slot of the recorded chain head.
+	HeadSlot uint64
+	// Repairable is true if a canonical block exists to which the chain head can be
+	// rewound to restore consistency.
+	Repairable bool
+	// LastConsistentSlot and LastConsistentRoot identify the most recent canonical block
+	// which is linked to genesis through consistent main chain entries, and whose saved
+	// post-state matches its state root.
+	LastConsistentSlot uint64
+	LastConsistentRoot [32]byte
+}
+
+// Consistent returns true if no inconsistency was found.
+func (r *IntegrityReport) Consistent() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *IntegrityReport) addProblem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// canonicalEntry is a main chain entry, along with its block if it exists.
+type canonicalEntry struct {
+	slot  uint64
+	root  []byte
+	block *pb.BeaconBlock
+}
+
+// VerifyIntegrity checks the consistency of the canonical chain recorded in the DB. Every
+// main chain entry must refer to a saved block linked to the previous entry, down to the
+// genesis block or to the checkpoint block the DB was initialized from. The recorded head
+// must be the last main chain entry, the canonical state must match the state root of the
+// head block, and the validator index entries must match the canonical validator registry.
+func (db *BeaconDB) VerifyIntegrity() (*IntegrityReport, error) {
+	report := &IntegrityReport{}
+	err := db.view(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		chainInfo := tx.Bucket(chainInfoBucket)
+		mainChain := tx.Bucket(mainChainBucket)
+		snapshots := tx.Bucket(stateSnapshotBucket)
+
+		var entries []*canonicalEntry
+		if err := mainChain.ForEach(func(k, v []byte) error {
+			entries = append(entries, &canonicalEntry{slot: decodeToSlotNumber(k), root: append([]byte{}, v...)})
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			report.addProblem("main chain is empty")
+			return nil
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].slot < entries[j].slot
+		})
+
+		// Walk up the main chain while it is consistent, and remember the last block
+		// whose post-state is available.
+		linked := true
+		for i, entry := range entries {
+			slot := entry.slot - params.BeaconConfig().GenesisSlot
+			enc := blockBkt.Get(entry.root)
+			if enc == nil {
+				report.addProblem("canonical block %#x at slot %d is missing", entry.root, slot)
+				linked = false
+				continue
+			}
+			block, err := createBlock(enc)
+			if err != nil {
+				return err
+			}
+			entry.block = block
+			if block.Slot != entry.slot {
+				report.addProblem("canonical block %#x recorded at slot %d has slot %d",
+					entry.root, slot, block.Slot-params.BeaconConfig().GenesisSlot)
+				linked = false
+				continue
+			}
+			if i == 0 {
+				if entry.slot != params.BeaconConfig().GenesisSlot && snapshots.Get(entry.root) == nil {
+					report.addProblem("main chain starts at slot %d, which is neither genesis nor a checkpoint", slot)
+					linked = false
+				}
+			} else if !bytes.Equal(block.ParentRootHash32, entries[i-1].root) {
+				report.addProblem("canonical block at slot %d does not link to the canonical block at slot %d",
+					slot, entries[i-1].slot-params.BeaconConfig().GenesisSlot)
+				linked = false
+			}
+			if !linked {
+				continue
+			}
+			if stateEnc := blockPostState(tx, entry.root); stateEnc != nil {
+				stateRoot, err := storedStateRoot(tx, stateEnc)
+				if err != nil {
+					return err
+				}
+				if bytes.Equal(stateRoot[:], block.StateRootHash32) {
+					report.Repairable = true
+					report.LastConsistentSlot = entry.slot
+					report.LastConsistentRoot = bytesutil.ToBytes32(entry.root)
+				}
+			}
+		}
+
+		last := entries[len(entries)-1]
+		height := chainInfo.Get(mainChainHeightKey)
+		if height == nil {
+			report.addProblem("chain head height is missing")
+		} else {
+			report.HeadSlot = decodeToSlotNumber(height)
+			if report.HeadSlot != last.slot {
+				report.addProblem("chain head height %d does not match the last canonical slot %d",
+					report.HeadSlot-params.BeaconConfig().GenesisSlot, last.slot-params.BeaconConfig().GenesisSlot)
+			}
+		}
+
+		stateEnc := chainInfo.Get(stateLookupKey)
+		if stateEnc == nil {
+			report.addProblem("canonical state is missing")
+			return nil
+		}
+		beaconState, err := decodeState(tx, stateEnc)
+		if err != nil {
+			return err
+		}
+		if last.block != nil {
+			stateRoot, err := hashutil.HashProto(beaconState)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(stateRoot[:], last.block.StateRootHash32) {
+				report.addProblem("canonical state hash %#x does not match the head block state root %#x",
+					stateRoot, last.block.StateRootHash32)
+			}
+		}
+		return verifyValidatorIndices(tx.Bucket(validatorBucket), beaconState, report)
+	})
+	return report, err
+}
+
+// verifyValidatorIndices checks that every validator index entry maps the hash of a
+// public key to the index of the validator with that public key.
+func verifyValidatorIndices(validatorBkt storage.Bucket, beaconState *pb.BeaconState, report *IntegrityReport) error {
+	return validatorBkt.ForEach(func(k, v []byte) error {
+		index, n := binary.Uvarint(v)
+		if n <= 0 {
+			report.addProblem("validator index entry %#x cannot be decoded", k)
+			return nil
+		}
+		if index >= uint64(len(beaconState.ValidatorRegistry)) {
+			report.addProblem("validator index entry %#x refers to index %d outside of the validator registry", k, index)
+			return nil
+		}
+		h := hashutil.Hash(beaconState.ValidatorRegistry[index].Pubkey)
+		if !bytes.Equal(h[:], k) {
+			report.addProblem("validator index entry %#x refers to index %d of another validator", k, index)
+		}
+		return nil
+	})
+}
+
+// blockPostState returns the encoded post-state saved for a block, either as the state of
+// an unfinalized block or as a state snapshot. Returns nil if no state was saved.
+func blockPostState(tx storage.Tx, blockRoot []byte) []byte {
+	if stateRoot := tx.Bucket(blockStateRootBucket).Get(blockRoot); stateRoot != nil {
+		if enc := tx.Bucket(chainInfoBucket).Get(stateRoot); enc != nil {
+			return enc
+		}
+	}
+	return tx.Bucket(stateSnapshotBucket).Get(blockRoot)
+}
+
+// RewindChainHead makes the given canonical block the chain head again, with its saved
+// post-state as the canonical state. The main chain entries after the block are removed,
+// the validator index is rebuilt from the restored state, and the justified block is moved
+// back to the block if it is not part of the remaining chain.
+func (db *BeaconDB) RewindChainHead(blockRoot [32]byte) error {
+	err := db.update(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		chainInfo := tx.Bucket(chainInfoBucket)
+		mainChain := tx.Bucket(mainChainBucket)
+
+		enc := blockBkt.Get(blockRoot[:])
+		if enc == nil {
+			return fmt.Errorf("block %#x not found", blockRoot)
+		}
+		block, err := createBlock(enc)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(mainChain.Get(encodeSlotNumber(block.Slot)), blockRoot[:]) {
+			return fmt.Errorf("block %#x is not part of the main chain", blockRoot)
+		}
+		stateEnc := blockPostState(tx, blockRoot[:])
+		if stateEnc == nil {
+			return fmt.Errorf("no state saved for block %#x", blockRoot)
+		}
+		beaconState, err := decodeState(tx, stateEnc)
+		if err != nil {
+			return err
+		}
+
+		var staleKeys [][]byte
+		if err := mainChain.ForEach(func(k, v []byte) error {
+			if decodeToSlotNumber(k) > block.Slot {
+				staleKeys = append(staleKeys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range staleKeys {
+			if err := mainChain.Delete(k); err != nil {
+				return err
+			}
+		}
+		if err := chainInfo.Put(mainChainHeightKey, encodeSlotNumber(block.Slot)); err != nil {
+			return fmt.Errorf("failed to record the block as the head of the main chain: %v", err)
+		}
+		if err := putStoredState(tx, chainInfo, stateLookupKey, append([]byte{}, stateEnc...)); err != nil {
+			return fmt.Errorf("failed to save beacon state as canonical: %v", err)
+		}
+
+		justified := chainInfo.Get(justifiedBlockLookupKey)
+		justifiedEnc := blockBkt.Get(justified)
+		justifiedCanonical := false
+		if justifiedEnc != nil {
+			justifiedBlock, err := createBlock(justifiedEnc)
+			if err != nil {
+				return err
+			}
+			justifiedCanonical = justifiedBlock.Slot <= block.Slot &&
+				bytes.Equal(mainChain.Get(encodeSlotNumber(justifiedBlock.Slot)), justified)
+		}
+		if !justifiedCanonical {
+			if err := chainInfo.Put(justifiedBlockLookupKey, blockRoot[:]); err != nil {
+				return fmt.Errorf("failed to record justified block: %v", err)
+			}
+		}
+
+		return rebuildValidatorIndices(tx, beaconState)
+	})
+	if err != nil {
+		return err
+	}
+	db.stateCache.Purge()
+	return nil
+}
+
+// rebuildValidatorIndices replaces the validator index entries with the validators of
+// the given state.
+func rebuildValidatorIndices(tx storage.Tx, beaconState *pb.BeaconState) error {
+	for _, bucket := range [][]byte{validatorBucket, validatorPubkeyBucket} {
+		if err := tx.DeleteBucket(bucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+			return err
+		}
+	}
+	return syncValidatorIndices(tx, beaconState.ValidatorRegistry)
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// extendChain saves a child of the chain head, along with its post-state, and makes it
+// the new chain head. The state root of the child does not match its post-state unless
+// consistent is true.
+func extendChain(t *testing.T, db *BeaconDB, consistent bool) [32]byte {
+	head, err := db.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	headRoot, err := hashutil.HashBeaconBlock(head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconState, err := db.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconState.Slot = head.Slot + 1
+	enc, err := proto.Marshal(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateRoot := hashutil.Hash(enc)
+	if !consistent {
+		stateRoot = hashutil.Hash([]byte("not the state"))
+	}
+	block := &pb.BeaconBlock{
+		Slot:             head.Slot + 1,
+		ParentRootHash32: headRoot[:],
+		StateRootHash32:  stateRoot[:],
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveBlock(block); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+	if err := db.SaveUnfinalizedBlockState(blockRoot, beaconState); err != nil {
+		t.Fatalf("failed to save block state: %v", err)
+	}
+	if err := db.UpdateChainHead(block, beaconState); err != nil {
+		t.Fatalf("failed to update chain head: %v", err)
+	}
+	return blockRoot
+}
+
+func verifyIntegrity(t *testing.T, db *BeaconDB) *IntegrityReport {
+	report, err := db.VerifyIntegrity()
+	if err != nil {
+		t.Fatalf("failed to verify integrity: %v", err)
+	}
+	return report
+}
+
+func expectProblem(t *testing.T, report *IntegrityReport, want string) {
+	for _, problem := range report.Problems {
+		if strings.Contains(problem, want) {
+			return
+		}
+	}
+	t.Errorf("expected a problem containing %q, received %v", want, report.Problems)
+}
+
+func TestVerifyIntegrity_ConsistentChain(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 4)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	headRoot := extendChain(t, db, true /* consistent */)
+
+	report := verifyIntegrity(t, db)
+	if !report.Consistent() {
+		t.Fatalf("expected a consistent DB, received problems %v", report.Problems)
+	}
+	if report.HeadSlot != params.BeaconConfig().GenesisSlot+1 {
+		t.Errorf("expected head slot %d, received %d", params.BeaconConfig().GenesisSlot+1, report.HeadSlot)
+	}
+	if report.LastConsistentRoot != headRoot {
+		t.Errorf("expected last consistent block %#x, received %#x", headRoot, report.LastConsistentRoot)
+	}
+}
+
+func TestVerifyIntegrity_MissingCanonicalBlock(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 4)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	extendChain(t, db, true /* consistent */)
+	headRoot := extendChain(t, db, true /* consistent */)
+	if err := db.update(func(tx storage.Tx) error {
+		return tx.Bucket(blockBucket).Delete(headRoot[:])
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyIntegrity(t, db)
+	expectProblem(t, report, "at slot 2 is missing")
+	if !report.Repairable || report.LastConsistentSlot != params.BeaconConfig().GenesisSlot+1 {
+		t.Errorf("expected the block at slot 1 to be the last consistent block, received slot %d",
+			report.LastConsistentSlot-params.BeaconConfig().GenesisSlot)
+	}
+}
+
+func TestVerifyIntegrity_HeadStateMismatch(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 4)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	extendChain(t, db, false /* consistent */)
+
+	report := verifyIntegrity(t, db)
+	expectProblem(t, report, "does not match the head block state root")
+	genesis, err := db.BlockBySlot(params.BeaconConfig().GenesisSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.LastConsistentRoot != genesisRoot {
+		t.Errorf("expected the genesis block to be the last consistent block, received %#x", report.LastConsistentRoot)
+	}
+}
+
+func TestVerifyIntegrity_WrongValidatorIndex(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 4)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	beaconState, err := db.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveValidatorIndex(beaconState.ValidatorRegistry[0].Pubkey, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyIntegrity(t, db)
+	expectProblem(t, report, "refers to index 1 of another validator")
+}
+
+func TestRewindChainHead_RestoresConsistency(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 4)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	consistentRoot := extendChain(t, db, true /* consistent */)
+	extendChain(t, db, false /* consistent */)
+	beaconState, err := db.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveValidatorIndex(beaconState.ValidatorRegistry[0].Pubkey, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyIntegrity(t, db)
+	if report.Consistent() || !report.Repairable {
+		t.Fatalf("expected a repairable inconsistent DB, received %v", report)
+	}
+	if report.LastConsistentRoot != consistentRoot {
+		t.Fatalf("expected last consistent block %#x, received %#x", consistentRoot, report.LastConsistentRoot)
+	}
+	if err := db.RewindChainHead(report.LastConsistentRoot); err != nil {
+		t.Fatalf("failed to rewind chain head: %v", err)
+	}
+
+	report = verifyIntegrity(t, db)
+	if !report.Consistent() {
+		t.Fatalf("expected a consistent DB after rewinding, received problems %v", report.Problems)
+	}
+	head, err := db.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Slot != params.BeaconConfig().GenesisSlot+1 {
+		t.Errorf("expected chain head at slot 1, received %d", head.Slot-params.BeaconConfig().GenesisSlot)
+	}
+	index, err := db.ValidatorIndex(beaconState.ValidatorRegistry[0].Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != 0 {
+		t.Errorf("expected validator index to be rebuilt as 0, received %d", index)
+	}
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// migration converts the DB content from the schema version at which the migration
+// is listed to the next one.
+type migration struct {
+	name    string
+	migrate func(tx storage.Tx) error
+}
+
+// migrations is the ordered list of schema changes. The migration at index i upgrades
+// a DB of schema version i to version i+1, so the current schema version is the number
+// of migrations. Migrations must never be removed or reordered once released.
+var migrations = []migration{
+	{name: "move genesis block to the genesis slot of the main chain", migrate: migrateGenesisMainChainKey},
+	{name: "index blocks by slot and parent root", migrate: migrateBlockIndexes},
+	{name: "index attestations by slot, shard and block root", migrate: migrateAttestationIndexes},
+	{name: "store states as SSZ with shared chunks", migrate: migrateStateChunks},
+	{name: "index validators of the canonical registry by public key and index", migrate: migrateValidatorPubkeys},
+	{name: "record the canonical state as the post-state of the head block", migrate: migrateHeadBlockState},
+}
+
+// currentSchemaVersion is the schema version of the DB content written by this code.
+func currentSchemaVersion() uint64 {
+	return uint64(len(migrations))
+}
+
+// SchemaVersion returns the schema version of the DB content.
+func (db *BeaconDB) SchemaVersion() (uint64, error) {
+	var version uint64
+	err := db.view(func(tx storage.Tx) error {
+		enc := tx.Bucket(schemaBucket).Get(schemaVersionKey)
+		if enc != nil {
+			version = bytesutil.FromBytes8(enc)
+		}
+		return nil
+	})
+	return version, err
+}
+
+// migrate upgrades the DB content to the current schema version. Each migration runs in
+// its own transaction along with the update of the schema version, so an interrupted
+// upgrade resumes from the last completed migration. A DB written by a more recent version
+// of the code, with a schema version this code does not know about, is rejected.
+func (db *BeaconDB) migrate() error {
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return fmt.Errorf("could not retrieve schema version: %v", err)
+	}
+	if version > currentSchemaVersion() {
+		return fmt.Errorf("database schema version %d is newer than the supported schema version %d, please upgrade the beacon node",
+			version, currentSchemaVersion())
+	}
+
+	// A DB without any block is new, and is written with the current schema from the start.
+	if version == 0 {
+		var empty bool
+		if err := db.view(func(tx storage.Tx) error {
+			k, _ := tx.Bucket(blockBucket).Cursor().First()
+			empty = k == nil
+			return nil
+		}); err != nil {
+			return err
+		}
+		if empty {
+			return db.update(func(tx storage.Tx) error {
+				return tx.Bucket(schemaBucket).Put(schemaVersionKey, bytesutil.Bytes8(currentSchemaVersion()))
+			})
+		}
+	}
+
+	for ; version < currentSchemaVersion(); version++ {
+		m := migrations[version]
+		log.WithFields(logrus.Fields{
+			"fromVersion": version,
+			"toVersion":   version + 1,
+		}).Infof("Migrating DB schema: %s", m.name)
+		if err := db.update(func(tx storage.Tx) error {
+			if err := m.migrate(tx); err != nil {
+				return err
+			}
+			return tx.Bucket(schemaBucket).Put(schemaVersionKey, bytesutil.Bytes8(version+1))
+		}); err != nil {
+			return fmt.Errorf("could not migrate DB schema to version %d: %v", version+1, err)
+		}
+	}
+	return nil
+}
+
+// migrateGenesisMainChainKey moves the genesis block of the main chain from the slot 0
+// key to the key of the genesis slot, where the rest of the code looks for it.
+func migrateGenesisMainChainKey(tx storage.Tx) error {
+	mainChain := tx.Bucket(mainChainBucket)
+	chainInfo := tx.Bucket(chainInfoBucket)
+
+	zeroKey := encodeSlotNumber(0)
+	genesisKey := encodeSlotNumber(params.BeaconConfig().GenesisSlot)
+	genesisRoot := mainChain.Get(zeroKey)
+	if genesisRoot == nil {
+		return nil
+	}
+	enc := tx.Bucket(blockBucket).Get(genesisRoot)
+	if enc == nil {
+		return nil
+	}
+	genesis, err := createBlock(enc)
+	if err != nil {
+		return err
+	}
+	if genesis.Slot != params.BeaconConfig().GenesisSlot {
+		return nil
+	}
+
+	// The root is copied, as it is only valid until the key is deleted.
+	root := append([]byte{}, genesisRoot...)
+	if err := mainChain.Delete(zeroKey); err != nil {
+		return err
+	}
+	if err := mainChain.Put(genesisKey, root); err != nil {
+		return err
+	}
+	if bytes.Equal(chainInfo.Get(mainChainHeightKey), zeroKey) {
+		return chainInfo.Put(mainChainHeightKey, genesisKey)
+	}
+	return nil
+}
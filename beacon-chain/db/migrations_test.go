@@ -0,0 +1,100 @@
+package db
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestNewDB_SetsCurrentSchemaVersion(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		t.Fatalf("failed to get schema version: %v", err)
+	}
+	if version != currentSchemaVersion() {
+		t.Errorf("expected schema version %d, received %d", currentSchemaVersion(), version)
+	}
+}
+
+func TestNewDB_RefusesNewerSchemaVersion(t *testing.T) {
+	db := setupDB(t)
+	defer os.RemoveAll(db.DatabasePath)
+
+	if err := db.update(func(tx storage.Tx) error {
+		return tx.Bucket(schemaBucket).Put(schemaVersionKey, bytesutil.Bytes8(currentSchemaVersion()+1))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "is newer than the supported schema version"
+	if _, err := NewDB(db.DatabasePath); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error to contain %q, received %v", want, err)
+	}
+}
+
+func TestMigrate_MovesGenesisToGenesisSlotKey(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	genesis := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot}
+	if err := db.SaveBlock(genesis); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write the genesis block the way a DB of schema version 0 recorded it.
+	if err := db.update(func(tx storage.Tx) error {
+		if err := tx.Bucket(mainChainBucket).Put(encodeSlotNumber(0), genesisRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(chainInfoBucket).Put(mainChainHeightKey, encodeSlotNumber(0)); err != nil {
+			return err
+		}
+		return tx.Bucket(schemaBucket).Delete(schemaVersionKey)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.migrate(); err != nil {
+		t.Fatalf("failed to migrate DB: %v", err)
+	}
+
+	block, err := db.BlockBySlot(params.BeaconConfig().GenesisSlot)
+	if err != nil {
+		t.Fatalf("failed to get block by slot: %v", err)
+	}
+	if block == nil || block.Slot != genesis.Slot {
+		t.Errorf("expected genesis block at the genesis slot, received %v", block)
+	}
+	if block, _ := db.BlockBySlot(0); block != nil {
+		t.Errorf("expected no block at slot 0, received %v", block)
+	}
+	head, err := db.ChainHead()
+	if err != nil {
+		t.Fatalf("failed to get chain head: %v", err)
+	}
+	if head == nil || head.Slot != genesis.Slot {
+		t.Errorf("expected genesis block to be the chain head, received %v", head)
+	}
+	version, err := db.SchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != currentSchemaVersion() {
+		t.Errorf("expected schema version %d, received %d", currentSchemaVersion(), version)
+	}
+}
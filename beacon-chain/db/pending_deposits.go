@@ -2,20 +2,26 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"sort"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sirupsen/logrus"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 )
 
 var (
 	depositsCount = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "beacondb_pending_deposits",
-		Help: "The number of pending deposits in the beaconDB in-memory database",
+		Help: "The number of pending deposits in the beaconDB database",
 	})
 )
 
@@ -26,8 +32,59 @@ type depositContainer struct {
 	block   *big.Int
 }
 
+// encodeDepositContainer encodes the container as the number of the block followed by the
+// encoded deposit.
+func encodeDepositContainer(ctnr *depositContainer) ([]byte, error) {
+	enc, err := proto.Marshal(ctnr.deposit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deposit: %v", err)
+	}
+	return append(bytesutil.Bytes8(ctnr.block.Uint64()), enc...), nil
+}
+
+func decodeDepositContainer(enc []byte) (*depositContainer, error) {
+	if len(enc) < 8 {
+		return nil, errors.New("deposit container encoding is too short")
+	}
+	deposit := &pb.Deposit{}
+	if err := proto.Unmarshal(enc[8:], deposit); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal encoding: %v", err)
+	}
+	block := new(big.Int).SetUint64(bytesutil.FromBytes8(enc[:8]))
+	return &depositContainer{deposit: deposit, block: block}, nil
+}
+
+// loadPendingDeposits reads the pending deposits saved in the DB into memory, ordered
+// by Merkle tree index.
+func (db *BeaconDB) loadPendingDeposits() error {
+	var deposits []*depositContainer
+	if err := db.view(func(tx storage.Tx) error {
+		return tx.Bucket(pendingDepositsBucket).ForEach(func(k, v []byte) error {
+			ctnr, err := decodeDepositContainer(v)
+			if err != nil {
+				return err
+			}
+			deposits = append(deposits, ctnr)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	sort.Slice(deposits, func(i, j int) bool {
+		return deposits[i].deposit.MerkleTreeIndex < deposits[j].deposit.MerkleTreeIndex
+	})
+
+	db.depositsLock.Lock()
+	defer db.depositsLock.Unlock()
+	db.deposits = deposits
+	depositsCount.Set(float64(len(deposits)))
+	return nil
+}
+
 // InsertPendingDeposit into the database. If deposit or block number are nil
-// then this method does nothing.
+// then this method does nothing. A pending deposit with the same MerkleTreeIndex
+// is replaced, as the deposit logs of the proof of work chain may be processed
+// again after a restart.
 func (db *BeaconDB) InsertPendingDeposit(ctx context.Context, d *pb.Deposit, blockNum *big.Int) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "BeaconDB.InsertPendingDeposit")
 	defer span.Finish()
@@ -38,9 +95,28 @@ func (db *BeaconDB) InsertPendingDeposit(ctx context.Context, d *pb.Deposit, blo
 		}).Debug("Ignoring nil deposit insertion")
 		return
 	}
+	ctnr := &depositContainer{deposit: d, block: blockNum}
+	enc, err := encodeDepositContainer(ctnr)
+	if err != nil {
+		log.Errorf("Could not encode pending deposit: %v", err)
+		return
+	}
+
 	db.depositsLock.Lock()
 	defer db.depositsLock.Unlock()
-	db.deposits = append(db.deposits, &depositContainer{deposit: d, block: blockNum})
+	if err := db.update(func(tx storage.Tx) error {
+		return tx.Bucket(pendingDepositsBucket).Put(bytesutil.Bytes8(d.MerkleTreeIndex), enc)
+	}); err != nil {
+		log.Errorf("Could not save pending deposit: %v", err)
+		return
+	}
+	for i, existing := range db.deposits {
+		if existing.deposit.MerkleTreeIndex == d.MerkleTreeIndex {
+			db.deposits[i] = ctnr
+			return
+		}
+	}
+	db.deposits = append(db.deposits, ctnr)
 	depositsCount.Inc()
 }
 
@@ -77,6 +153,13 @@ func (db *BeaconDB) RemovePendingDeposit(ctx context.Context, d *pb.Deposit) {
 	db.depositsLock.Lock()
 	defer db.depositsLock.Unlock()
 
+	if err := db.update(func(tx storage.Tx) error {
+		return tx.Bucket(pendingDepositsBucket).Delete(bytesutil.Bytes8(d.MerkleTreeIndex))
+	}); err != nil {
+		log.Errorf("Could not remove pending deposit: %v", err)
+		return
+	}
+
 	idx := -1
 	for i, ctnr := range db.deposits {
 		if ctnr.deposit.MerkleTreeIndex == d.MerkleTreeIndex {
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"math/big"
+	"os"
 	"reflect"
 	"testing"
 
@@ -11,7 +12,8 @@ import (
 )
 
 func TestInsertPendingDeposit_OK(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 	db.InsertPendingDeposit(context.Background(), &pb.Deposit{}, big.NewInt(111))
 
 	if len(db.deposits) != 1 {
@@ -20,7 +22,8 @@ func TestInsertPendingDeposit_OK(t *testing.T) {
 }
 
 func TestInsertPendingDeposit_ignoresNilDeposit(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 	db.InsertPendingDeposit(context.Background(), nil /*deposit*/, nil /*blockNum*/)
 
 	if len(db.deposits) > 0 {
@@ -29,7 +32,8 @@ func TestInsertPendingDeposit_ignoresNilDeposit(t *testing.T) {
 }
 
 func TestRemovePendingDeposit_OK(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 	depToRemove := &pb.Deposit{MerkleTreeIndex: 1}
 	otherDep := &pb.Deposit{MerkleTreeIndex: 5}
 	db.deposits = []*depositContainer{
@@ -44,7 +48,8 @@ func TestRemovePendingDeposit_OK(t *testing.T) {
 }
 
 func TestRemovePendingDeposit_IgnoresNilDeposit(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 	db.deposits = []*depositContainer{{deposit: &pb.Deposit{}}}
 	db.RemovePendingDeposit(context.Background(), nil /*deposit*/)
 	if len(db.deposits) != 1 {
@@ -53,7 +58,8 @@ func TestRemovePendingDeposit_IgnoresNilDeposit(t *testing.T) {
 }
 
 func TestPendingDeposit_RoundTrip(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 	dep := &pb.Deposit{MerkleTreeIndex: 123}
 	db.InsertPendingDeposit(context.Background(), dep, big.NewInt(111))
 	db.RemovePendingDeposit(context.Background(), dep)
@@ -63,7 +69,8 @@ func TestPendingDeposit_RoundTrip(t *testing.T) {
 }
 
 func TestPendingDeposits_OK(t *testing.T) {
-	db := BeaconDB{}
+	db := setupDB(t)
+	defer teardownDB(t, db)
 
 	db.deposits = []*depositContainer{
 		{block: big.NewInt(2), deposit: &pb.Deposit{MerkleTreeIndex: 2}},
@@ -86,3 +93,47 @@ func TestPendingDeposits_OK(t *testing.T) {
 		t.Error("PendingDeposits(ctx, nil) did not return all deposits")
 	}
 }
+
+func TestInsertPendingDeposit_ReplacesDepositWithSameIndex(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	db.InsertPendingDeposit(context.Background(), &pb.Deposit{MerkleTreeIndex: 3}, big.NewInt(10))
+	db.InsertPendingDeposit(context.Background(), &pb.Deposit{MerkleTreeIndex: 3}, big.NewInt(11))
+
+	if len(db.deposits) != 1 || db.deposits[0].block.Cmp(big.NewInt(11)) != 0 {
+		t.Errorf("Expected the deposit to be replaced, received %+v", db.deposits)
+	}
+}
+
+func TestPendingDeposits_PersistAcrossRestarts(t *testing.T) {
+	db := setupDB(t)
+	defer os.RemoveAll(db.DatabasePath)
+
+	db.InsertPendingDeposit(context.Background(), &pb.Deposit{MerkleTreeIndex: 300, DepositData: []byte("b")}, big.NewInt(20))
+	db.InsertPendingDeposit(context.Background(), &pb.Deposit{MerkleTreeIndex: 2, DepositData: []byte("a")}, big.NewInt(10))
+	removed := &pb.Deposit{MerkleTreeIndex: 400}
+	db.InsertPendingDeposit(context.Background(), removed, big.NewInt(30))
+	db.RemovePendingDeposit(context.Background(), removed)
+	if err := db.Close(); err != nil {
+		t.Fatalf("Failed to close database: %v", err)
+	}
+
+	db, err := NewDB(db.DatabasePath)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+
+	expected := []*pb.Deposit{
+		{MerkleTreeIndex: 2, DepositData: []byte("a")},
+		{MerkleTreeIndex: 300, DepositData: []byte("b")},
+	}
+	deposits := db.PendingDeposits(context.Background(), nil)
+	if !reflect.DeepEqual(deposits, expected) {
+		t.Errorf("Unexpected deposits after restart. got=%+v want=%+v", deposits, expected)
+	}
+	if deposits := db.PendingDeposits(context.Background(), big.NewInt(10)); len(deposits) != 1 {
+		t.Errorf("Expected the block number of the deposits to be restored, received %+v", deposits)
+	}
+}
@@ -19,13 +19,36 @@ var (
 	mainChainBucket       = []byte("main-chain-bucket")
 	chainInfoBucket       = []byte("chain-info")
 	validatorBucket       = []byte("validator")
+	validatorPubkeyBucket = []byte("validator-pubkey-bucket")
+	blockStateRootBucket  = []byte("block-state-root-bucket")
+	stateSnapshotBucket   = []byte("state-snapshot-bucket")
 
-	mainChainHeightKey = []byte("chain-height")
-	stateLookupKey     = []byte("state")
+	blockSlotIndexBucket   = []byte("block-slot-index-bucket")
+	blockParentIndexBucket = []byte("block-parent-index-bucket")
+
+	attestationSlotIndexBucket  = []byte("attestation-slot-index-bucket")
+	attestationBlockIndexBucket = []byte("attestation-block-index-bucket")
+
+	stateChunkBucket     = []byte("state-chunk-bucket")
+	stateChunkRefsBucket = []byte("state-chunk-refs-bucket")
+
+	pendingDepositsBucket = []byte("pending-deposits-bucket")
+	powchainBucket        = []byte("powchain-bucket")
+
+	mainChainHeightKey      = []byte("chain-height")
+	stateLookupKey          = []byte("state")
+	justifiedBlockLookupKey = []byte("justified-block")
+	genesisTimeKey          = []byte("genesis-time")
+
+	depositTrieBranchKey      = []byte("deposit-trie-branch")
+	depositCountKey           = []byte("deposit-count")
+	lastProcessedETH1BlockKey = []byte("last-processed-eth1-block")
 
 	// DB internal use
 	cleanupHistoryBucket    = []byte("cleanup-history-bucket")
 	cleanedFinalizedSlotKey = []byte("cleaned-finalized-slot")
+	schemaBucket            = []byte("schema-bucket")
+	schemaVersionKey        = []byte("schema-version")
 )
 
 // encodeSlotNumber encodes a slot number as little-endian uint32.
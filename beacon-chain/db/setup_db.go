@@ -1,24 +1,13 @@
 package db
 
 import (
-	"crypto/rand"
-	"fmt"
-	"math/big"
 	"os"
-	"path"
 )
 
-// SetupDB instantiates and returns a simulated backend BeaconDB instance.
+// SetupDB instantiates and returns a simulated backend BeaconDB instance,
+// which keeps its content in memory.
 func SetupDB() (*BeaconDB, error) {
-	randPath, err := rand.Int(rand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return nil, fmt.Errorf("could not generate random file path: %v", err)
-	}
-	path := path.Join(os.TempDir(), fmt.Sprintf("/%d", randPath))
-	if err := os.RemoveAll(path); err != nil {
-		return nil, fmt.Errorf("failed to remove directory: %v", err)
-	}
-	return NewDB(path)
+	return NewInMemoryDB()
 }
 
 // TeardownDB cleans up a simulated backend BeaconDB instance.
@@ -1,15 +1,18 @@
 package db
 
 import (
-	"encoding/binary"
+	"bytes"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/boltdb/bolt"
 	"github.com/gogo/protobuf/proto"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
@@ -29,43 +32,139 @@ func (db *BeaconDB) InitializeState(genesisTime uint64, deposits []*pb.Deposit)
 	blockRoot, _ := hashutil.HashBeaconBlock(genesisBlock)
 	// #nosec G104
 	blockEnc, _ := proto.Marshal(genesisBlock)
-	zeroBinary := encodeSlotNumber(0)
+	slotBinary := encodeSlotNumber(genesisBlock.Slot)
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		blockBkt := tx.Bucket(blockBucket)
-		validatorBkt := tx.Bucket(validatorBucket)
 		mainChain := tx.Bucket(mainChainBucket)
 		chainInfo := tx.Bucket(chainInfoBucket)
+		blockStateRoots := tx.Bucket(blockStateRootBucket)
 
-		if err := chainInfo.Put(mainChainHeightKey, zeroBinary); err != nil {
+		if err := chainInfo.Put(mainChainHeightKey, slotBinary); err != nil {
 			return fmt.Errorf("failed to record block height: %v", err)
 		}
 
-		if err := mainChain.Put(zeroBinary, blockRoot[:]); err != nil {
+		if err := mainChain.Put(slotBinary, blockRoot[:]); err != nil {
 			return fmt.Errorf("failed to record block hash: %v", err)
 		}
 
 		if err := blockBkt.Put(blockRoot[:], blockEnc); err != nil {
 			return err
 		}
+		if err := indexBlock(tx.Bucket(blockSlotIndexBucket), tx.Bucket(blockParentIndexBucket), genesisBlock, blockRoot[:]); err != nil {
+			return err
+		}
 
-		for i, validator := range beaconState.ValidatorRegistry {
-			h := hashutil.Hash(validator.Pubkey)
-			buf := make([]byte, binary.MaxVarintLen64)
-			n := binary.PutUvarint(buf, uint64(i))
-			if err := validatorBkt.Put(h[:], buf[:n]); err != nil {
-				return err
-			}
+		if err := chainInfo.Put(justifiedBlockLookupKey, blockRoot[:]); err != nil {
+			return fmt.Errorf("failed to record justified block: %v", err)
+		}
+		if err := chainInfo.Put(genesisTimeKey, bytesutil.Bytes8(genesisTime)); err != nil {
+			return fmt.Errorf("failed to record genesis time: %v", err)
 		}
 
-		return chainInfo.Put(stateLookupKey, stateEnc)
+		// The genesis state is the post-state of the genesis block.
+		storedEnc, err := saveStateChunks(tx, beaconState)
+		if err != nil {
+			return err
+		}
+		if err := putStoredState(tx, chainInfo, stateHash[:], storedEnc); err != nil {
+			return fmt.Errorf("failed to save genesis block state: %v", err)
+		}
+		if err := blockStateRoots.Put(blockRoot[:], stateHash[:]); err != nil {
+			return fmt.Errorf("failed to record genesis block state root: %v", err)
+		}
+		if err := putStoredState(tx, tx.Bucket(stateSnapshotBucket), blockRoot[:], storedEnc); err != nil {
+			return fmt.Errorf("failed to save genesis state snapshot: %v", err)
+		}
+
+		if err := syncValidatorIndices(tx, beaconState.ValidatorRegistry); err != nil {
+			return err
+		}
+
+		return putStoredState(tx, chainInfo, stateLookupKey, storedEnc)
+	})
+}
+
+// InitializeStateFromCheckpoint seeds an empty DB with a trusted finalized block and its
+// post-state, instead of the genesis state. The block becomes the chain head and the
+// justified block, so the node syncs forward from the checkpoint. The block is expected
+// to commit to the state through its state root.
+func (db *BeaconDB) InitializeStateFromCheckpoint(block *pb.BeaconBlock, beaconState *pb.BeaconState) error {
+	stateEnc, err := proto.Marshal(beaconState)
+	if err != nil {
+		return fmt.Errorf("unable to encode beacon state: %v", err)
+	}
+	stateHash := hashutil.Hash(stateEnc)
+	if !bytes.Equal(block.StateRootHash32, stateHash[:]) {
+		return fmt.Errorf("checkpoint block state root %#x does not match state root %#x", block.StateRootHash32, stateHash)
+	}
+	if block.Slot != beaconState.Slot {
+		return fmt.Errorf("checkpoint block slot %d does not match state slot %d", block.Slot, beaconState.Slot)
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		return fmt.Errorf("unable to tree hash block: %v", err)
+	}
+	blockEnc, err := proto.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("unable to encode block: %v", err)
+	}
+	slotBinary := encodeSlotNumber(block.Slot)
+
+	return db.update(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		mainChain := tx.Bucket(mainChainBucket)
+		chainInfo := tx.Bucket(chainInfoBucket)
+		blockStateRoots := tx.Bucket(blockStateRootBucket)
+
+		if chainInfo.Get(stateLookupKey) != nil {
+			return errors.New("beacon state already exists")
+		}
+
+		if err := blockBkt.Put(blockRoot[:], blockEnc); err != nil {
+			return fmt.Errorf("failed to save checkpoint block: %v", err)
+		}
+		if err := indexBlock(tx.Bucket(blockSlotIndexBucket), tx.Bucket(blockParentIndexBucket), block, blockRoot[:]); err != nil {
+			return err
+		}
+		if err := mainChain.Put(slotBinary, blockRoot[:]); err != nil {
+			return fmt.Errorf("failed to record block hash: %v", err)
+		}
+		if err := chainInfo.Put(mainChainHeightKey, slotBinary); err != nil {
+			return fmt.Errorf("failed to record block height: %v", err)
+		}
+		if err := chainInfo.Put(justifiedBlockLookupKey, blockRoot[:]); err != nil {
+			return fmt.Errorf("failed to record justified block: %v", err)
+		}
+		if err := chainInfo.Put(genesisTimeKey, bytesutil.Bytes8(beaconState.GenesisTime)); err != nil {
+			return fmt.Errorf("failed to record genesis time: %v", err)
+		}
+		storedEnc, err := saveStateChunks(tx, beaconState)
+		if err != nil {
+			return err
+		}
+		if err := putStoredState(tx, chainInfo, stateHash[:], storedEnc); err != nil {
+			return fmt.Errorf("failed to save checkpoint block state: %v", err)
+		}
+		if err := blockStateRoots.Put(blockRoot[:], stateHash[:]); err != nil {
+			return fmt.Errorf("failed to record checkpoint block state root: %v", err)
+		}
+		if err := putStoredState(tx, tx.Bucket(stateSnapshotBucket), blockRoot[:], storedEnc); err != nil {
+			return fmt.Errorf("failed to save checkpoint state snapshot: %v", err)
+		}
+
+		if err := syncValidatorIndices(tx, beaconState.ValidatorRegistry); err != nil {
+			return err
+		}
+
+		return putStoredState(tx, chainInfo, stateLookupKey, storedEnc)
 	})
 }
 
 // State fetches the canonical beacon chain's state from the DB.
 func (db *BeaconDB) State() (*pb.BeaconState, error) {
 	var beaconState *pb.BeaconState
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
 		enc := chainInfo.Get(stateLookupKey)
 		if enc == nil {
@@ -73,22 +172,26 @@ func (db *BeaconDB) State() (*pb.BeaconState, error) {
 		}
 
 		var err error
-		beaconState, err = createState(enc)
+		beaconState, err = decodeState(tx, enc)
 		return err
 	})
 
 	return beaconState, err
 }
 
-// SaveState updates the beacon chain state.
+// SaveState updates the beacon chain state, along with the validator indices of its
+// validator registry.
 func (db *BeaconDB) SaveState(beaconState *pb.BeaconState) error {
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
-		beaconStateEnc, err := proto.Marshal(beaconState)
+		enc, err := saveStateChunks(tx, beaconState)
 		if err != nil {
 			return err
 		}
-		return chainInfo.Put(stateLookupKey, beaconStateEnc)
+		if err := putStoredState(tx, chainInfo, stateLookupKey, enc); err != nil {
+			return err
+		}
+		return syncValidatorIndices(tx, beaconState.ValidatorRegistry)
 	})
 }
 
@@ -96,7 +199,7 @@ func (db *BeaconDB) SaveState(beaconState *pb.BeaconState) error {
 // active and crystallized state pair.
 func (db *BeaconDB) UnfinalizedBlockState(stateRoot [32]byte) (*pb.BeaconState, error) {
 	var beaconState *pb.BeaconState
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
 		encState := chainInfo.Get(stateRoot[:])
 		if encState == nil {
@@ -104,40 +207,178 @@ func (db *BeaconDB) UnfinalizedBlockState(stateRoot [32]byte) (*pb.BeaconState,
 		}
 
 		var err error
-		beaconState, err = createState(encState)
+		beaconState, err = decodeState(tx, encState)
 		return err
 	})
 	return beaconState, err
 }
 
 // SaveUnfinalizedBlockState persists the associated state
-// for a given unfinalized block.
-func (db *BeaconDB) SaveUnfinalizedBlockState(beaconState *pb.BeaconState) error {
+// for a given unfinalized block, which is the state resulting
+// from applying the block to the post-state of its parent.
+func (db *BeaconDB) SaveUnfinalizedBlockState(blockRoot [32]byte, beaconState *pb.BeaconState) error {
 	enc, err := proto.Marshal(beaconState)
 	if err != nil {
 		return fmt.Errorf("unable to marshal the beacon state: %v", err)
 	}
 	stateHash := hashutil.Hash(enc)
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
-		if err := chainInfo.Put(stateHash[:], enc); err != nil {
+		blockStateRoots := tx.Bucket(blockStateRootBucket)
+		storedEnc, err := saveStateChunks(tx, beaconState)
+		if err != nil {
+			return err
+		}
+		if err := putStoredState(tx, chainInfo, stateHash[:], storedEnc); err != nil {
 			return fmt.Errorf("failed to save beacon state: %v", err)
 		}
+		if err := blockStateRoots.Put(blockRoot[:], stateHash[:]); err != nil {
+			return fmt.Errorf("failed to record block state root: %v", err)
+		}
 		return nil
 	})
 }
 
-func createState(enc []byte) (*pb.BeaconState, error) {
-	protoState := &pb.BeaconState{}
-	err := proto.Unmarshal(enc, protoState)
+// BlockState fetches the post-state of an unfinalized block by the block's
+// root. It returns nil if no state was saved for the block.
+func (db *BeaconDB) BlockState(blockRoot [32]byte) (*pb.BeaconState, error) {
+	var beaconState *pb.BeaconState
+	err := db.view(func(tx storage.Tx) error {
+		stateRoot := tx.Bucket(blockStateRootBucket).Get(blockRoot[:])
+		if stateRoot == nil {
+			return nil
+		}
+		encState := tx.Bucket(chainInfoBucket).Get(stateRoot)
+		if encState == nil {
+			return nil
+		}
+
+		var err error
+		beaconState, err = decodeState(tx, encState)
+		return err
+	})
+	return beaconState, err
+}
+
+// migrateHeadBlockState records the canonical state as the post-state of the head block,
+// for DBs written before the post-state of every block was kept. Incoming blocks are applied
+// to the post-state of their parent, so the head could not be extended without it. The
+// justified block of the canonical state is recorded as the block the fork choice starts
+// from, or the head block if the justified block was not saved.
+func migrateHeadBlockState(tx storage.Tx) error {
+	chainInfo := tx.Bucket(chainInfoBucket)
+	blockStateRoots := tx.Bucket(blockStateRootBucket)
+
+	height := chainInfo.Get(mainChainHeightKey)
+	if height == nil {
+		return nil
+	}
+	headRoot := tx.Bucket(mainChainBucket).Get(height)
+	stateEnc := chainInfo.Get(stateLookupKey)
+	if headRoot == nil || stateEnc == nil {
+		return nil
+	}
+	headRoot = append([]byte{}, headRoot...)
+	beaconState, err := decodeState(tx, stateEnc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal encoding: %v", err)
+		return err
+	}
+
+	if blockStateRoots.Get(headRoot) == nil {
+		stateRoot, err := hashutil.HashProto(beaconState)
+		if err != nil {
+			return err
+		}
+		if err := putStoredState(tx, chainInfo, stateRoot[:], append([]byte{}, stateEnc...)); err != nil {
+			return fmt.Errorf("failed to save head block state: %v", err)
+		}
+		if err := blockStateRoots.Put(headRoot, stateRoot[:]); err != nil {
+			return fmt.Errorf("failed to record head block state root: %v", err)
+		}
 	}
-	return protoState, nil
+
+	if chainInfo.Get(justifiedBlockLookupKey) != nil {
+		return nil
+	}
+	justifiedRoot := headRoot
+	root, err := b.BlockRoot(beaconState, helpers.StartSlot(beaconState.JustifiedEpoch))
+	if err == nil && tx.Bucket(blockBucket).Get(root) != nil {
+		justifiedRoot = root
+	}
+	return chainInfo.Put(justifiedBlockLookupKey, justifiedRoot)
+}
+
+// DeleteBlockStates removes the post-states of the blocks below the given slot, along with
+// the post-states of blocks which no longer exist. A state is only deleted once no remaining
+// block refers to it. Returns the number of deleted states.
+func (db *BeaconDB) DeleteBlockStates(beforeSlot uint64) (int, error) {
+	var deleted int
+	err := db.update(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		chainInfo := tx.Bucket(chainInfoBucket)
+		blockStateRoots := tx.Bucket(blockStateRootBucket)
+
+		var staleBlocks [][]byte
+		staleStates := make(map[[32]byte]bool)
+		keptStates := make(map[[32]byte]bool)
+		if err := blockStateRoots.ForEach(func(blockRoot, stateRoot []byte) error {
+			enc := blockBkt.Get(blockRoot)
+			if enc != nil {
+				block, err := createBlock(enc)
+				if err != nil {
+					return err
+				}
+				if block.Slot >= beforeSlot {
+					keptStates[bytesutil.ToBytes32(stateRoot)] = true
+					return nil
+				}
+			}
+			staleBlocks = append(staleBlocks, blockRoot)
+			staleStates[bytesutil.ToBytes32(stateRoot)] = true
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, blockRoot := range staleBlocks {
+			if err := blockStateRoots.Delete(blockRoot); err != nil {
+				return fmt.Errorf("failed to delete state root of block %#x: %v", blockRoot, err)
+			}
+		}
+		for stateRoot := range staleStates {
+			if keptStates[stateRoot] {
+				continue
+			}
+			if err := deleteStoredState(tx, chainInfo, stateRoot[:]); err != nil {
+				return fmt.Errorf("failed to delete state %#x: %v", stateRoot, err)
+			}
+			deleted++
+		}
+		return nil
+	})
+	return deleted, err
 }
 
-// GenesisTime returns the genesis timestamp for the state.
+// GenesisTime returns the genesis timestamp for the state. It is read from the genesis time
+// recorded when the state was initialized, without decoding the state, unless the DB was
+// initialized before the genesis time was recorded.
 func (db *BeaconDB) GenesisTime() (time.Time, error) {
+	var genesisTime uint64
+	var recorded bool
+	err := db.view(func(tx storage.Tx) error {
+		if enc := tx.Bucket(chainInfoBucket).Get(genesisTimeKey); enc != nil {
+			genesisTime = bytesutil.FromBytes8(enc)
+			recorded = true
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not retrieve genesis time: %v", err)
+	}
+	if recorded {
+		return time.Unix(int64(genesisTime), int64(0)), nil
+	}
+
 	state, err := db.State()
 	if err != nil {
 		return time.Time{}, fmt.Errorf("could not retrieve state: %v", err)
@@ -145,6 +386,5 @@ func (db *BeaconDB) GenesisTime() (time.Time, error) {
 	if state == nil {
 		return time.Time{}, fmt.Errorf("state not found: %v", err)
 	}
-	genesisTime := time.Unix(int64(state.GenesisTime), int64(0))
-	return genesisTime, nil
+	return time.Unix(int64(state.GenesisTime), int64(0)), nil
 }
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// stateSnapshotEpochInterval is the number of epochs between two state snapshots
+// of the canonical chain. Historical states are regenerated by replaying at most
+// this many epochs of blocks on top of the closest snapshot.
+const stateSnapshotEpochInterval = 4
+
+// stateCacheSize is the number of regenerated historical states kept in memory.
+const stateCacheSize = 32
+
+// stateCacheKey identifies a regenerated state by the canonical block it was built
+// on, so a cached state is not returned for a slot once the chain reorgs.
+type stateCacheKey struct {
+	blockRoot [32]byte
+	slot      uint64
+}
+
+// snapshotPeriod returns the snapshot interval the given slot belongs to.
+func snapshotPeriod(slot uint64) uint64 {
+	return slot / (stateSnapshotEpochInterval * params.BeaconConfig().SlotsPerEpoch)
+}
+
+// saveStateSnapshot stores the post-state of a new canonical head as a snapshot if the
+// head is the first block of its snapshot interval, or if its parent is unknown.
+func saveStateSnapshot(tx storage.Tx, block *pb.BeaconBlock, blockRoot [32]byte, stateEnc []byte) error {
+	if parentEnc := tx.Bucket(blockBucket).Get(block.ParentRootHash32); parentEnc != nil {
+		parent, err := createBlock(parentEnc)
+		if err != nil {
+			return err
+		}
+		if snapshotPeriod(parent.Slot) == snapshotPeriod(block.Slot) {
+			return nil
+		}
+	}
+	return putStoredState(tx, tx.Bucket(stateSnapshotBucket), blockRoot[:], stateEnc)
+}
+
+// StateSnapshot fetches the state snapshot stored for a block, which is the post-state
+// of the block. It returns nil if no snapshot was stored for the block.
+func (db *BeaconDB) StateSnapshot(blockRoot [32]byte) (*pb.BeaconState, error) {
+	var beaconState *pb.BeaconState
+	err := db.view(func(tx storage.Tx) error {
+		enc := tx.Bucket(stateSnapshotBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+
+		var err error
+		beaconState, err = decodeState(tx, enc)
+		return err
+	})
+	return beaconState, err
+}
+
+// StateAtSlot regenerates the canonical beacon state at the given slot, which cannot be
+// after the slot of the chain head. The closest state snapshot of the canonical chain at or
+// before the slot is loaded, and the canonical blocks after it are replayed on top of it.
+// Recently regenerated states are cached.
+func (db *BeaconDB) StateAtSlot(slot uint64) (*pb.BeaconState, error) {
+	head, err := db.ChainHead()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chain head: %v", err)
+	}
+	if head == nil {
+		return nil, errors.New("no chain head found")
+	}
+	if slot > head.Slot {
+		return nil, fmt.Errorf(
+			"slot %d is after the chain head slot %d",
+			slot-params.BeaconConfig().GenesisSlot,
+			head.Slot-params.BeaconConfig().GenesisSlot,
+		)
+	}
+
+	var cached *pb.BeaconState
+	var snapshot *pb.BeaconState
+	var snapshotRoot [32]byte
+	var key stateCacheKey
+	// The canonical blocks to replay, from the most recent one down.
+	var blocks []*pb.BeaconBlock
+	err = db.view(func(tx storage.Tx) error {
+		blockBkt := tx.Bucket(blockBucket)
+		mainChain := tx.Bucket(mainChainBucket)
+		snapshots := tx.Bucket(stateSnapshotBucket)
+
+		// Find the most recent canonical block at or before the slot.
+		var root []byte
+		for s := slot; root == nil; s-- {
+			root = mainChain.Get(encodeSlotNumber(s))
+			if root == nil && (s == 0 || s <= params.BeaconConfig().GenesisSlot) {
+				return fmt.Errorf("no canonical block found at or before slot %d", slot-params.BeaconConfig().GenesisSlot)
+			}
+		}
+		key = stateCacheKey{blockRoot: bytesutil.ToBytes32(root), slot: slot}
+		if value, ok := db.stateCache.Get(key); ok {
+			cached = value.(*pb.BeaconState)
+			return nil
+		}
+
+		// Walk back through the ancestors of that block until one has a snapshot.
+		for {
+			if enc := snapshots.Get(root); enc != nil {
+				var err error
+				snapshot, err = decodeState(tx, enc)
+				snapshotRoot = bytesutil.ToBytes32(root)
+				return err
+			}
+			enc := blockBkt.Get(root)
+			if enc == nil {
+				return fmt.Errorf("no state snapshot found before slot %d", slot-params.BeaconConfig().GenesisSlot)
+			}
+			block, err := createBlock(enc)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, block)
+			root = block.ParentRootHash32
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil {
+		return proto.Clone(cached).(*pb.BeaconState), nil
+	}
+
+	beaconState, err := replayBlocks(snapshot, snapshotRoot, blocks, slot)
+	if err != nil {
+		return nil, fmt.Errorf("could not replay blocks on top of state snapshot: %v", err)
+	}
+	db.stateCache.Add(key, proto.Clone(beaconState))
+	return beaconState, nil
+}
+
+// replayBlocks runs the state transition from the given snapshot up to the given slot.
+// The blocks are ordered from the most recent one down, and slots without a block are
+// processed as skipped slots.
+func replayBlocks(beaconState *pb.BeaconState, prevBlockRoot [32]byte, blocks []*pb.BeaconBlock, slot uint64) (*pb.BeaconState, error) {
+	if beaconState.Slot > slot {
+		return nil, fmt.Errorf(
+			"state snapshot slot %d is after slot %d",
+			beaconState.Slot-params.BeaconConfig().GenesisSlot,
+			slot-params.BeaconConfig().GenesisSlot,
+		)
+	}
+	next := len(blocks) - 1
+	for beaconState.Slot < slot {
+		var block *pb.BeaconBlock
+		if next >= 0 && blocks[next].Slot == beaconState.Slot+1 {
+			block = blocks[next]
+			next--
+		}
+
+		newState, err := state.ExecuteStateTransition(beaconState, block, prevBlockRoot, false)
+		if err != nil {
+			return nil, fmt.Errorf("could not execute state transition at slot %d: %v",
+				beaconState.Slot-params.BeaconConfig().GenesisSlot, err)
+		}
+		beaconState = newState
+		if block != nil {
+			prevBlockRoot, err = hashutil.HashBeaconBlock(block)
+			if err != nil {
+				return nil, fmt.Errorf("could not tree hash block: %v", err)
+			}
+		}
+	}
+	if next >= 0 {
+		return nil, fmt.Errorf("block at slot %d does not follow the state snapshot", blocks[next].Slot-params.BeaconConfig().GenesisSlot)
+	}
+	return beaconState, nil
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestUpdateChainHead_SavesStateSnapshot(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	interval := stateSnapshotEpochInterval * params.BeaconConfig().SlotsPerEpoch
+	genesis := &pb.BeaconBlock{Slot: genesisSlot}
+	genesisRoot, _ := hashutil.HashBeaconBlock(genesis)
+	block1 := &pb.BeaconBlock{Slot: genesisSlot + 1, ParentRootHash32: genesisRoot[:]}
+	block1Root, _ := hashutil.HashBeaconBlock(block1)
+	block2 := &pb.BeaconBlock{Slot: genesisSlot + interval, ParentRootHash32: block1Root[:]}
+	block2Root, _ := hashutil.HashBeaconBlock(block2)
+
+	for _, block := range []*pb.BeaconBlock{genesis, block1, block2} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+		if err := db.UpdateChainHead(block, &pb.BeaconState{Slot: block.Slot}); err != nil {
+			t.Fatalf("failed to update chain head: %v", err)
+		}
+	}
+
+	for root, wantSnapshot := range map[[32]byte]bool{genesisRoot: true, block1Root: false, block2Root: true} {
+		snapshot, err := db.StateSnapshot(root)
+		if err != nil {
+			t.Fatalf("failed to get state snapshot: %v", err)
+		}
+		if (snapshot != nil) != wantSnapshot {
+			t.Errorf("expected snapshot of block %#x to exist: %v, received %v", root, wantSnapshot, snapshot)
+		}
+	}
+}
+
+func TestStateAtSlot_ReplaysBlocks(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 10)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	genesis, err := db.BlockBySlot(genesisSlot)
+	if err != nil {
+		t.Fatalf("failed to get genesis block: %v", err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisState, err := db.State()
+	if err != nil {
+		t.Fatalf("failed to get genesis state: %v", err)
+	}
+
+	skippedSlotState, err := state.ExecuteStateTransition(
+		proto.Clone(genesisState).(*pb.BeaconState), nil, genesisRoot, false)
+	if err != nil {
+		t.Fatalf("failed to process skipped slot: %v", err)
+	}
+	block := &pb.BeaconBlock{
+		Slot:             genesisSlot + 2,
+		ParentRootHash32: genesisRoot[:],
+		Eth1Data: &pb.Eth1Data{
+			DepositRootHash32: []byte("a"),
+			BlockHash32:       []byte("b"),
+		},
+		Body: &pb.BeaconBlockBody{},
+	}
+	headState, err := state.ExecuteStateTransition(
+		proto.Clone(skippedSlotState).(*pb.BeaconState), block, genesisRoot, false)
+	if err != nil {
+		t.Fatalf("failed to process block: %v", err)
+	}
+	if err := db.SaveBlock(block); err != nil {
+		t.Fatalf("failed to save block: %v", err)
+	}
+	if err := db.UpdateChainHead(block, headState); err != nil {
+		t.Fatalf("failed to update chain head: %v", err)
+	}
+
+	tests := []struct {
+		slot uint64
+		want *pb.BeaconState
+	}{
+		{slot: genesisSlot, want: genesisState},
+		{slot: genesisSlot + 1, want: skippedSlotState},
+		{slot: genesisSlot + 2, want: headState},
+	}
+	for _, tt := range tests {
+		beaconState, err := db.StateAtSlot(tt.slot)
+		if err != nil {
+			t.Fatalf("failed to regenerate state at slot %d: %v", tt.slot-genesisSlot, err)
+		}
+		if !proto.Equal(beaconState, tt.want) {
+			t.Errorf("unexpected state at slot %d", tt.slot-genesisSlot)
+		}
+		// Modifying the returned state must not affect the cached state.
+		beaconState.Slot = 0
+		beaconState, err = db.StateAtSlot(tt.slot)
+		if err != nil {
+			t.Fatalf("failed to fetch cached state at slot %d: %v", tt.slot-genesisSlot, err)
+		}
+		if !proto.Equal(beaconState, tt.want) {
+			t.Errorf("unexpected cached state at slot %d", tt.slot-genesisSlot)
+		}
+	}
+}
+
+func TestStateAtSlot_AfterChainHead(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 10)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("failed to initialize state: %v", err)
+	}
+	if _, err := db.StateAtSlot(params.BeaconConfig().GenesisSlot + 1); err == nil {
+		t.Error("expected regenerating a state after the chain head to fail")
+	}
+}
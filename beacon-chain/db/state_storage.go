@@ -0,0 +1,306 @@
+package db
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/ssz"
+)
+
+// A state is stored as the SSZ encoding of its small fields, along with the hashes of the
+// chunks holding its large list fields. Each of these fields is split into chunks of
+// stateChunkLength elements, stored once in the state chunk bucket under the hash of their
+// SSZ encoding. Consecutive states share the chunks which did not change between them, so
+// storing a state only writes the chunks of the list elements which changed since.
+//
+// The number of stored states referring to each chunk is kept in the state chunk references
+// bucket, and a chunk is deleted once no state refers to it anymore. States must therefore
+// only be written with putStoredState and deleted with deleteStoredState.
+
+// stateChunkFormat is the first byte of a stored state.
+const stateChunkFormat byte = 1
+
+// stateChunkLength is the number of list elements per chunk.
+const stateChunkLength = 256
+
+// chunkedStateFields are the names of the list fields of the state which are stored in chunks.
+var chunkedStateFields = []string{
+	"ValidatorRegistry",
+	"ValidatorBalances",
+	"LatestRandaoMixes",
+	"LatestBlockRootHash32S",
+	"LatestIndexRootHash32S",
+	"LatestSlashedBalances",
+}
+
+// storedState is the stored encoding of a state.
+type storedState struct {
+	// State holds the fields of the state which are not stored in chunks.
+	State *pb.BeaconState
+	// ChunkCounts holds the number of chunks of each chunked field.
+	ChunkCounts []uint64
+	// Chunks holds the hashes of the chunks of every chunked field, in field order.
+	Chunks [][]byte
+}
+
+// encodeState returns the stored encoding of the state, along with the encoded chunks it
+// refers to by hash.
+func encodeState(beaconState *pb.BeaconState) ([]byte, map[[32]byte][]byte, error) {
+	residual := *beaconState
+	stored := &storedState{State: &residual}
+	chunks := make(map[[32]byte][]byte)
+
+	src := reflect.ValueOf(beaconState).Elem()
+	dst := reflect.ValueOf(&residual).Elem()
+	for _, name := range chunkedStateFields {
+		list := src.FieldByName(name)
+		var count uint64
+		for i := 0; i < list.Len(); i += stateChunkLength {
+			end := i + stateChunkLength
+			if end > list.Len() {
+				end = list.Len()
+			}
+			buf := new(bytes.Buffer)
+			if err := ssz.Encode(buf, list.Slice(i, end).Interface()); err != nil {
+				return nil, nil, fmt.Errorf("could not encode %s chunk: %v", name, err)
+			}
+			h := hashutil.Hash(buf.Bytes())
+			chunks[h] = buf.Bytes()
+			stored.Chunks = append(stored.Chunks, h[:])
+			count++
+		}
+		stored.ChunkCounts = append(stored.ChunkCounts, count)
+		field := dst.FieldByName(name)
+		field.Set(reflect.Zero(field.Type()))
+	}
+
+	buf := bytes.NewBuffer([]byte{stateChunkFormat})
+	if err := ssz.Encode(buf, stored); err != nil {
+		return nil, nil, fmt.Errorf("could not encode state: %v", err)
+	}
+	return buf.Bytes(), chunks, nil
+}
+
+func decodeStoredState(enc []byte) (*storedState, error) {
+	if len(enc) == 0 || enc[0] != stateChunkFormat {
+		return nil, errors.New("unknown state encoding")
+	}
+	stored := &storedState{}
+	if err := ssz.Decode(bytes.NewReader(enc[1:]), stored); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %v", err)
+	}
+	if len(stored.ChunkCounts) != len(chunkedStateFields) {
+		return nil, fmt.Errorf("expected chunk counts of %d fields, received %d", len(chunkedStateFields), len(stored.ChunkCounts))
+	}
+	var total uint64
+	for _, count := range stored.ChunkCounts {
+		total += count
+	}
+	if total != uint64(len(stored.Chunks)) {
+		return nil, fmt.Errorf("expected %d chunks, received %d", total, len(stored.Chunks))
+	}
+	if stored.State == nil {
+		stored.State = &pb.BeaconState{}
+	}
+	return stored, nil
+}
+
+// decodeState rebuilds a state from its stored encoding and the chunks it refers to.
+func decodeState(tx storage.Tx, enc []byte) (*pb.BeaconState, error) {
+	stored, err := decodeStoredState(enc)
+	if err != nil {
+		return nil, err
+	}
+	chunkBkt := tx.Bucket(stateChunkBucket)
+	beaconState := stored.State
+	dst := reflect.ValueOf(beaconState).Elem()
+	chunks := stored.Chunks
+	for i, name := range chunkedStateFields {
+		field := dst.FieldByName(name)
+		list := reflect.Zero(field.Type())
+		for _, h := range chunks[:stored.ChunkCounts[i]] {
+			chunkEnc := chunkBkt.Get(h)
+			if chunkEnc == nil {
+				return nil, fmt.Errorf("%s chunk %#x not found", name, h)
+			}
+			chunk := reflect.New(field.Type())
+			if err := ssz.Decode(bytes.NewReader(chunkEnc), chunk.Interface()); err != nil {
+				return nil, fmt.Errorf("failed to decode %s chunk: %v", name, err)
+			}
+			list = reflect.AppendSlice(list, chunk.Elem())
+		}
+		field.Set(list)
+		chunks = chunks[stored.ChunkCounts[i]:]
+	}
+	return beaconState, nil
+}
+
+// saveStateChunks stores the chunks of the state which are not stored yet, and returns the
+// stored encoding of the state. The new chunks are only kept once the encoding is written
+// with putStoredState.
+func saveStateChunks(tx storage.Tx, beaconState *pb.BeaconState) ([]byte, error) {
+	enc, chunks, err := encodeState(beaconState)
+	if err != nil {
+		return nil, err
+	}
+	chunkBkt := tx.Bucket(stateChunkBucket)
+	for h, chunkEnc := range chunks {
+		if chunkBkt.Get(h[:]) != nil {
+			continue
+		}
+		if err := chunkBkt.Put(h[:], chunkEnc); err != nil {
+			return nil, fmt.Errorf("failed to save state chunk: %v", err)
+		}
+	}
+	return enc, nil
+}
+
+// addChunkReferences adds sign times the references of the stored state to the chunks to
+// the reference count deltas.
+func addChunkReferences(deltas map[string]int64, enc []byte, sign int64) error {
+	stored, err := decodeStoredState(enc)
+	if err != nil {
+		return err
+	}
+	for _, h := range stored.Chunks {
+		deltas[string(h)] += sign
+	}
+	return nil
+}
+
+// updateChunkReferences applies the reference count deltas to the chunks, and deletes the
+// chunks which are no longer referred to. Chunks whose reference count is unchanged are
+// not written.
+func updateChunkReferences(tx storage.Tx, deltas map[string]int64) error {
+	chunkBkt := tx.Bucket(stateChunkBucket)
+	refsBkt := tx.Bucket(stateChunkRefsBucket)
+	for h, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		var refs int64
+		if enc := refsBkt.Get([]byte(h)); enc != nil {
+			refs = int64(bytesutil.FromBytes8(enc))
+		}
+		refs += delta
+		if refs > 0 {
+			if err := refsBkt.Put([]byte(h), bytesutil.Bytes8(uint64(refs))); err != nil {
+				return fmt.Errorf("failed to update state chunk references: %v", err)
+			}
+			continue
+		}
+		if err := refsBkt.Delete([]byte(h)); err != nil {
+			return fmt.Errorf("failed to delete state chunk references: %v", err)
+		}
+		if err := chunkBkt.Delete([]byte(h)); err != nil {
+			return fmt.Errorf("failed to delete state chunk: %v", err)
+		}
+	}
+	return nil
+}
+
+// putStoredState writes the stored encoding of a state under the key, and releases the
+// chunks of the state it replaces.
+func putStoredState(tx storage.Tx, bkt storage.Bucket, key []byte, enc []byte) error {
+	deltas := make(map[string]int64)
+	if prev := bkt.Get(key); prev != nil {
+		if bytes.Equal(prev, enc) {
+			return nil
+		}
+		if err := addChunkReferences(deltas, prev, -1); err != nil {
+			return err
+		}
+	}
+	if err := addChunkReferences(deltas, enc, 1); err != nil {
+		return err
+	}
+	if err := updateChunkReferences(tx, deltas); err != nil {
+		return err
+	}
+	return bkt.Put(key, enc)
+}
+
+// deleteStoredState deletes the state stored under the key, and releases its chunks.
+func deleteStoredState(tx storage.Tx, bkt storage.Bucket, key []byte) error {
+	enc := bkt.Get(key)
+	if enc == nil {
+		return nil
+	}
+	deltas := make(map[string]int64)
+	if err := addChunkReferences(deltas, enc, -1); err != nil {
+		return err
+	}
+	if err := updateChunkReferences(tx, deltas); err != nil {
+		return err
+	}
+	return bkt.Delete(key)
+}
+
+// storedStateRoot returns the root of a stored state, which is the hash of the protobuf
+// encoding of the state that blocks commit to.
+func storedStateRoot(tx storage.Tx, enc []byte) ([32]byte, error) {
+	beaconState, err := decodeState(tx, enc)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return hashutil.HashProto(beaconState)
+}
+
+// migrateStateChunks converts the states stored as protobuf encodings to the chunked
+// state encoding.
+func migrateStateChunks(tx storage.Tx) error {
+	chainInfo := tx.Bucket(chainInfoBucket)
+	snapshots := tx.Bucket(stateSnapshotBucket)
+
+	type storedKey struct {
+		bkt storage.Bucket
+		key []byte
+	}
+	keys := []storedKey{{chainInfo, stateLookupKey}}
+	seen := make(map[[32]byte]bool)
+	if err := tx.Bucket(blockStateRootBucket).ForEach(func(_, stateRoot []byte) error {
+		root := bytesutil.ToBytes32(stateRoot)
+		if !seen[root] {
+			seen[root] = true
+			keys = append(keys, storedKey{chainInfo, append([]byte{}, stateRoot...)})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := snapshots.ForEach(func(blockRoot, _ []byte) error {
+		keys = append(keys, storedKey{snapshots, append([]byte{}, blockRoot...)})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		protoEnc := k.bkt.Get(k.key)
+		if protoEnc == nil {
+			continue
+		}
+		beaconState := &pb.BeaconState{}
+		if err := proto.Unmarshal(protoEnc, beaconState); err != nil {
+			return fmt.Errorf("failed to unmarshal state %#x: %v", k.key, err)
+		}
+		if err := k.bkt.Delete(k.key); err != nil {
+			return err
+		}
+		enc, err := saveStateChunks(tx, beaconState)
+		if err != nil {
+			return err
+		}
+		if err := putStoredState(tx, k.bkt, k.key, enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
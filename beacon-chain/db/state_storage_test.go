@@ -0,0 +1,164 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// chunkedTestState returns a state whose list fields span several chunks.
+func chunkedTestState() *pb.BeaconState {
+	validators := make([]*pb.Validator, stateChunkLength+10)
+	balances := make([]uint64, len(validators))
+	for i := range validators {
+		validators[i] = &pb.Validator{Pubkey: []byte{byte(i), byte(i >> 8)}, ExitEpoch: params.BeaconConfig().FarFutureEpoch}
+		balances[i] = params.BeaconConfig().MaxDepositAmount
+	}
+	mixes := make([][]byte, 2*stateChunkLength)
+	for i := range mixes {
+		mixes[i] = make([]byte, 32)
+	}
+	return &pb.BeaconState{
+		Slot:              params.BeaconConfig().GenesisSlot + 3,
+		ValidatorRegistry: validators,
+		ValidatorBalances: balances,
+		LatestRandaoMixes: mixes,
+		Fork:              &pb.Fork{CurrentVersion: 1},
+		LatestEth1Data:    &pb.Eth1Data{DepositRootHash32: []byte("deposit root")},
+	}
+}
+
+func countChunks(t *testing.T, db *BeaconDB) int {
+	var count int
+	if err := db.view(func(tx storage.Tx) error {
+		return tx.Bucket(stateChunkBucket).ForEach(func(k, v []byte) error {
+			count++
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestSaveState_RoundTripsChunkedState(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := chunkedTestState()
+	if err := db.SaveState(beaconState); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	retrieved, err := db.State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if !proto.Equal(beaconState, retrieved) {
+		t.Fatalf("expected state %v, received %v", beaconState, retrieved)
+	}
+	want, err := hashutil.HashProto(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := hashutil.HashProto(retrieved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("expected state root %#x, received %#x", want, root)
+	}
+}
+
+func TestSaveState_SharesUnchangedChunks(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := chunkedTestState()
+	if err := db.SaveState(beaconState); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	initial := countChunks(t, db)
+
+	// Changing one balance only replaces the balance chunk holding it.
+	beaconState.ValidatorBalances[0]++
+	beaconState.Slot++
+	if err := db.SaveState(beaconState); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	if count := countChunks(t, db); count != initial {
+		t.Errorf("expected the replaced chunk to be released, received %d chunks instead of %d", count, initial)
+	}
+
+	blockRoot := [32]byte{'A'}
+	if err := db.SaveUnfinalizedBlockState(blockRoot, chunkedTestState()); err != nil {
+		t.Fatalf("failed to save block state: %v", err)
+	}
+	if count := countChunks(t, db); count != initial+1 {
+		t.Errorf("expected the states to share every other chunk, received %d chunks instead of %d", count, initial+1)
+	}
+	retrieved, err := db.State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if !proto.Equal(beaconState, retrieved) {
+		t.Error("expected the canonical state to be unaffected by the block state")
+	}
+}
+
+func TestMigrateStateChunks_ConvertsProtobufStates(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := chunkedTestState()
+	enc, err := proto.Marshal(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateRoot := hashutil.Hash(enc)
+	blockRoot := [32]byte{'A'}
+	// Write the states the way a DB storing protobuf encodings recorded them.
+	if err := db.update(func(tx storage.Tx) error {
+		chainInfo := tx.Bucket(chainInfoBucket)
+		if err := chainInfo.Put(stateLookupKey, enc); err != nil {
+			return err
+		}
+		if err := chainInfo.Put(stateRoot[:], enc); err != nil {
+			return err
+		}
+		if err := tx.Bucket(blockStateRootBucket).Put(blockRoot[:], stateRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSnapshotBucket).Put(blockRoot[:], enc); err != nil {
+			return err
+		}
+		return migrateStateChunks(tx)
+	}); err != nil {
+		t.Fatalf("failed to migrate states: %v", err)
+	}
+
+	retrieved, err := db.State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if !proto.Equal(beaconState, retrieved) {
+		t.Error("expected the canonical state to be converted")
+	}
+	retrieved, err = db.BlockState(blockRoot)
+	if err != nil {
+		t.Fatalf("failed to get block state: %v", err)
+	}
+	if !proto.Equal(beaconState, retrieved) {
+		t.Error("expected the block state to be converted")
+	}
+	retrieved, err = db.StateSnapshot(blockRoot)
+	if err != nil {
+		t.Fatalf("failed to get state snapshot: %v", err)
+	}
+	if !proto.Equal(beaconState, retrieved) {
+		t.Error("expected the state snapshot to be converted")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
@@ -105,4 +106,188 @@ func TestGenesisTime_OK(t *testing.T) {
 	if time1 != time2 {
 		t.Fatalf("Expected %v and %v to be equal", time1, time2)
 	}
+
+	beaconState, err := db.State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if uint64(time1.Unix()) != beaconState.GenesisTime {
+		t.Errorf("Expected the genesis time %d of the state, received %d", beaconState.GenesisTime, time1.Unix())
+	}
+}
+
+func TestBlockState_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	deposits, _ := setupInitialDeposits(t, 10)
+	if err := db.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("Failed to initialize state: %v", err)
+	}
+	genesis, err := db.ChainHead()
+	if err != nil {
+		t.Fatalf("Failed to get chain head: %v", err)
+	}
+	genesisRoot, err := hashutil.HashBeaconBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisState, err := db.BlockState(genesisRoot)
+	if err != nil {
+		t.Fatalf("Failed to get genesis block state: %v", err)
+	}
+	canonicalState, err := db.State()
+	if err != nil {
+		t.Fatalf("Failed to get state: %v", err)
+	}
+	if !proto.Equal(genesisState, canonicalState) {
+		t.Error("Expected genesis block state to equal the genesis state")
+	}
+
+	blockRoot := [32]byte{'a'}
+	if s, err := db.BlockState(blockRoot); err != nil || s != nil {
+		t.Fatalf("Expected no state for an unknown block, received %v, %v", s, err)
+	}
+	postState := &pb.BeaconState{Slot: params.BeaconConfig().GenesisSlot + 1}
+	if err := db.SaveUnfinalizedBlockState(blockRoot, postState); err != nil {
+		t.Fatalf("Failed to save block state: %v", err)
+	}
+	received, err := db.BlockState(blockRoot)
+	if err != nil {
+		t.Fatalf("Failed to get block state: %v", err)
+	}
+	if !proto.Equal(received, postState) {
+		t.Errorf("Expected %v, received %v", postState, received)
+	}
+	stateRoot, err := hashutil.HashProto(postState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byStateRoot, err := db.UnfinalizedBlockState(stateRoot)
+	if err != nil {
+		t.Fatalf("Failed to get state by state root: %v", err)
+	}
+	if !proto.Equal(byStateRoot, postState) {
+		t.Errorf("Expected %v, received %v", postState, byStateRoot)
+	}
+}
+
+func TestDeleteBlockStates_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	oldBlock := &pb.BeaconBlock{Slot: 1}
+	newBlock := &pb.BeaconBlock{Slot: 3}
+	var roots [][32]byte
+	for i, block := range []*pb.BeaconBlock{oldBlock, newBlock} {
+		if err := db.SaveBlock(block); err != nil {
+			t.Fatalf("failed to save block: %v", err)
+		}
+		root, err := hashutil.HashBeaconBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveUnfinalizedBlockState(root, &pb.BeaconState{Slot: uint64(i)}); err != nil {
+			t.Fatalf("failed to save block state: %v", err)
+		}
+		roots = append(roots, root)
+	}
+	// A state whose block no longer exists.
+	missingRoot := [32]byte{'m'}
+	if err := db.SaveUnfinalizedBlockState(missingRoot, &pb.BeaconState{Slot: 10}); err != nil {
+		t.Fatalf("failed to save block state: %v", err)
+	}
+
+	deleted, err := db.DeleteBlockStates(2)
+	if err != nil {
+		t.Fatalf("failed to delete block states: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 deleted states, received %d", deleted)
+	}
+	for _, root := range [][32]byte{roots[0], missingRoot} {
+		if s, err := db.BlockState(root); err != nil || s != nil {
+			t.Errorf("expected state of block %#x to be deleted, received %v, %v", root, s, err)
+		}
+	}
+	if s, err := db.BlockState(roots[1]); err != nil || s == nil {
+		t.Errorf("expected state of block at slot %d to be kept, received %v, %v", newBlock.Slot, s, err)
+	}
+}
+
+func TestInitializeStateFromCheckpoint_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := &pb.BeaconState{
+		Slot:              params.BeaconConfig().GenesisSlot + 128,
+		FinalizedEpoch:    params.BeaconConfig().GenesisEpoch + 2,
+		ValidatorRegistry: []*pb.Validator{{Pubkey: []byte{'a'}}, {Pubkey: []byte{'b'}}},
+	}
+	stateRoot, err := hashutil.HashProto(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pb.BeaconBlock{Slot: beaconState.Slot, StateRootHash32: stateRoot[:]}
+	if err := db.InitializeStateFromCheckpoint(block, beaconState); err != nil {
+		t.Fatalf("Failed to initialize state from checkpoint: %v", err)
+	}
+
+	head, err := db.ChainHead()
+	if err != nil {
+		t.Fatalf("Failed to get chain head: %v", err)
+	}
+	if !proto.Equal(head, block) {
+		t.Errorf("Expected head %v, received %v", block, head)
+	}
+	justified, err := db.JustifiedBlock()
+	if err != nil {
+		t.Fatalf("Failed to get justified block: %v", err)
+	}
+	if !proto.Equal(justified, block) {
+		t.Errorf("Expected justified block %v, received %v", block, justified)
+	}
+	canonicalState, err := db.State()
+	if err != nil {
+		t.Fatalf("Failed to get state: %v", err)
+	}
+	if !proto.Equal(canonicalState, beaconState) {
+		t.Errorf("Expected state %v, received %v", beaconState, canonicalState)
+	}
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockState, err := db.BlockState(blockRoot)
+	if err != nil {
+		t.Fatalf("Failed to get block state: %v", err)
+	}
+	if !proto.Equal(blockState, beaconState) {
+		t.Errorf("Expected block state %v, received %v", beaconState, blockState)
+	}
+	index, err := db.ValidatorIndex([]byte{'b'})
+	if err != nil {
+		t.Fatalf("Failed to get validator index: %v", err)
+	}
+	if index != 1 {
+		t.Errorf("Expected validator index 1, received %d", index)
+	}
+
+	if err := db.InitializeStateFromCheckpoint(block, beaconState); err == nil {
+		t.Error("Expected initializing an existing chain to fail")
+	}
+}
+
+func TestInitializeStateFromCheckpoint_StateRootMismatch(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := &pb.BeaconState{Slot: params.BeaconConfig().GenesisSlot + 128}
+	block := &pb.BeaconBlock{Slot: beaconState.Slot, StateRootHash32: []byte{'x'}}
+	if err := db.InitializeStateFromCheckpoint(block, beaconState); err == nil {
+		t.Error("Expected a block which does not commit to the state to be rejected")
+	}
+	if s, err := db.State(); err != nil || s != nil {
+		t.Errorf("Expected no state to be saved, received %v, %v", s, err)
+	}
 }
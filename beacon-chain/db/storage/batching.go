@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchingBackend buffers the writes of its transactions in memory, and writes them to
+// the underlying engine in a single transaction once the size of the writes buffered since
+// the last flush exceeds a threshold, once the oldest buffered write is older than a
+// maximum delay, or when Flush is called. Transactions read the buffered writes on top of
+// the content of the underlying engine, so they observe every committed write whether it
+// has been flushed or not.
+//
+// The buffered writes are flushed all at once, so the underlying engine only ever holds
+// the content as of a committed transaction. A crash loses the writes since the last
+// flush, but never leaves a reference, such as the chain head, to data which was not
+// written along with it.
+type BatchingBackend struct {
+	backend         Backend
+	maxPendingBytes int
+	maxDelay        time.Duration
+
+	// flushLock serializes the flushes, which write to the underlying engine without
+	// holding lock, so transactions are not blocked by the write.
+	flushLock    sync.Mutex
+	lock         sync.RWMutex
+	pending      *overlayTx
+	pendingBytes int
+	// The buffered writes being flushed, which are read below the pending writes until
+	// the flush completes.
+	flushing *overlayTx
+	timer    *time.Timer
+	// The error of the last flush triggered by the timer, returned by the next write.
+	flushErr error
+}
+
+// NewBatchingBackend wraps the storage engine with a write buffer, which is flushed once
+// maxPendingBytes of keys and values were written to it, or once its oldest write is older
+// than maxDelay.
+func NewBatchingBackend(backend Backend, maxPendingBytes int, maxDelay time.Duration) *BatchingBackend {
+	return &BatchingBackend{
+		backend:         backend,
+		maxPendingBytes: maxPendingBytes,
+		maxDelay:        maxDelay,
+		pending:         newOverlayTx(nil, false),
+	}
+}
+
+// layer returns a transaction of the underlying engine, seen through the buffered writes.
+func (b *BatchingBackend) layer(tx Tx, writable bool) *overlayTx {
+	var committed store = &txStore{tx: tx}
+	if b.flushing != nil {
+		committed = &overlayTx{
+			store:   committed,
+			created: b.flushing.created,
+			deleted: b.flushing.deleted,
+			changes: b.flushing.changes,
+		}
+	}
+	pending := &overlayTx{
+		store:   committed,
+		created: b.pending.created,
+		deleted: b.pending.deleted,
+		changes: b.pending.changes,
+	}
+	return newOverlayTx(pending, writable)
+}
+
+// View runs the function in a read-only transaction, which reads the buffered writes.
+func (b *BatchingBackend) View(fn func(Tx) error) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.backend.View(func(tx Tx) error {
+		layer := b.layer(tx, false)
+		if err := fn(layer); err != nil {
+			return err
+		}
+		if layer.err != nil {
+			return layer.err
+		}
+		return layer.store.(*overlayTx).err
+	})
+}
+
+// Update runs the function in a read-write transaction, whose writes are buffered if the
+// function returns nil.
+func (b *BatchingBackend) Update(fn func(Tx) error) error {
+	b.lock.RLock()
+	failed := b.flushErr != nil
+	b.lock.RUnlock()
+	if failed {
+		if err := b.Flush(); err != nil {
+			return err
+		}
+	}
+
+	full, err := b.update(fn)
+	if err != nil {
+		return err
+	}
+	if full {
+		return b.Flush()
+	}
+	return nil
+}
+
+// update buffers the writes of the function, and returns whether the buffer is full.
+func (b *BatchingBackend) update(fn func(Tx) error) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var layer *overlayTx
+	if err := b.backend.View(func(tx Tx) error {
+		layer = b.layer(tx, true)
+		if err := fn(layer); err != nil {
+			return err
+		}
+		if layer.err != nil {
+			return layer.err
+		}
+		return layer.store.(*overlayTx).err
+	}); err != nil {
+		return false, err
+	}
+	b.buffer(layer)
+
+	if b.pendingBytes >= b.maxPendingBytes {
+		return true, nil
+	}
+	if b.timer == nil && b.hasPending() {
+		b.timer = time.AfterFunc(b.maxDelay, b.flushOnTimer)
+	}
+	return false, nil
+}
+
+// Batch behaves like Update, as every write is already batched.
+func (b *BatchingBackend) Batch(fn func(Tx) error) error {
+	return b.Update(fn)
+}
+
+// Flush writes the buffered writes to the underlying engine. Transactions keep reading
+// and buffering writes while the flush writes to the underlying engine. The buffered
+// writes are kept if the write fails, so the next flush retries them.
+func (b *BatchingBackend) Flush() error {
+	b.flushLock.Lock()
+	defer b.flushLock.Unlock()
+
+	b.lock.Lock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if !b.hasPending() {
+		b.flushErr = nil
+		b.lock.Unlock()
+		return nil
+	}
+	flushing, flushingBytes := b.pending, b.pendingBytes
+	b.flushing = flushing
+	b.pending = newOverlayTx(nil, false)
+	b.pendingBytes = 0
+	b.lock.Unlock()
+
+	err := b.write(flushing)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.flushing = nil
+	if err != nil {
+		// The writes buffered during the flush are newer than the flushed ones.
+		newer := b.pending
+		b.pending, b.pendingBytes = flushing, flushingBytes
+		b.buffer(newer)
+		return err
+	}
+	b.flushErr = nil
+	return nil
+}
+
+// Close flushes the buffered writes and closes the underlying engine. The engine is closed
+// even if the flush fails, and the flush error is returned.
+func (b *BatchingBackend) Close() error {
+	flushErr := b.Flush()
+	if err := b.backend.Close(); err != nil {
+		return err
+	}
+	if flushErr != nil {
+		return fmt.Errorf("could not flush buffered writes: %v", flushErr)
+	}
+	return nil
+}
+
+func (b *BatchingBackend) flushOnTimer() {
+	err := b.Flush()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.flushErr = err
+}
+
+func (b *BatchingBackend) hasPending() bool {
+	return len(b.pending.created) > 0 || len(b.pending.deleted) > 0 || len(b.pending.changes) > 0
+}
+
+// buffer adds the writes of a committed transaction to the buffered writes.
+func (b *BatchingBackend) buffer(tx *overlayTx) {
+	p := b.pending
+	for name := range tx.deleted {
+		delete(p.created, name)
+		delete(p.changes, name)
+		p.deleted[name] = true
+	}
+	for name := range tx.created {
+		p.created[name] = true
+	}
+	for name, changes := range tx.changes {
+		bkt, ok := p.changes[name]
+		if !ok {
+			bkt = make(map[string]change)
+			p.changes[name] = bkt
+		}
+		for k, c := range changes {
+			bkt[k] = c
+			b.pendingBytes += len(k) + len(c.value)
+		}
+	}
+}
+
+// write writes the buffered writes in a single transaction of the underlying engine.
+func (b *BatchingBackend) write(p *overlayTx) error {
+	return b.backend.Update(func(tx Tx) error {
+		for name := range p.deleted {
+			if err := tx.DeleteBucket([]byte(name)); err != nil {
+				return err
+			}
+		}
+		for name := range p.created {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		for name, changes := range p.changes {
+			bkt := tx.Bucket([]byte(name))
+			if bkt == nil {
+				continue
+			}
+			for k, c := range changes {
+				if c.deleted {
+					if err := bkt.Delete([]byte(k)); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := bkt.Put([]byte(k), c.value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// txStore reads the committed content of a transaction of another storage engine.
+type txStore struct {
+	tx Tx
+}
+
+func (s *txStore) hasBucket(name string) (bool, error) {
+	return s.tx.Bucket([]byte(name)) != nil, nil
+}
+
+func (s *txStore) get(bucket string, key []byte) ([]byte, error) {
+	bkt := s.tx.Bucket([]byte(bucket))
+	if bkt == nil {
+		return nil, nil
+	}
+	return bkt.Get(key), nil
+}
+
+func (s *txStore) entries(bucket string) ([]entry, error) {
+	bkt := s.tx.Bucket([]byte(bucket))
+	if bkt == nil {
+		return nil, nil
+	}
+	var entries []entry
+	err := bkt.ForEach(func(k, v []byte) error {
+		entries = append(entries, entry{key: k, value: v})
+		return nil
+	})
+	return entries, err
+}
+
+func (s *txStore) cursor(bucket string) (Cursor, error) {
+	bkt := s.tx.Bucket([]byte(bucket))
+	if bkt == nil {
+		return &sliceCursor{}, nil
+	}
+	return bkt.Cursor(), nil
+}
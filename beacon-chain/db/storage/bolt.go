@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+type boltBackend struct {
+	db *bolt.DB
+}
+
+// NewBoltBackend opens the bolt file of the given directory, creating it if needed.
+func NewBoltBackend(dirPath string) (Backend, error) {
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		return nil, err
+	}
+	datafile := path.Join(dirPath, "beaconchain.db")
+	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	return &boltBackend{db: boltDB}, nil
+}
+
+func (b *boltBackend) View(fn func(Tx) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltBackend) Update(fn func(Tx) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltBackend) Batch(fn func(Tx) error) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltBackend) Close() error {
+	return b.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) Bucket(name []byte) Bucket {
+	bkt := t.tx.Bucket(name)
+	if bkt == nil {
+		return nil
+	}
+	return &boltBucket{bkt: bkt}
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bkt, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{bkt: bkt}, nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	if err := t.tx.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
+type boltBucket struct {
+	bkt *bolt.Bucket
+}
+
+func (b *boltBucket) Get(key []byte) []byte {
+	return b.bkt.Get(key)
+}
+
+func (b *boltBucket) Put(key []byte, value []byte) error {
+	if !b.bkt.Writable() {
+		return ErrTxNotWritable
+	}
+	return b.bkt.Put(key, value)
+}
+
+func (b *boltBucket) Delete(key []byte) error {
+	if !b.bkt.Writable() {
+		return ErrTxNotWritable
+	}
+	return b.bkt.Delete(key)
+}
+
+func (b *boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return b.bkt.ForEach(fn)
+}
+
+func (b *boltBucket) Cursor() Cursor {
+	return b.bkt.Cursor()
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// The LevelDB keyspace is flat, so buckets are emulated with key prefixes. Each bucket
+// is recorded under its name, and its keys are stored after a prefix made of the length
+// of the bucket name followed by the name.
+const (
+	levelDBBucketPrefix byte = iota
+	levelDBKeyPrefix
+)
+
+func levelDBBucketKey(name string) []byte {
+	return append([]byte{levelDBBucketPrefix}, name...)
+}
+
+func levelDBKeyPrefixOf(bucket string) []byte {
+	return append([]byte{levelDBKeyPrefix, byte(len(bucket))}, bucket...)
+}
+
+func levelDBKey(bucket string, key []byte) []byte {
+	return append(levelDBKeyPrefixOf(bucket), key...)
+}
+
+// levelDBBackend stores its buckets in a LevelDB log-structured merge-tree. Transactions
+// read from a snapshot of the DB, and the writes of a read-write transaction are applied
+// in a single atomic batch when it commits. Read-write transactions are serialized.
+type levelDBBackend struct {
+	db        *leveldb.DB
+	writeLock sync.Mutex
+}
+
+// NewLevelDBBackend opens the LevelDB directory of the given directory, creating it if needed.
+func NewLevelDBBackend(dirPath string) (Backend, error) {
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		return nil, err
+	}
+	db, err := leveldb.OpenFile(path.Join(dirPath, "beaconchain.ldb"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not open leveldb: %v", err)
+	}
+	return &levelDBBackend{db: db}, nil
+}
+
+func (l *levelDBBackend) View(fn func(Tx) error) error {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return fmt.Errorf("could not get leveldb snapshot: %v", err)
+	}
+	defer snapshot.Release()
+
+	s := &levelDBStore{snapshot: snapshot}
+	defer s.release()
+	tx := newOverlayTx(s, false)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.err
+}
+
+func (l *levelDBBackend) Update(fn func(Tx) error) error {
+	l.writeLock.Lock()
+	defer l.writeLock.Unlock()
+
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return fmt.Errorf("could not get leveldb snapshot: %v", err)
+	}
+	defer snapshot.Release()
+
+	s := &levelDBStore{snapshot: snapshot}
+	defer s.release()
+	tx := newOverlayTx(s, true)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if tx.err != nil {
+		return tx.err
+	}
+	batch, err := s.batch(tx)
+	if err != nil {
+		return err
+	}
+	return l.db.Write(batch, nil)
+}
+
+func (l *levelDBBackend) Batch(fn func(Tx) error) error {
+	return l.Update(fn)
+}
+
+func (l *levelDBBackend) Close() error {
+	return l.db.Close()
+}
+
+type levelDBStore struct {
+	snapshot *leveldb.Snapshot
+	// The iterators of the cursors opened in the transaction, released when it ends.
+	iterators []iterator.Iterator
+}
+
+func (s *levelDBStore) release() {
+	for _, iter := range s.iterators {
+		iter.Release()
+	}
+	s.iterators = nil
+}
+
+func (s *levelDBStore) hasBucket(name string) (bool, error) {
+	return s.snapshot.Has(levelDBBucketKey(name), nil)
+}
+
+func (s *levelDBStore) get(bucket string, key []byte) ([]byte, error) {
+	value, err := s.snapshot.Get(levelDBKey(bucket, key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	return value, err
+}
+
+func (s *levelDBStore) entries(bucket string) ([]entry, error) {
+	prefix := levelDBKeyPrefixOf(bucket)
+	iter := s.snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	var entries []entry
+	for iter.Next() {
+		// The iterator reuses its buffers, so the key and value are copied.
+		entries = append(entries, entry{
+			key:   append([]byte{}, iter.Key()[len(prefix):]...),
+			value: append([]byte{}, iter.Value()...),
+		})
+	}
+	return entries, iter.Error()
+}
+
+func (s *levelDBStore) cursor(bucket string) (Cursor, error) {
+	prefix := levelDBKeyPrefixOf(bucket)
+	iter := s.snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+	s.iterators = append(s.iterators, iter)
+	return &levelDBCursor{iter: iter, prefix: prefix}, nil
+}
+
+// levelDBCursor iterates over the keys of a bucket with an iterator over the key prefix
+// of the bucket.
+type levelDBCursor struct {
+	iter   iterator.Iterator
+	prefix []byte
+}
+
+func (c *levelDBCursor) current(ok bool) ([]byte, []byte) {
+	if !ok {
+		return nil, nil
+	}
+	// The iterator reuses its buffers, so the key and value are copied.
+	return append([]byte{}, c.iter.Key()[len(c.prefix):]...), append([]byte{}, c.iter.Value()...)
+}
+
+func (c *levelDBCursor) First() ([]byte, []byte) {
+	return c.current(c.iter.First())
+}
+
+func (c *levelDBCursor) Next() ([]byte, []byte) {
+	return c.current(c.iter.Next())
+}
+
+func (c *levelDBCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.current(c.iter.Seek(append(append([]byte{}, c.prefix...), seek...)))
+}
+
+// batch returns the writes of a transaction as a LevelDB batch.
+func (s *levelDBStore) batch(tx *overlayTx) (*leveldb.Batch, error) {
+	batch := new(leveldb.Batch)
+	for name := range tx.deleted {
+		entries, err := s.entries(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			batch.Delete(levelDBKey(name, e.key))
+		}
+		batch.Delete(levelDBBucketKey(name))
+	}
+	for name := range tx.created {
+		batch.Put(levelDBBucketKey(name), []byte{})
+	}
+	for name, changes := range tx.changes {
+		if !tx.created[name] {
+			exists, err := s.hasBucket(name)
+			if err != nil {
+				return nil, err
+			}
+			if !exists || tx.deleted[name] {
+				continue
+			}
+		}
+		for k, c := range changes {
+			if c.deleted {
+				batch.Delete(levelDBKey(name, []byte(k)))
+				continue
+			}
+			batch.Put(levelDBKey(name, []byte(k)), c.value)
+		}
+	}
+	return batch, nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// memoryBackend keeps its buckets in memory. Read-write transactions are
+// exclusive, while read-only transactions run concurrently.
+type memoryBackend struct {
+	lock    sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+// NewMemoryBackend returns an empty in-memory storage engine.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{buckets: make(map[string]map[string][]byte)}
+}
+
+func (m *memoryBackend) View(fn func(Tx) error) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	tx := newOverlayTx(m, false)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.err
+}
+
+func (m *memoryBackend) Update(fn func(Tx) error) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	tx := newOverlayTx(m, true)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if tx.err != nil {
+		return tx.err
+	}
+	m.commit(tx)
+	return nil
+}
+
+func (m *memoryBackend) Batch(fn func(Tx) error) error {
+	return m.Update(fn)
+}
+
+func (m *memoryBackend) Close() error {
+	return nil
+}
+
+func (m *memoryBackend) commit(tx *overlayTx) {
+	for name := range tx.deleted {
+		delete(m.buckets, name)
+	}
+	for name := range tx.created {
+		if _, ok := m.buckets[name]; !ok {
+			m.buckets[name] = make(map[string][]byte)
+		}
+	}
+	for name, changes := range tx.changes {
+		bkt, ok := m.buckets[name]
+		if !ok {
+			continue
+		}
+		for k, c := range changes {
+			if c.deleted {
+				delete(bkt, k)
+				continue
+			}
+			bkt[k] = c.value
+		}
+	}
+}
+
+func (m *memoryBackend) hasBucket(name string) (bool, error) {
+	_, ok := m.buckets[name]
+	return ok, nil
+}
+
+func (m *memoryBackend) get(bucket string, key []byte) ([]byte, error) {
+	return m.buckets[bucket][string(key)], nil
+}
+
+func (m *memoryBackend) entries(bucket string) ([]entry, error) {
+	bkt := m.buckets[bucket]
+	entries := make([]entry, 0, len(bkt))
+	for k, v := range bkt {
+		entries = append(entries, entry{key: []byte(k), value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+	return entries, nil
+}
@@ -0,0 +1,347 @@
+package storage
+
+import (
+	"bytes"
+	"sort"
+)
+
+// store is the committed content of an engine which buffers the writes of a
+// transaction in memory, and applies them all at once when it commits.
+type store interface {
+	hasBucket(name string) (bool, error)
+	get(bucket string, key []byte) ([]byte, error)
+	// entries returns the key-value pairs of the bucket sorted by key.
+	entries(bucket string) ([]entry, error)
+}
+
+// cursorStore is a store which iterates over the keys of a bucket without reading them all
+// first. An overlay uses the cursor of its store for the buckets it has no change for.
+type cursorStore interface {
+	cursor(bucket string) (Cursor, error)
+}
+
+type entry struct {
+	key   []byte
+	value []byte
+}
+
+type change struct {
+	value   []byte
+	deleted bool
+}
+
+// overlayTx is a transaction reading from a store, whose writes are kept in
+// memory until the transaction is committed.
+type overlayTx struct {
+	store    store
+	writable bool
+	// The buckets created and deleted in the transaction. The committed content
+	// of a deleted bucket is no longer visible, even if it is created again.
+	created map[string]bool
+	deleted map[string]bool
+	changes map[string]map[string]change
+	// The first error the store returned while reading.
+	err error
+}
+
+func newOverlayTx(s store, writable bool) *overlayTx {
+	return &overlayTx{
+		store:    s,
+		writable: writable,
+		created:  make(map[string]bool),
+		deleted:  make(map[string]bool),
+		changes:  make(map[string]map[string]change),
+	}
+}
+
+func (t *overlayTx) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+func (t *overlayTx) bucketExists(name string) bool {
+	if t.created[name] {
+		return true
+	}
+	if t.deleted[name] {
+		return false
+	}
+	exists, err := t.store.hasBucket(name)
+	if err != nil {
+		t.fail(err)
+		return false
+	}
+	return exists
+}
+
+func (t *overlayTx) Bucket(name []byte) Bucket {
+	if !t.bucketExists(string(name)) {
+		return nil
+	}
+	return &overlayBucket{tx: t, name: string(name)}
+}
+
+func (t *overlayTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	if !t.writable {
+		return nil, ErrTxNotWritable
+	}
+	if !t.bucketExists(string(name)) {
+		t.created[string(name)] = true
+	}
+	return &overlayBucket{tx: t, name: string(name)}, nil
+}
+
+func (t *overlayTx) DeleteBucket(name []byte) error {
+	if !t.writable {
+		return ErrTxNotWritable
+	}
+	delete(t.created, string(name))
+	delete(t.changes, string(name))
+	t.deleted[string(name)] = true
+	return nil
+}
+
+type overlayBucket struct {
+	tx   *overlayTx
+	name string
+}
+
+func (b *overlayBucket) Get(key []byte) []byte {
+	if c, ok := b.tx.changes[b.name][string(key)]; ok {
+		if c.deleted {
+			return nil
+		}
+		return c.value
+	}
+	if b.tx.deleted[b.name] {
+		return nil
+	}
+	value, err := b.tx.store.get(b.name, key)
+	if err != nil {
+		b.tx.fail(err)
+		return nil
+	}
+	return value
+}
+
+func (b *overlayBucket) set(key []byte, c change) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+	changes, ok := b.tx.changes[b.name]
+	if !ok {
+		changes = make(map[string]change)
+		b.tx.changes[b.name] = changes
+	}
+	changes[string(key)] = c
+	return nil
+}
+
+func (b *overlayBucket) Put(key []byte, value []byte) error {
+	return b.set(key, change{value: append([]byte{}, value...)})
+}
+
+func (b *overlayBucket) Delete(key []byte) error {
+	return b.set(key, change{deleted: true})
+}
+
+// entries merges the committed content of the bucket with the changes of the transaction.
+func (b *overlayBucket) entries() []entry {
+	var entries []entry
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		entries = append(entries, entry{key: k, value: v})
+	}
+	return entries
+}
+
+func (b *overlayBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return b.tx.err
+}
+
+// Cursor iterates over the committed content of the bucket with the cursor of the store, and
+// merges the changes of the transaction into it as it goes.
+func (b *overlayBucket) Cursor() Cursor {
+	var committed Cursor = &sliceCursor{}
+	if !b.tx.deleted[b.name] {
+		committed = b.committedCursor()
+	}
+	changes := b.tx.changes[b.name]
+	if len(changes) == 0 {
+		return committed
+	}
+	return newMergeCursor(committed, changes)
+}
+
+// committedCursor returns a cursor over the committed content of the bucket.
+func (b *overlayBucket) committedCursor() Cursor {
+	if cs, ok := b.tx.store.(cursorStore); ok {
+		c, err := cs.cursor(b.name)
+		if err != nil {
+			b.tx.fail(err)
+			return &sliceCursor{}
+		}
+		return c
+	}
+	committed, err := b.tx.store.entries(b.name)
+	if err != nil {
+		b.tx.fail(err)
+		return &sliceCursor{}
+	}
+	return &sliceCursor{entries: committed}
+}
+
+// An overlay is itself a store, so overlays can be stacked: the committed content of the
+// upper overlay is the content of the lower overlay, changes included.
+
+func (t *overlayTx) hasBucket(name string) (bool, error) {
+	exists := t.bucketExists(name)
+	return exists, t.err
+}
+
+func (t *overlayTx) get(bucket string, key []byte) ([]byte, error) {
+	value := (&overlayBucket{tx: t, name: bucket}).Get(key)
+	return value, t.err
+}
+
+func (t *overlayTx) entries(bucket string) ([]entry, error) {
+	entries := (&overlayBucket{tx: t, name: bucket}).entries()
+	return entries, t.err
+}
+
+func (t *overlayTx) cursor(bucket string) (Cursor, error) {
+	c := (&overlayBucket{tx: t, name: bucket}).Cursor()
+	return c, t.err
+}
+
+// sliceCursor iterates over the entries of a bucket as of the cursor creation.
+type sliceCursor struct {
+	entries []entry
+	index   int
+}
+
+func (c *sliceCursor) current() ([]byte, []byte) {
+	if c.index >= len(c.entries) {
+		return nil, nil
+	}
+	return c.entries[c.index].key, c.entries[c.index].value
+}
+
+func (c *sliceCursor) First() ([]byte, []byte) {
+	c.index = 0
+	return c.current()
+}
+
+func (c *sliceCursor) Next() ([]byte, []byte) {
+	if c.index < len(c.entries) {
+		c.index++
+	}
+	return c.current()
+}
+
+func (c *sliceCursor) Seek(seek []byte) ([]byte, []byte) {
+	c.index = sort.Search(len(c.entries), func(i int) bool {
+		return bytes.Compare(c.entries[i].key, seek) >= 0
+	})
+	return c.current()
+}
+
+type changeEntry struct {
+	key []byte
+	change
+}
+
+// mergeCursor iterates over the committed content of a bucket, as seen through the changes
+// of a transaction. Only the changes are sorted, the committed content is read with the
+// cursor of the store.
+type mergeCursor struct {
+	committed Cursor
+	changes   []changeEntry
+	// The current position in the committed content and in the changes.
+	key, value []byte
+	index      int
+	// Whether the key of the cursor comes from the committed content, from the changes, or
+	// from both when a change overrides a committed key.
+	fromCommitted, fromChanges bool
+}
+
+func newMergeCursor(committed Cursor, changes map[string]change) *mergeCursor {
+	sorted := make([]changeEntry, 0, len(changes))
+	for k, c := range changes {
+		sorted = append(sorted, changeEntry{key: []byte(k), change: c})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].key, sorted[j].key) < 0
+	})
+	return &mergeCursor{committed: committed, changes: sorted}
+}
+
+// settle returns the smallest key of the committed content and the changes from their
+// current positions, skipping the deleted keys.
+func (c *mergeCursor) settle() ([]byte, []byte) {
+	for {
+		var ch *changeEntry
+		if c.index < len(c.changes) {
+			ch = &c.changes[c.index]
+		}
+		c.fromCommitted, c.fromChanges = false, false
+		switch {
+		case c.key == nil && ch == nil:
+			return nil, nil
+		case ch == nil:
+			c.fromCommitted = true
+		case c.key == nil:
+			c.fromChanges = true
+		default:
+			cmp := bytes.Compare(c.key, ch.key)
+			c.fromCommitted = cmp <= 0
+			c.fromChanges = cmp >= 0
+		}
+		if !c.fromChanges {
+			return c.key, c.value
+		}
+		if !ch.deleted {
+			return ch.key, ch.value
+		}
+		c.advance()
+	}
+}
+
+// advance moves past the current key.
+func (c *mergeCursor) advance() {
+	if c.fromCommitted {
+		c.key, c.value = c.committed.Next()
+	}
+	if c.fromChanges {
+		c.index++
+	}
+}
+
+func (c *mergeCursor) First() ([]byte, []byte) {
+	c.key, c.value = c.committed.First()
+	c.index = 0
+	return c.settle()
+}
+
+func (c *mergeCursor) Next() ([]byte, []byte) {
+	if !c.fromCommitted && !c.fromChanges {
+		return nil, nil
+	}
+	c.advance()
+	return c.settle()
+}
+
+func (c *mergeCursor) Seek(seek []byte) ([]byte, []byte) {
+	c.key, c.value = c.committed.Seek(seek)
+	c.index = sort.Search(len(c.changes), func(i int) bool {
+		return bytes.Compare(c.changes[i].key, seek) >= 0
+	})
+	return c.settle()
+}
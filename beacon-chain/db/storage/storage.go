@@ -0,0 +1,89 @@
+// Package storage defines the key-value storage engines the beacon chain DB persists
+// its data into. Every engine organizes keys into named buckets, runs reads and writes
+// in transactions, and iterates over the keys of a bucket in byte order.
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The names of the supported storage engines.
+const (
+	// BoltEngine is a B+tree engine backed by a single memory mapped file. It is
+	// the default engine, and is well suited to the read heavy load of a full node.
+	BoltEngine = "bolt"
+	// LevelDBEngine is a log-structured merge-tree engine, which is write optimized
+	// and better suited to archive nodes.
+	LevelDBEngine = "leveldb"
+	// MemoryEngine keeps all data in memory and is meant for tests.
+	MemoryEngine = "memory"
+)
+
+// ErrTxNotWritable is returned when writing in a read-only transaction.
+var ErrTxNotWritable = errors.New("tx not writable")
+
+// Backend is a storage engine.
+type Backend interface {
+	// View runs the function in a read-only transaction.
+	View(fn func(Tx) error) error
+	// Update runs the function in a read-write transaction. The transaction is
+	// committed if the function returns nil, and rolled back otherwise.
+	Update(fn func(Tx) error) error
+	// Batch behaves like Update, but the engine may coalesce concurrent calls into
+	// a single transaction, so the function can be run more than once.
+	Batch(fn func(Tx) error) error
+	// Close releases the resources of the engine.
+	Close() error
+}
+
+// Tx is a storage transaction. The values read in a transaction are only valid
+// until the transaction ends and must not be modified.
+type Tx interface {
+	// Bucket returns the bucket with the given name, or nil if it does not exist.
+	Bucket(name []byte) Bucket
+	// CreateBucketIfNotExists returns the bucket with the given name, creating it if needed.
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	// DeleteBucket removes the bucket with the given name and all of its keys.
+	DeleteBucket(name []byte) error
+}
+
+// Bucket is a collection of key-value pairs.
+type Bucket interface {
+	// Get returns the value of the key, or nil if the key does not exist.
+	Get(key []byte) []byte
+	// Put sets the value of the key.
+	Put(key []byte, value []byte) error
+	// Delete removes the key. Deleting a key which does not exist is not an error.
+	Delete(key []byte) error
+	// ForEach calls the function for every key-value pair of the bucket in key order,
+	// and stops at the first error. The bucket must not be modified during the iteration.
+	ForEach(fn func(k, v []byte) error) error
+	// Cursor returns an iterator over the keys of the bucket in key order.
+	Cursor() Cursor
+}
+
+// Cursor iterates over the key-value pairs of a bucket in key order. A nil key
+// means the iteration is over.
+type Cursor interface {
+	// First moves the cursor to the first key of the bucket.
+	First() (key []byte, value []byte)
+	// Next moves the cursor to the next key.
+	Next() (key []byte, value []byte)
+	// Seek moves the cursor to the first key which is greater than or equal to the given key.
+	Seek(seek []byte) (key []byte, value []byte)
+}
+
+// Open opens the storage engine with the given name at the given directory.
+func Open(engine string, dirPath string) (Backend, error) {
+	switch engine {
+	case BoltEngine, "":
+		return NewBoltBackend(dirPath)
+	case LevelDBEngine:
+		return NewLevelDBBackend(dirPath)
+	case MemoryEngine:
+		return NewMemoryBackend(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage engine %q", engine)
+	}
+}
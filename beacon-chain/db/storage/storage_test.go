@@ -0,0 +1,423 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+var testBucket = []byte("test-bucket")
+
+// batchingEngine is a bolt engine whose writes are buffered until they are flushed.
+const batchingEngine = "batching"
+
+func openTestBackend(engine string, dirPath string) (Backend, error) {
+	if engine != batchingEngine {
+		return Open(engine, dirPath)
+	}
+	backend, err := NewBoltBackend(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewBatchingBackend(backend, 1<<20, time.Hour), nil
+}
+
+// forEachEngine runs the test against a fresh instance of every storage engine.
+func forEachEngine(t *testing.T, test func(t *testing.T, backend Backend)) {
+	for _, engine := range []string{BoltEngine, LevelDBEngine, MemoryEngine, batchingEngine} {
+		t.Run(engine, func(t *testing.T) {
+			dirPath := path.Join(testutil.TempDir(), fmt.Sprintf("storage-%s-%d", engine, rand.Int()))
+			if err := os.RemoveAll(dirPath); err != nil {
+				t.Fatalf("Failed to remove directory: %v", err)
+			}
+			defer os.RemoveAll(dirPath)
+
+			backend, err := openTestBackend(engine, dirPath)
+			if err != nil {
+				t.Fatalf("Could not open %s engine: %v", engine, err)
+			}
+			defer backend.Close()
+			if err := backend.Update(func(tx Tx) error {
+				_, err := tx.CreateBucketIfNotExists(testBucket)
+				return err
+			}); err != nil {
+				t.Fatalf("Could not create bucket: %v", err)
+			}
+			test(t, backend)
+		})
+	}
+}
+
+func TestBackend_PutGetDelete(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, backend Backend) {
+		if err := backend.Update(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			if err := bkt.Put([]byte("a"), []byte("1")); err != nil {
+				return err
+			}
+			if err := bkt.Put([]byte("b"), []byte("2")); err != nil {
+				return err
+			}
+			// Writes are visible within the transaction.
+			if v := bkt.Get([]byte("a")); !bytes.Equal(v, []byte("1")) {
+				return fmt.Errorf("expected value 1 within the transaction, received %s", v)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := backend.Update(func(tx Tx) error {
+			return tx.Bucket(testBucket).Delete([]byte("a"))
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := backend.View(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			if v := bkt.Get([]byte("a")); v != nil {
+				t.Errorf("Expected deleted key to be missing, received %s", v)
+			}
+			if v := bkt.Get([]byte("b")); !bytes.Equal(v, []byte("2")) {
+				t.Errorf("Expected value 2, received %s", v)
+			}
+			if tx.Bucket([]byte("missing-bucket")) != nil {
+				t.Error("Expected missing bucket to be nil")
+			}
+			if err := bkt.Put([]byte("c"), []byte("3")); err == nil {
+				t.Error("Expected write in a read-only transaction to fail")
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestBackend_RollbackOnError(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, backend Backend) {
+		wantErr := errors.New("rollback")
+		if err := backend.Update(func(tx Tx) error {
+			if err := tx.Bucket(testBucket).Put([]byte("a"), []byte("1")); err != nil {
+				return err
+			}
+			return wantErr
+		}); err != wantErr {
+			t.Fatalf("Expected error %v, received %v", wantErr, err)
+		}
+
+		if err := backend.View(func(tx Tx) error {
+			if v := tx.Bucket(testBucket).Get([]byte("a")); v != nil {
+				t.Errorf("Expected write of a failed transaction to be rolled back, received %s", v)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestBackend_Iteration(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, backend Backend) {
+		if err := backend.Update(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			for _, k := range []string{"d", "b", "a", "c"} {
+				if err := bkt.Put([]byte(k), []byte(k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := backend.Update(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			// Uncommitted writes are part of the iteration.
+			if err := bkt.Put([]byte("e"), []byte("e")); err != nil {
+				return err
+			}
+			if err := bkt.Delete([]byte("b")); err != nil {
+				return err
+			}
+
+			var keys []byte
+			if err := bkt.ForEach(func(k, v []byte) error {
+				keys = append(keys, k...)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if string(keys) != "acde" {
+				t.Errorf("Expected keys acde in order, received %s", keys)
+			}
+
+			c := bkt.Cursor()
+			if k, _ := c.Seek([]byte("b")); !bytes.Equal(k, []byte("c")) {
+				t.Errorf("Expected seek to land on c, received %s", k)
+			}
+			if k, _ := c.Next(); !bytes.Equal(k, []byte("d")) {
+				t.Errorf("Expected next key d, received %s", k)
+			}
+			if k, _ := c.First(); !bytes.Equal(k, []byte("a")) {
+				t.Errorf("Expected first key a, received %s", k)
+			}
+			if k, _ := c.Seek([]byte("f")); k != nil {
+				t.Errorf("Expected seek past the last key to return nil, received %s", k)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestBackend_CursorOverCommittedKeys(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, backend Backend) {
+		if err := backend.Update(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			for _, k := range []string{"c", "a", "b"} {
+				if err := bkt.Put([]byte(k), []byte(k+k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := backend.View(func(tx Tx) error {
+			c := tx.Bucket(testBucket).Cursor()
+			var keys []byte
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				if string(v) != string(k)+string(k) {
+					t.Errorf("Expected value %s%s, received %s", k, k, v)
+				}
+				keys = append(keys, k...)
+			}
+			if string(keys) != "abc" {
+				t.Errorf("Expected keys abc in order, received %s", keys)
+			}
+			if k, _ := c.Seek([]byte("bb")); !bytes.Equal(k, []byte("c")) {
+				t.Errorf("Expected seek to land on c, received %s", k)
+			}
+			if k, _ := c.Next(); k != nil {
+				t.Errorf("Expected the iteration to be over, received %s", k)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestBackend_DeleteBucket(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, backend Backend) {
+		if err := backend.Update(func(tx Tx) error {
+			return tx.Bucket(testBucket).Put([]byte("a"), []byte("1"))
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := backend.Update(func(tx Tx) error {
+			if err := tx.DeleteBucket(testBucket); err != nil {
+				return err
+			}
+			if tx.Bucket(testBucket) != nil {
+				return errors.New("expected deleted bucket to be nil")
+			}
+			_, err := tx.CreateBucketIfNotExists(testBucket)
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := backend.View(func(tx Tx) error {
+			bkt := tx.Bucket(testBucket)
+			if bkt == nil {
+				return errors.New("expected recreated bucket to exist")
+			}
+			if v := bkt.Get([]byte("a")); v != nil {
+				t.Errorf("Expected keys of the deleted bucket to be removed, received %s", v)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// hasKey checks whether the test bucket of the engine holds the key.
+func hasKey(t *testing.T, backend Backend, key []byte) bool {
+	var exists bool
+	if err := backend.View(func(tx Tx) error {
+		bkt := tx.Bucket(testBucket)
+		exists = bkt != nil && bkt.Get(key) != nil
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return exists
+}
+
+func newTestBatchingBackend(t *testing.T, maxPendingBytes int, maxDelay time.Duration) (*BatchingBackend, Backend) {
+	underlying := NewMemoryBackend()
+	backend := NewBatchingBackend(underlying, maxPendingBytes, maxDelay)
+	if err := backend.Update(func(tx Tx) error {
+		_, err := tx.CreateBucketIfNotExists(testBucket)
+		return err
+	}); err != nil {
+		t.Fatalf("Could not create bucket: %v", err)
+	}
+	return backend, underlying
+}
+
+func TestBatchingBackend_BuffersWritesUntilFlush(t *testing.T) {
+	backend, underlying := newTestBatchingBackend(t, 1<<20, time.Hour)
+	if err := backend.Update(func(tx Tx) error {
+		return tx.Bucket(testBucket).Put([]byte("a"), []byte("1"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasKey(t, backend, []byte("a")) {
+		t.Error("Expected buffered write to be visible")
+	}
+	if hasKey(t, underlying, []byte("a")) {
+		t.Error("Expected write to be buffered until flushed")
+	}
+	if err := backend.Flush(); err != nil {
+		t.Fatalf("Could not flush: %v", err)
+	}
+	if !hasKey(t, underlying, []byte("a")) {
+		t.Error("Expected flushed write to be written to the underlying engine")
+	}
+}
+
+func TestBatchingBackend_FlushesOnClose(t *testing.T) {
+	backend, underlying := newTestBatchingBackend(t, 1<<20, time.Hour)
+	if err := backend.Update(func(tx Tx) error {
+		return tx.Bucket(testBucket).Put([]byte("a"), []byte("1"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Close(); err != nil {
+		t.Fatalf("Could not close: %v", err)
+	}
+	if !hasKey(t, underlying, []byte("a")) {
+		t.Error("Expected buffered writes to be flushed on close")
+	}
+}
+
+func TestBatchingBackend_FlushesAtSizeThreshold(t *testing.T) {
+	backend, underlying := newTestBatchingBackend(t, 8, time.Hour)
+	if err := backend.Update(func(tx Tx) error {
+		return tx.Bucket(testBucket).Put([]byte("a"), []byte("0123456789"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !hasKey(t, underlying, []byte("a")) {
+		t.Error("Expected writes over the size threshold to be flushed")
+	}
+}
+
+func TestBatchingBackend_FlushesAfterMaxDelay(t *testing.T) {
+	backend, underlying := newTestBatchingBackend(t, 1<<20, 10*time.Millisecond)
+	if err := backend.Update(func(tx Tx) error {
+		return tx.Bucket(testBucket).Put([]byte("a"), []byte("1"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !hasKey(t, underlying, []byte("a")) {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected buffered writes to be flushed after the maximum delay")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// stallingBackend is an engine whose read-write transactions wait for the test to release
+// them, and fail with the error it releases them with.
+type stallingBackend struct {
+	Backend
+	started chan struct{}
+	release chan error
+}
+
+func (s *stallingBackend) Update(fn func(Tx) error) error {
+	s.started <- struct{}{}
+	if err := <-s.release; err != nil {
+		return err
+	}
+	return s.Backend.Update(fn)
+}
+
+func TestBatchingBackend_ReadsAndWritesDuringFlush(t *testing.T) {
+	backend, underlying := newTestBatchingBackend(t, 1<<20, time.Hour)
+	if err := backend.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	stalling := &stallingBackend{Backend: underlying, started: make(chan struct{}), release: make(chan error)}
+	backend.backend = stalling
+
+	attempts := []struct {
+		err error
+		// The key written while the flush is waiting.
+		key string
+	}{
+		{err: errors.New("write failed"), key: "b"},
+		{err: nil, key: "c"},
+	}
+	for _, attempt := range attempts {
+		if err := backend.Update(func(tx Tx) error {
+			return tx.Bucket(testBucket).Put([]byte("a"), []byte("1"))
+		}); err != nil {
+			t.Fatal(err)
+		}
+		flushed := make(chan error)
+		go func() {
+			flushed <- backend.Flush()
+		}()
+		<-stalling.started
+
+		// The flush is waiting on the underlying engine, which must not block transactions.
+		if !hasKey(t, backend, []byte("a")) {
+			t.Error("Expected the write being flushed to be visible")
+		}
+		if err := backend.Update(func(tx Tx) error {
+			return tx.Bucket(testBucket).Put([]byte(attempt.key), []byte("2"))
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		stalling.release <- attempt.err
+		if err := <-flushed; err != attempt.err {
+			t.Errorf("Expected flush error %v, received %v", attempt.err, err)
+		}
+		for _, k := range []string{"a", attempt.key} {
+			if !hasKey(t, backend, []byte(k)) {
+				t.Errorf("Expected key %s to be visible after the flush", k)
+			}
+		}
+	}
+	for _, k := range []string{"a", "b"} {
+		if !hasKey(t, underlying, []byte(k)) {
+			t.Errorf("Expected key %s of the failed flush to be written by the next flush", k)
+		}
+	}
+	if hasKey(t, underlying, []byte("c")) {
+		t.Error("Expected the write buffered during the flush to stay buffered")
+	}
+}
+
+func TestOpen_UnknownEngine(t *testing.T) {
+	if _, err := Open("unknown", testutil.TempDir()); err == nil {
+		t.Error("Expected opening an unknown engine to fail")
+	}
+}
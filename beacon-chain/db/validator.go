@@ -5,7 +5,8 @@ import (
 	"encoding/binary"
 	"fmt"
 
-	"github.com/boltdb/bolt"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
@@ -13,13 +14,16 @@ import (
 func (db *BeaconDB) SaveValidatorIndex(pubKey []byte, index int) error {
 	h := hashutil.Hash(pubKey)
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		bucket := tx.Bucket(validatorBucket)
 
 		buf := make([]byte, binary.MaxVarintLen64)
 		n := binary.PutUvarint(buf, uint64(index))
 
-		return bucket.Put(h[:], buf[:n])
+		if err := bucket.Put(h[:], buf[:n]); err != nil {
+			return err
+		}
+		return tx.Bucket(validatorPubkeyBucket).Put(validatorPubkeyKey(uint64(index)), pubKey)
 	})
 }
 
@@ -32,7 +36,7 @@ func (db *BeaconDB) ValidatorIndex(pubKey []byte) (uint64, error) {
 	var index uint64
 	h := hashutil.Hash(pubKey)
 
-	err := db.view(func(tx *bolt.Tx) error {
+	err := db.view(func(tx storage.Tx) error {
 		bucket := tx.Bucket(validatorBucket)
 
 		enc := bucket.Get(h[:])
@@ -52,9 +56,21 @@ func (db *BeaconDB) ValidatorIndex(pubKey []byte) (uint64, error) {
 func (db *BeaconDB) DeleteValidatorIndex(pubKey []byte) error {
 	h := hashutil.Hash(pubKey)
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		a := tx.Bucket(validatorBucket)
-
+		pubkeys := tx.Bucket(validatorPubkeyBucket)
+
+		if enc := a.Get(h[:]); enc != nil {
+			index, err := binary.ReadUvarint(bytes.NewBuffer(enc))
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(pubkeys.Get(validatorPubkeyKey(index)), pubKey) {
+				if err := pubkeys.Delete(validatorPubkeyKey(index)); err != nil {
+					return err
+				}
+			}
+		}
 		return a.Delete(h[:])
 	})
 }
@@ -64,7 +80,7 @@ func (db *BeaconDB) HasValidator(pubKey []byte) bool {
 	exists := false
 	h := hashutil.Hash(pubKey)
 	// #nosec G104, similar to HasBlock, HasAttestation... etc
-	db.view(func(tx *bolt.Tx) error {
+	db.view(func(tx storage.Tx) error {
 		a := tx.Bucket(validatorBucket)
 
 		exists = a.Get(h[:]) != nil
@@ -72,3 +88,135 @@ func (db *BeaconDB) HasValidator(pubKey []byte) bool {
 	})
 	return exists
 }
+
+// ValidatorNotFoundError is returned when no validator index is saved for a public key.
+type ValidatorNotFoundError struct {
+	PubKey []byte
+}
+
+func (e *ValidatorNotFoundError) Error() string {
+	return fmt.Sprintf("validator %#x does not exist", e.PubKey)
+}
+
+// ValidatorIndices returns the indices of the validators with the given public keys, in
+// the order of the public keys. It fails with a ValidatorNotFoundError if any of the
+// validators does not exist.
+func (db *BeaconDB) ValidatorIndices(pubKeys [][]byte) ([]uint64, error) {
+	indices := make([]uint64, len(pubKeys))
+	err := db.view(func(tx storage.Tx) error {
+		bucket := tx.Bucket(validatorBucket)
+
+		for i, pubKey := range pubKeys {
+			h := hashutil.Hash(pubKey)
+			enc := bucket.Get(h[:])
+			if enc == nil {
+				return &ValidatorNotFoundError{PubKey: pubKey}
+			}
+			index, err := binary.ReadUvarint(bytes.NewBuffer(enc))
+			if err != nil {
+				return err
+			}
+			indices[i] = index
+		}
+		return nil
+	})
+	return indices, err
+}
+
+// ValidatorPubkey returns the public key of the validator with the given index in the
+// canonical validator registry. Returns nil if no validator has the index.
+func (db *BeaconDB) ValidatorPubkey(index uint64) ([]byte, error) {
+	var pubKey []byte
+	err := db.view(func(tx storage.Tx) error {
+		if enc := tx.Bucket(validatorPubkeyBucket).Get(validatorPubkeyKey(index)); enc != nil {
+			pubKey = append([]byte{}, enc...)
+		}
+		return nil
+	})
+	return pubKey, err
+}
+
+// validatorPubkeyKey encodes a validator index as big-endian, so the public keys are
+// ordered by index.
+func validatorPubkeyKey(index uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, index)
+	return key
+}
+
+// syncValidatorIndices updates the index and public key mappings to the canonical validator
+// registry. Validators only get appended to the registry, so the registry is walked back
+// from its end until a validator already mapped to its index is found. The mappings of the
+// validators beyond the end of the registry, left by a longer registry of another branch,
+// are removed.
+func syncValidatorIndices(tx storage.Tx, registry []*pb.Validator) error {
+	indexBkt := tx.Bucket(validatorBucket)
+	pubkeyBkt := tx.Bucket(validatorPubkeyBucket)
+
+	var stale [][]byte
+	c := pubkeyBkt.Cursor()
+	for k, v := c.Seek(validatorPubkeyKey(uint64(len(registry)))); k != nil; k, v = c.Next() {
+		stale = append(stale, append([]byte{}, k...), append([]byte{}, v...))
+	}
+	for i := 0; i < len(stale); i += 2 {
+		if err := unmapValidator(indexBkt, pubkeyBkt, stale[i], stale[i+1]); err != nil {
+			return err
+		}
+	}
+
+	for i := len(registry) - 1; i >= 0; i-- {
+		key := validatorPubkeyKey(uint64(i))
+		pubKey := registry[i].Pubkey
+		prev := pubkeyBkt.Get(key)
+		if bytes.Equal(prev, pubKey) {
+			break
+		}
+		if prev != nil {
+			if err := unmapValidator(indexBkt, pubkeyBkt, key, append([]byte{}, prev...)); err != nil {
+				return err
+			}
+		}
+		h := hashutil.Hash(pubKey)
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, uint64(i))
+		if err := indexBkt.Put(h[:], buf[:n]); err != nil {
+			return fmt.Errorf("failed to save validator index: %v", err)
+		}
+		if err := pubkeyBkt.Put(key, pubKey); err != nil {
+			return fmt.Errorf("failed to save validator public key: %v", err)
+		}
+	}
+	return nil
+}
+
+// unmapValidator removes the mappings of the validator with the given public key, which is
+// mapped to the index encoded by the key.
+func unmapValidator(indexBkt storage.Bucket, pubkeyBkt storage.Bucket, key []byte, pubKey []byte) error {
+	h := hashutil.Hash(pubKey)
+	if enc := indexBkt.Get(h[:]); enc != nil {
+		index, err := binary.ReadUvarint(bytes.NewBuffer(enc))
+		if err != nil {
+			return err
+		}
+		if index == binary.BigEndian.Uint64(key) {
+			if err := indexBkt.Delete(h[:]); err != nil {
+				return err
+			}
+		}
+	}
+	return pubkeyBkt.Delete(key)
+}
+
+// migrateValidatorPubkeys maps the validators of the canonical state, including the ones
+// added after genesis which were never mapped, and builds the public key lookup.
+func migrateValidatorPubkeys(tx storage.Tx) error {
+	enc := tx.Bucket(chainInfoBucket).Get(stateLookupKey)
+	if enc == nil {
+		return nil
+	}
+	beaconState, err := decodeState(tx, enc)
+	if err != nil {
+		return err
+	}
+	return syncValidatorIndices(tx, beaconState.ValidatorRegistry)
+}
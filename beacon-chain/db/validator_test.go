@@ -1,9 +1,12 @@
 package db
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
 func TestSaveAndRetrieveValidatorIndex_OK(t *testing.T) {
@@ -63,3 +66,104 @@ func TestSaveAndDeleteValidatorIndex_OK(t *testing.T) {
 		t.Errorf("Want: %v, got: %v", want, err.Error())
 	}
 }
+
+func testRegistry(pubKeys ...string) []*pb.Validator {
+	registry := make([]*pb.Validator, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		registry[i] = &pb.Validator{Pubkey: []byte(pubKey)}
+	}
+	return registry
+}
+
+func TestSaveState_IndexesValidatorsAddedToRegistry(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	beaconState := &pb.BeaconState{ValidatorRegistry: testRegistry("A", "B")}
+	if err := db.SaveState(beaconState); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	// A deposit processed after genesis appends a validator to the registry.
+	beaconState.ValidatorRegistry = testRegistry("A", "B", "C")
+	if err := db.SaveState(beaconState); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	index, err := db.ValidatorIndex([]byte("C"))
+	if err != nil {
+		t.Fatalf("failed to get validator index: %v", err)
+	}
+	if index != 2 {
+		t.Errorf("expected validator index 2, received %d", index)
+	}
+	pubKey, err := db.ValidatorPubkey(2)
+	if err != nil {
+		t.Fatalf("failed to get validator public key: %v", err)
+	}
+	if !bytes.Equal(pubKey, []byte("C")) {
+		t.Errorf("expected validator public key %#x, received %#x", []byte("C"), pubKey)
+	}
+	pubKey, err = db.ValidatorPubkey(3)
+	if err != nil {
+		t.Fatalf("failed to get validator public key: %v", err)
+	}
+	if pubKey != nil {
+		t.Errorf("expected no validator at index 3, received %#x", pubKey)
+	}
+}
+
+func TestSaveState_UnindexesValidatorsOfReplacedRegistry(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	if err := db.SaveState(&pb.BeaconState{ValidatorRegistry: testRegistry("A", "B", "C")}); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	// A reorg to a branch which processed a different deposit.
+	if err := db.SaveState(&pb.BeaconState{ValidatorRegistry: testRegistry("A", "D")}); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	for _, pubKey := range []string{"B", "C"} {
+		if db.HasValidator([]byte(pubKey)) {
+			t.Errorf("expected validator %s to be unindexed", pubKey)
+		}
+	}
+	index, err := db.ValidatorIndex([]byte("D"))
+	if err != nil {
+		t.Fatalf("failed to get validator index: %v", err)
+	}
+	if index != 1 {
+		t.Errorf("expected validator index 1, received %d", index)
+	}
+	pubKey, err := db.ValidatorPubkey(2)
+	if err != nil {
+		t.Fatalf("failed to get validator public key: %v", err)
+	}
+	if pubKey != nil {
+		t.Errorf("expected no validator at index 2, received %#x", pubKey)
+	}
+}
+
+func TestValidatorIndices_OK(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	if err := db.SaveState(&pb.BeaconState{ValidatorRegistry: testRegistry("A", "B", "C")}); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	indices, err := db.ValidatorIndices([][]byte{[]byte("C"), []byte("A")})
+	if err != nil {
+		t.Fatalf("failed to get validator indices: %v", err)
+	}
+	if len(indices) != 2 || indices[0] != 2 || indices[1] != 0 {
+		t.Errorf("expected validator indices [2 0], received %v", indices)
+	}
+
+	_, err = db.ValidatorIndices([][]byte{[]byte("A"), []byte("D")})
+	want := fmt.Sprintf("validator %#x does not exist", []byte("D"))
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error %q, received %v", want, err)
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/boltdb/bolt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/opentracing/opentracing-go"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/storage"
 )
 
 var depositContractAddressKey = []byte("deposit-contract")
@@ -20,7 +20,7 @@ func (db *BeaconDB) VerifyContractAddress(ctx context.Context, addr common.Addre
 	span, ctx := opentracing.StartSpanFromContext(ctx, "BeaconDB.VerifyContractAddress")
 	defer span.Finish()
 
-	return db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx storage.Tx) error {
 		chainInfo := tx.Bucket(chainInfoBucket)
 
 		expectedAddress := chainInfo.Get(depositContractAddressKey)
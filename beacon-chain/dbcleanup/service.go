@@ -0,0 +1,191 @@
+// Package dbcleanup defines the life cycle and logic of the beacon DB cleanup routine,
+// which removes the data made obsolete by finality so the DB does not grow without bound.
+package dbcleanup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "dbcleaner")
+
+type chainService interface {
+	CanonicalStateFeed() *event.Feed
+}
+
+// CleanupService represents a service that prunes the beacon chain DB whenever the
+// finalized epoch advances. It removes the blocks which conflict with the finalized
+// chain, the block states which are no longer reachable and the pending attestations
+// which were included in finalized blocks. The last cleaned finalized slot is recorded
+// in the DB, so an interrupted cleanup is resumed when the service restarts.
+type CleanupService struct {
+	ctx                context.Context
+	cancel             context.CancelFunc
+	beaconDB           *db.BeaconDB
+	chainService       chainService
+	canonicalStateChan chan *pb.BeaconState
+}
+
+// Config options for the service.
+type Config struct {
+	SubscriptionBuf int
+	BeaconDB        *db.BeaconDB
+	ChainService    chainService
+}
+
+// NewCleanupService instantiates a new service instance that will
+// be registered into a running beacon node.
+func NewCleanupService(ctx context.Context, cfg *Config) *CleanupService {
+	ctx, cancel := context.WithCancel(ctx)
+	return &CleanupService{
+		ctx:                ctx,
+		cancel:             cancel,
+		beaconDB:           cfg.BeaconDB,
+		chainService:       cfg.ChainService,
+		canonicalStateChan: make(chan *pb.BeaconState, cfg.SubscriptionBuf),
+	}
+}
+
+// Start a cleanup service's main event loop.
+func (d *CleanupService) Start() {
+	log.Info("Starting service")
+	go d.cleanDB()
+}
+
+// Stop the cleanup service's main event loop and associated goroutines.
+func (d *CleanupService) Stop() error {
+	defer d.cancel()
+	log.Info("Stopping service")
+	return nil
+}
+
+// Status always returns nil.
+func (d *CleanupService) Status() error {
+	return nil
+}
+
+func (d *CleanupService) cleanDB() {
+	// Catch up with the finalized epoch of the stored state first, in case the
+	// previous cleanup was interrupted or finality advanced while the node was down.
+	beaconState, err := d.beaconDB.State()
+	if err != nil {
+		log.Errorf("Could not retrieve beacon state: %v", err)
+	} else if beaconState != nil {
+		if err := d.cleanup(beaconState); err != nil {
+			log.Errorf("Failed to clean up DB: %v", err)
+		}
+	}
+
+	stateSub := d.chainService.CanonicalStateFeed().Subscribe(d.canonicalStateChan)
+	defer stateSub.Unsubscribe()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			log.Debug("Cleanup service context closed, exiting goroutine")
+			return
+		case beaconState := <-d.canonicalStateChan:
+			if err := d.cleanup(beaconState); err != nil {
+				log.Errorf("Failed to clean up DB: %v", err)
+			}
+		}
+	}
+}
+
+// cleanup prunes the DB content made obsolete by the finalized epoch of the given
+// state, if it is more recent than the finalized slot of the last cleanup. Every step
+// is idempotent, so a cleanup which did not complete is simply run again.
+func (d *CleanupService) cleanup(beaconState *pb.BeaconState) error {
+	finalizedSlot := helpers.StartSlot(beaconState.FinalizedEpoch)
+	cleanedSlot, err := d.beaconDB.CleanedFinalizedSlot()
+	if err != nil {
+		return fmt.Errorf("could not retrieve last cleaned finalized slot: %v", err)
+	}
+	if finalizedSlot <= cleanedSlot {
+		return nil
+	}
+
+	startSlot := cleanedSlot + 1
+	if startSlot < params.BeaconConfig().GenesisSlot {
+		startSlot = params.BeaconConfig().GenesisSlot
+	}
+	attestations, err := d.deleteFinalizedAttestations(startSlot, finalizedSlot)
+	if err != nil {
+		return fmt.Errorf("could not delete finalized attestations: %v", err)
+	}
+
+	blocks, err := d.beaconDB.DeleteNonCanonicalBlocks(startSlot, finalizedSlot)
+	if err != nil {
+		return fmt.Errorf("could not delete non-canonical blocks: %v", err)
+	}
+
+	// The post-state of the finalized block is kept, as blocks built on top of
+	// the finalized block are applied to it.
+	finalizedBlock, err := d.finalizedBlock(finalizedSlot)
+	if err != nil {
+		return fmt.Errorf("could not retrieve finalized block: %v", err)
+	}
+	var states int
+	if finalizedBlock != nil {
+		states, err = d.beaconDB.DeleteBlockStates(finalizedBlock.Slot)
+		if err != nil {
+			return fmt.Errorf("could not delete block states: %v", err)
+		}
+	}
+
+	if err := d.beaconDB.SaveCleanedFinalizedSlot(finalizedSlot); err != nil {
+		return fmt.Errorf("could not save cleaned finalized slot: %v", err)
+	}
+	log.WithFields(logrus.Fields{
+		"finalizedSlot": finalizedSlot - params.BeaconConfig().GenesisSlot,
+		"attestations":  attestations,
+		"blocks":        blocks,
+		"states":        states,
+	}).Info("Cleaned up finalized DB content")
+	return nil
+}
+
+// deleteFinalizedAttestations removes the attestations included in the canonical
+// blocks between the given slots from the attestation pool.
+func (d *CleanupService) deleteFinalizedAttestations(startSlot uint64, endSlot uint64) (int, error) {
+	var deleted int
+	for slot := startSlot; slot <= endSlot; slot++ {
+		block, err := d.beaconDB.BlockBySlot(slot)
+		if err != nil {
+			return deleted, fmt.Errorf("could not retrieve block at slot %d: %v", slot, err)
+		}
+		if block == nil || block.Body == nil {
+			continue
+		}
+		for _, attestation := range block.Body.Attestations {
+			if err := d.beaconDB.DeleteAttestation(attestation); err != nil {
+				return deleted, fmt.Errorf("could not delete attestation: %v", err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// finalizedBlock returns the canonical block at the finalized slot or, if that slot
+// was skipped, the most recent canonical block before it.
+func (d *CleanupService) finalizedBlock(finalizedSlot uint64) (*pb.BeaconBlock, error) {
+	slot := finalizedSlot
+	for {
+		block, err := d.beaconDB.BlockBySlot(slot)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil || slot <= params.BeaconConfig().GenesisSlot {
+			return block, nil
+		}
+		slot--
+	}
+}
@@ -0,0 +1,159 @@
+package dbcleanup
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func init() {
+	logrus.SetLevel(logrus.DebugLevel)
+	logrus.SetOutput(ioutil.Discard)
+}
+
+type mockChainService struct {
+	stateFeed *event.Feed
+}
+
+func (m *mockChainService) CanonicalStateFeed() *event.Feed {
+	return m.stateFeed
+}
+
+func setupCleanupService(beaconDB *db.BeaconDB) *CleanupService {
+	return NewCleanupService(context.Background(), &Config{
+		BeaconDB:     beaconDB,
+		ChainService: &mockChainService{stateFeed: new(event.Feed)},
+	})
+}
+
+func saveBlockWithState(t *testing.T, beaconDB *db.BeaconDB, block *pb.BeaconBlock) [32]byte {
+	if err := beaconDB.SaveBlock(block); err != nil {
+		t.Fatalf("Could not save block: %v", err)
+	}
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatalf("Could not hash block: %v", err)
+	}
+	if err := beaconDB.SaveUnfinalizedBlockState(root, &pb.BeaconState{Slot: block.Slot}); err != nil {
+		t.Fatalf("Could not save block state: %v", err)
+	}
+	return root
+}
+
+func TestLifecycle_OK(t *testing.T) {
+	hook := logTest.NewGlobal()
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+
+	cleanupService := setupCleanupService(beaconDB)
+	cleanupService.Start()
+	if err := cleanupService.Stop(); err != nil {
+		t.Fatalf("Could not stop service: %v", err)
+	}
+
+	testutil.AssertLogsContain(t, hook, "Starting service")
+	testutil.AssertLogsContain(t, hook, "Stopping service")
+}
+
+func TestCleanup_PrunesFinalizedContent(t *testing.T) {
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	cleanupService := setupCleanupService(beaconDB)
+
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	attestation := &pb.Attestation{
+		Data: &pb.AttestationData{Slot: genesisSlot, Shard: 1},
+	}
+	if err := beaconDB.SaveAttestation(attestation); err != nil {
+		t.Fatalf("Could not save attestation: %v", err)
+	}
+
+	genesis := &pb.BeaconBlock{Slot: genesisSlot}
+	genesisRoot := saveBlockWithState(t, beaconDB, genesis)
+	blockA := &pb.BeaconBlock{
+		Slot:             genesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		Body:             &pb.BeaconBlockBody{Attestations: []*pb.Attestation{attestation}},
+	}
+	rootA := saveBlockWithState(t, beaconDB, blockA)
+	blockB := &pb.BeaconBlock{
+		Slot:             genesisSlot + 1,
+		ParentRootHash32: genesisRoot[:],
+		StateRootHash32:  []byte{'B'},
+	}
+	rootB := saveBlockWithState(t, beaconDB, blockB)
+	finalized := &pb.BeaconBlock{
+		Slot:             genesisSlot + params.BeaconConfig().SlotsPerEpoch,
+		ParentRootHash32: rootA[:],
+	}
+	finalizedRoot := saveBlockWithState(t, beaconDB, finalized)
+	for _, block := range []*pb.BeaconBlock{genesis, blockA, finalized} {
+		if err := beaconDB.UpdateChainHead(block, &pb.BeaconState{}); err != nil {
+			t.Fatalf("Could not update chain head: %v", err)
+		}
+	}
+
+	finalizedState := &pb.BeaconState{FinalizedEpoch: params.BeaconConfig().GenesisEpoch + 1}
+	if err := cleanupService.cleanup(finalizedState); err != nil {
+		t.Fatalf("Could not clean up DB: %v", err)
+	}
+
+	attestationRoot, err := hashutil.HashProto(attestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beaconDB.HasAttestation(attestationRoot) {
+		t.Error("Expected attestation included in a finalized block to be deleted")
+	}
+	if beaconDB.HasBlock(rootB) {
+		t.Error("Expected block conflicting with the finalized chain to be deleted")
+	}
+	for _, root := range [][32]byte{genesisRoot, rootA, finalizedRoot} {
+		if !beaconDB.HasBlock(root) {
+			t.Errorf("Expected canonical block %#x to be kept", root)
+		}
+	}
+	for _, root := range [][32]byte{genesisRoot, rootA, rootB} {
+		if s, err := beaconDB.BlockState(root); err != nil || s != nil {
+			t.Errorf("Expected state of block %#x to be deleted, received %v, %v", root, s, err)
+		}
+	}
+	if s, err := beaconDB.BlockState(finalizedRoot); err != nil || s == nil {
+		t.Errorf("Expected state of the finalized block to be kept, received %v, %v", s, err)
+	}
+
+	cleanedSlot, err := beaconDB.CleanedFinalizedSlot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cleanedSlot != finalized.Slot {
+		t.Errorf("Expected cleaned finalized slot %d, received %d", finalized.Slot, cleanedSlot)
+	}
+}
+
+func TestCleanup_SkipsAlreadyCleanedSlot(t *testing.T) {
+	hook := logTest.NewGlobal()
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	cleanupService := setupCleanupService(beaconDB)
+
+	finalizedEpoch := params.BeaconConfig().GenesisEpoch + 1
+	finalizedSlot := finalizedEpoch * params.BeaconConfig().SlotsPerEpoch
+	if err := beaconDB.SaveCleanedFinalizedSlot(finalizedSlot); err != nil {
+		t.Fatal(err)
+	}
+	if err := cleanupService.cleanup(&pb.BeaconState{FinalizedEpoch: finalizedEpoch}); err != nil {
+		t.Fatalf("Could not clean up DB: %v", err)
+	}
+	testutil.AssertLogsDoNotContain(t, hook, "Cleaned up finalized DB content")
+}
@@ -1,28 +1,15 @@
 package internal
 
 import (
-	"crypto/rand"
-	"fmt"
-	"math/big"
 	"os"
-	"path"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
-	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
-// SetupDB instantiates and returns a BeaconDB instance.
+// SetupDB instantiates and returns a BeaconDB instance, which keeps its content in memory.
 func SetupDB(t testing.TB) *db.BeaconDB {
-	randPath, err := rand.Int(rand.Reader, big.NewInt(1000000))
-	if err != nil {
-		t.Fatalf("Could not generate random file path: %v", err)
-	}
-	path := path.Join(testutil.TempDir(), fmt.Sprintf("/%d", randPath))
-	if err := os.RemoveAll(path); err != nil {
-		t.Fatalf("Failed to remove directory: %v", err)
-	}
-	db, err := db.NewDB(path)
+	db, err := db.NewInMemoryDB()
 	if err != nil {
 		t.Fatalf("Could not setup DB: %v", err)
 	}
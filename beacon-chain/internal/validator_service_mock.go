@@ -70,3 +70,23 @@ func (mr *MockValidatorServiceClientMockRecorder) ValidatorIndex(arg0, arg1 inte
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorIndex", reflect.TypeOf((*MockValidatorServiceClient)(nil).ValidatorIndex), varargs...)
 }
+
+// ValidatorIndices mocks base method
+func (m *MockValidatorServiceClient) ValidatorIndices(arg0 context.Context, arg1 *v1.ValidatorIndicesRequest, arg2 ...grpc.CallOption) (*v1.ValidatorIndicesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidatorIndices", varargs...)
+	ret0, _ := ret[0].(*v1.ValidatorIndicesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorIndices indicates an expected call of ValidatorIndices
+func (mr *MockValidatorServiceClientMockRecorder) ValidatorIndices(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorIndices", reflect.TypeOf((*MockValidatorServiceClient)(nil).ValidatorIndices), varargs...)
+}
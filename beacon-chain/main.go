@@ -61,6 +61,46 @@ VERSION:
 	app.Action = startNode
 	app.Version = version.GetVersion()
 
+	app.Commands = []cli.Command{
+		{
+			Name:     "db",
+			Category: "db",
+			Usage:    "defines commands to move chain data in and out of the beacon chain DB, and to check it",
+			Subcommands: cli.Commands{
+				cli.Command{
+					Name: "export",
+					Description: `exports the canonical blocks of a slot range to a file, optionally along with
+the post-state of the first block, to archive chain data or to move it to another node`,
+					Flags: []cli.Flag{
+						utils.DBFileFlag,
+						utils.DBExportStartSlotFlag,
+						utils.DBExportEndSlotFlag,
+						utils.DBExportStateFlag,
+					},
+					Action: node.ExportDB,
+				},
+				cli.Command{
+					Name: "import",
+					Description: `imports the blocks of an export file, validating each of them by running the
+state transition before saving it as the new chain head`,
+					Flags: []cli.Flag{
+						utils.DBFileFlag,
+					},
+					Action: node.ImportDB,
+				},
+				cli.Command{
+					Name: "verify",
+					Description: `checks that the canonical chain, chain head, canonical state and validator
+indices recorded in the DB are consistent, and reports every problem found`,
+					Flags: []cli.Flag{
+						utils.DBFixFlag,
+					},
+					Action: node.VerifyDB,
+				},
+			},
+		},
+	}
+
 	app.Flags = []cli.Flag{
 		utils.DemoConfigFlag,
 		utils.DepositContractFlag,
@@ -71,7 +111,16 @@ VERSION:
 		utils.GenesisJSON,
 		utils.EnablePOWChain,
 		utils.EnableDBCleanup,
+		utils.DBEngineFlag,
+		utils.DBBatchSizeFlag,
 		utils.ChainStartDelay,
+		utils.CheckpointStateFlag,
+		utils.CheckpointBlockFlag,
+		utils.CheckpointBlockRootFlag,
+		utils.HealthMaxHeadSlotLagFlag,
+		utils.HealthMaxFinalityEpochLagFlag,
+		utils.HealthMaxFullBlockBufferFlag,
+		utils.HealthMaxBlockFailuresFlag,
 		cmd.BootstrapNode,
 		cmd.RelayNode,
 		cmd.P2PPort,
@@ -0,0 +1,394 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/utils"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/ssz"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// A chain export file starts with chainExportMagic and the encoding of its records. Each
+// record is made of its type, the length of its encoding as a little-endian uint32, and
+// its encoding. The optional state record comes first, and holds the post-state of the
+// first block record. The block records follow in increasing slot order.
+var chainExportMagic = []byte("prysm-chain-export")
+
+const (
+	protobufEncoding byte = iota
+	sszEncoding
+)
+
+const (
+	stateRecord byte = iota + 1
+	blockRecord
+)
+
+// maxExportRecordSize bounds the length of a record read from an export file, so a
+// corrupted length prefix does not exhaust the memory of the node.
+const maxExportRecordSize = 1 << 28
+
+// ExportDB is the action of the db export command. It writes the canonical blocks of the
+// requested slot range to a file, along with the post-state of the first block if asked to.
+func ExportDB(ctx *cli.Context) error {
+	filePath := ctx.String(utils.DBFileFlag.Name)
+	if filePath == "" {
+		return errors.New("the export file is required")
+	}
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return fmt.Errorf("could not open beacon chain DB: %v", err)
+	}
+	defer beaconDB.Close()
+
+	startSlot := params.BeaconConfig().GenesisSlot + ctx.Uint64(utils.DBExportStartSlotFlag.Name)
+	// Without an end slot, the export goes up to the chain head.
+	endSlot := uint64(math.MaxUint64)
+	if ctx.IsSet(utils.DBExportEndSlotFlag.Name) {
+		endSlot = params.BeaconConfig().GenesisSlot + ctx.Uint64(utils.DBExportEndSlotFlag.Name)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	exported, err := exportChain(beaconDB, w, startSlot, endSlot, ctx.Bool(utils.DBExportStateFlag.Name), filepath.Ext(filePath) == ".ssz")
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not export chain: %v", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		"blocks": exported,
+		"file":   filePath,
+	}).Info("Exported beacon chain")
+	return nil
+}
+
+// ImportDB is the action of the db import command. It validates the blocks of an export
+// file by running the state transition on each of them, and saves them as the new chain head.
+func ImportDB(ctx *cli.Context) error {
+	filePath := ctx.String(utils.DBFileFlag.Name)
+	if filePath == "" {
+		return errors.New("the import file is required")
+	}
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return fmt.Errorf("could not open beacon chain DB: %v", err)
+	}
+	defer beaconDB.Close()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	imported, err := importChain(beaconDB, bufio.NewReader(file))
+	if err != nil {
+		return fmt.Errorf("could not import chain: %v", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		"blocks": imported,
+		"file":   filePath,
+	}).Info("Imported beacon chain")
+	return nil
+}
+
+// exportChain writes the canonical blocks from startSlot to endSlot, inclusive, as an
+// export file. Returns the number of exported blocks.
+func exportChain(beaconDB *db.BeaconDB, w io.Writer, startSlot uint64, endSlot uint64, includeState bool, useSSZ bool) (int, error) {
+	head, err := beaconDB.ChainHead()
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve chain head: %v", err)
+	}
+	if endSlot > head.Slot {
+		endSlot = head.Slot
+	}
+	if startSlot > endSlot {
+		return 0, fmt.Errorf("start slot %d is after end slot %d",
+			startSlot-params.BeaconConfig().GenesisSlot, endSlot-params.BeaconConfig().GenesisSlot)
+	}
+
+	encoding := protobufEncoding
+	if useSSZ {
+		encoding = sszEncoding
+	}
+	if _, err := w.Write(append(append([]byte{}, chainExportMagic...), encoding)); err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	for slot := startSlot; slot <= endSlot; slot++ {
+		block, err := beaconDB.BlockBySlot(slot)
+		if err != nil {
+			return exported, fmt.Errorf("could not retrieve block at slot %d: %v", slot-params.BeaconConfig().GenesisSlot, err)
+		}
+		if block == nil {
+			continue
+		}
+		if exported == 0 && includeState {
+			beaconState, err := beaconDB.StateAtSlot(block.Slot)
+			if err != nil {
+				return exported, fmt.Errorf("could not regenerate state at slot %d: %v", block.Slot-params.BeaconConfig().GenesisSlot, err)
+			}
+			if err := writeExportRecord(w, encoding, stateRecord, beaconState); err != nil {
+				return exported, fmt.Errorf("could not write state: %v", err)
+			}
+		}
+		if err := writeExportRecord(w, encoding, blockRecord, block); err != nil {
+			return exported, fmt.Errorf("could not write block at slot %d: %v", block.Slot-params.BeaconConfig().GenesisSlot, err)
+		}
+		exported++
+	}
+	if exported == 0 {
+		return 0, errors.New("no canonical block in the slot range")
+	}
+	return exported, nil
+}
+
+// importChain reads an export file and applies its blocks on top of the DB. A state
+// record initializes an empty DB from the first block and its post-state, and is ignored
+// when the DB already holds a chain. Blocks already in the DB are skipped, and every
+// other block has to extend a block whose post-state is known. Returns the number of
+// imported blocks.
+func importChain(beaconDB *db.BeaconDB, r io.Reader) (int, error) {
+	header := make([]byte, len(chainExportMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, fmt.Errorf("could not read export file header: %v", err)
+	}
+	if !bytes.Equal(header[:len(chainExportMagic)], chainExportMagic) {
+		return 0, errors.New("not a chain export file")
+	}
+	encoding := header[len(chainExportMagic)]
+	if encoding != protobufEncoding && encoding != sszEncoding {
+		return 0, fmt.Errorf("unknown export file encoding %d", encoding)
+	}
+
+	var checkpointState *pb.BeaconState
+	var beaconState *pb.BeaconState
+	var prevRoot [32]byte
+	blocks := 0
+	imported := 0
+	for records := 0; ; records++ {
+		recordType, enc, err := readExportRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		switch recordType {
+		case stateRecord:
+			if records != 0 {
+				return imported, errors.New("state record does not come first")
+			}
+			checkpointState = &pb.BeaconState{}
+			if err := decodeExportRecord(encoding, enc, checkpointState); err != nil {
+				return imported, fmt.Errorf("could not decode state: %v", err)
+			}
+		case blockRecord:
+			blocks++
+			block := &pb.BeaconBlock{}
+			if err := decodeExportRecord(encoding, enc, block); err != nil {
+				return imported, fmt.Errorf("could not decode block: %v", err)
+			}
+			root, err := hashutil.HashBeaconBlock(block)
+			if err != nil {
+				return imported, fmt.Errorf("could not tree hash block: %v", err)
+			}
+
+			if records == 1 && checkpointState != nil {
+				initialized, err := initializeImport(beaconDB, block, checkpointState)
+				if err != nil {
+					return imported, err
+				}
+				if initialized {
+					beaconState, prevRoot = checkpointState, root
+					imported++
+					continue
+				}
+			}
+			if beaconDB.HasBlock(root) {
+				beaconState = nil
+				continue
+			}
+
+			parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+			if beaconState == nil || parentRoot != prevRoot {
+				beaconState, err = importPreState(beaconDB, parentRoot)
+				if err != nil {
+					return imported, fmt.Errorf("could not retrieve pre-state of block at slot %d: %v",
+						block.Slot-params.BeaconConfig().GenesisSlot, err)
+				}
+			}
+			beaconState, err = applyImportedBlock(beaconState, block)
+			if err != nil {
+				return imported, fmt.Errorf("invalid block at slot %d: %v", block.Slot-params.BeaconConfig().GenesisSlot, err)
+			}
+			if err := beaconDB.SaveBlock(block); err != nil {
+				return imported, fmt.Errorf("could not save block: %v", err)
+			}
+			if err := beaconDB.SaveUnfinalizedBlockState(root, beaconState); err != nil {
+				return imported, fmt.Errorf("could not save block state: %v", err)
+			}
+			if err := beaconDB.UpdateChainHead(block, beaconState); err != nil {
+				return imported, fmt.Errorf("could not update chain head: %v", err)
+			}
+			prevRoot = root
+			imported++
+		default:
+			return imported, fmt.Errorf("unknown record type %d", recordType)
+		}
+	}
+	if checkpointState != nil && blocks == 0 {
+		return 0, errors.New("state record is not followed by its block")
+	}
+	return imported, nil
+}
+
+// initializeImport seeds an empty DB with the first block of an export file and its
+// post-state. Returns false if the DB already holds a chain.
+func initializeImport(beaconDB *db.BeaconDB, block *pb.BeaconBlock, beaconState *pb.BeaconState) (bool, error) {
+	existing, err := beaconDB.State()
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve beacon state: %v", err)
+	}
+	if existing != nil {
+		log.Warn("Beacon chain data already exists, ignoring exported state")
+		return false, nil
+	}
+	if err := beaconDB.InitializeStateFromCheckpoint(block, beaconState); err != nil {
+		return false, fmt.Errorf("could not initialize beacon chain from exported state: %v", err)
+	}
+	return true, nil
+}
+
+// importPreState returns the post-state of the parent of an imported block, which is
+// either the chain head or a block processed since the last finalized epoch.
+func importPreState(beaconDB *db.BeaconDB, parentRoot [32]byte) (*pb.BeaconState, error) {
+	head, err := beaconDB.ChainHead()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chain head: %v", err)
+	}
+	headRoot, err := hashutil.HashBeaconBlock(head)
+	if err != nil {
+		return nil, fmt.Errorf("could not tree hash chain head: %v", err)
+	}
+	if headRoot == parentRoot {
+		return beaconDB.State()
+	}
+	beaconState, err := beaconDB.BlockState(parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	if beaconState == nil {
+		return nil, fmt.Errorf("no state found for parent block %#x", parentRoot)
+	}
+	return beaconState, nil
+}
+
+// applyImportedBlock runs the state transition of the skipped slots before the block,
+// then the block transition with its signatures verified.
+func applyImportedBlock(beaconState *pb.BeaconState, block *pb.BeaconBlock) (*pb.BeaconState, error) {
+	if block.Body == nil {
+		return nil, errors.New("block has no body")
+	}
+	if block.Slot <= beaconState.Slot {
+		return nil, fmt.Errorf("block is not after the pre-state slot %d", beaconState.Slot-params.BeaconConfig().GenesisSlot)
+	}
+	parentRoot := bytesutil.ToBytes32(block.ParentRootHash32)
+
+	var err error
+	for beaconState.Slot < block.Slot-1 {
+		beaconState, err = state.ExecuteStateTransition(beaconState, nil, parentRoot, true /* sig verify */)
+		if err != nil {
+			return nil, fmt.Errorf("could not execute state transition without block %v", err)
+		}
+	}
+	beaconState, err = state.ExecuteStateTransition(beaconState, block, parentRoot, true /* sig verify */)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute state transition with block %v", err)
+	}
+	return beaconState, nil
+}
+
+func writeExportRecord(w io.Writer, encoding byte, recordType byte, msg proto.Message) error {
+	var enc []byte
+	if encoding == sszEncoding {
+		buf := new(bytes.Buffer)
+		if err := ssz.Encode(buf, msg); err != nil {
+			return err
+		}
+		enc = buf.Bytes()
+	} else {
+		var err error
+		enc, err = proto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+	}
+	if len(enc) > maxExportRecordSize {
+		return fmt.Errorf("record of %d bytes exceeds the maximum record size", len(enc))
+	}
+
+	prefix := make([]byte, 5)
+	prefix[0] = recordType
+	binary.LittleEndian.PutUint32(prefix[1:], uint32(len(enc)))
+	if _, err := w.Write(prefix); err != nil {
+		return err
+	}
+	_, err := w.Write(enc)
+	return err
+}
+
+// readExportRecord returns the type and encoding of the next record, or io.EOF once
+// every record was read.
+func readExportRecord(r io.Reader) (byte, []byte, error) {
+	prefix := make([]byte, 5)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, fmt.Errorf("could not read record prefix: %v", err)
+	}
+	size := binary.LittleEndian.Uint32(prefix[1:])
+	if size > maxExportRecordSize {
+		return 0, nil, fmt.Errorf("record of %d bytes exceeds the maximum record size", size)
+	}
+	enc := make([]byte, size)
+	if _, err := io.ReadFull(r, enc); err != nil {
+		return 0, nil, fmt.Errorf("could not read record: %v", err)
+	}
+	return prefix[0], enc, nil
+}
+
+func decodeExportRecord(encoding byte, enc []byte, msg proto.Message) error {
+	if encoding == sszEncoding {
+		return ssz.Decode(bytes.NewReader(enc), msg)
+	}
+	return proto.Unmarshal(enc, msg)
+}
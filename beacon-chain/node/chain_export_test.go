@@ -0,0 +1,193 @@
+package node
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func initializeGenesis(t *testing.T, beaconDB *db.BeaconDB) {
+	deposits := make([]*pb.Deposit, 8)
+	for i := range deposits {
+		depositInput := &pb.DepositInput{Pubkey: []byte{byte(i)}}
+		depositData, err := helpers.EncodeDepositData(depositInput, params.BeaconConfig().MaxDepositAmount, time.Now().Unix())
+		if err != nil {
+			t.Fatalf("Could not encode deposit: %v", err)
+		}
+		deposits[i] = &pb.Deposit{DepositData: depositData}
+	}
+	if err := beaconDB.InitializeState(uint64(time.Now().Unix()), deposits); err != nil {
+		t.Fatalf("Could not initialize beacon state: %v", err)
+	}
+}
+
+func TestExportImport_GenesisWithState(t *testing.T) {
+	exportImportGenesis(t, false /* useSSZ */)
+}
+
+func TestExportImport_GenesisWithStateSSZ(t *testing.T) {
+	exportImportGenesis(t, true /* useSSZ */)
+}
+
+// exportImportGenesis exports the genesis block and state of a chain in the given encoding,
+// and checks that importing them into an empty DB restores the same chain head and state.
+func exportImportGenesis(t *testing.T, useSSZ bool) {
+	source := internal.SetupDB(t)
+	defer internal.TeardownDB(t, source)
+	initializeGenesis(t, source)
+
+	buf := new(bytes.Buffer)
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	exported, err := exportChain(source, buf, genesisSlot, genesisSlot+10, true /* includeState */, useSSZ)
+	if err != nil {
+		t.Fatalf("Could not export chain: %v", err)
+	}
+	if exported != 1 {
+		t.Errorf("Expected 1 exported block, received %d", exported)
+	}
+
+	target := internal.SetupDB(t)
+	defer internal.TeardownDB(t, target)
+	imported, err := importChain(target, buf)
+	if err != nil {
+		t.Fatalf("Could not import chain: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("Expected 1 imported block, received %d", imported)
+	}
+
+	sourceHead, err := source.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetHead, err := target.ChainHead()
+	if err != nil {
+		t.Fatalf("Could not retrieve chain head of the import: %v", err)
+	}
+	if !proto.Equal(sourceHead, targetHead) {
+		t.Errorf("Expected chain head %v, received %v", sourceHead, targetHead)
+	}
+	sourceState, err := source.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetState, err := target.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(sourceState, targetState) {
+		t.Error("Expected the imported state to equal the exported state")
+	}
+}
+
+func TestExportRecord_SSZRoundTrip(t *testing.T) {
+	block := &pb.BeaconBlock{
+		Slot:             params.BeaconConfig().GenesisSlot + 3,
+		ParentRootHash32: []byte{'p'},
+		StateRootHash32:  []byte{'s'},
+		RandaoReveal:     []byte{'r'},
+		Eth1Data:         &pb.Eth1Data{DepositRootHash32: []byte{'d'}, BlockHash32: []byte{'b'}},
+		Body: &pb.BeaconBlockBody{
+			Attestations: []*pb.Attestation{{
+				Data:                &pb.AttestationData{Slot: 2, Shard: 1},
+				AggregationBitfield: []byte{1},
+			}},
+			VoluntaryExits: []*pb.VoluntaryExit{{Epoch: 1, ValidatorIndex: 4}},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := writeExportRecord(buf, sszEncoding, blockRecord, block); err != nil {
+		t.Fatalf("Could not write record: %v", err)
+	}
+	recordType, enc, err := readExportRecord(buf)
+	if err != nil {
+		t.Fatalf("Could not read record: %v", err)
+	}
+	if recordType != blockRecord {
+		t.Errorf("Expected block record type %d, received %d", blockRecord, recordType)
+	}
+	decoded := &pb.BeaconBlock{}
+	if err := decodeExportRecord(sszEncoding, enc, decoded); err != nil {
+		t.Fatalf("Could not decode record: %v", err)
+	}
+	if hashBlock(t, decoded) != hashBlock(t, block) {
+		t.Errorf("Expected decoded block %v, received %v", block, decoded)
+	}
+}
+
+func TestImportChain_SkipsKnownBlocks(t *testing.T) {
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	initializeGenesis(t, beaconDB)
+
+	buf := new(bytes.Buffer)
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	if _, err := exportChain(beaconDB, buf, genesisSlot, genesisSlot, false /* includeState */, false /* useSSZ */); err != nil {
+		t.Fatalf("Could not export chain: %v", err)
+	}
+	imported, err := importChain(beaconDB, buf)
+	if err != nil {
+		t.Fatalf("Could not import chain: %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("Expected known blocks to be skipped, received %d imported blocks", imported)
+	}
+}
+
+func TestImportChain_RejectsInvalidBlock(t *testing.T) {
+	source := internal.SetupDB(t)
+	defer internal.TeardownDB(t, source)
+	initializeGenesis(t, source)
+
+	buf := new(bytes.Buffer)
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	if _, err := exportChain(source, buf, genesisSlot, genesisSlot, true /* includeState */, false /* useSSZ */); err != nil {
+		t.Fatalf("Could not export chain: %v", err)
+	}
+	genesis, err := source.ChainHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot := hashBlock(t, genesis)
+	invalid := &pb.BeaconBlock{Slot: genesisSlot + 1, ParentRootHash32: genesisRoot[:]}
+	if err := writeExportRecord(buf, protobufEncoding, blockRecord, invalid); err != nil {
+		t.Fatal(err)
+	}
+
+	target := internal.SetupDB(t)
+	defer internal.TeardownDB(t, target)
+	want := "has no body"
+	if _, err := importChain(target, buf); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %q, received %v", want, err)
+	}
+	if target.HasBlock(hashBlock(t, invalid)) {
+		t.Error("Expected the invalid block not to be saved")
+	}
+}
+
+func TestImportChain_RejectsUnknownFile(t *testing.T) {
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+
+	want := "not a chain export file"
+	if _, err := importChain(beaconDB, strings.NewReader("definitely not an export file")); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %q, received %v", want, err)
+	}
+}
+
+func hashBlock(t *testing.T, block *pb.BeaconBlock) [32]byte {
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/utils"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/ssz"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// initializeFromCheckpoint seeds an empty beacon DB with the trusted finalized block and
+// state given through the checkpoint flags, so the node syncs forward from the checkpoint
+// instead of waiting for the ChainStart log and replaying every block since genesis.
+func (b *BeaconNode) initializeFromCheckpoint(ctx *cli.Context) error {
+	statePath := ctx.GlobalString(utils.CheckpointStateFlag.Name)
+	blockPath := ctx.GlobalString(utils.CheckpointBlockFlag.Name)
+	rootHex := ctx.GlobalString(utils.CheckpointBlockRootFlag.Name)
+	if blockPath == "" || rootHex == "" {
+		return errors.New("the checkpoint block and its root are required to start from a checkpoint state")
+	}
+
+	beaconState, err := b.db.State()
+	if err != nil {
+		return fmt.Errorf("could not retrieve beacon state: %v", err)
+	}
+	if beaconState != nil {
+		log.Warn("Beacon chain data already exists, ignoring checkpoint state")
+		return nil
+	}
+
+	root, err := hex.DecodeString(strings.TrimPrefix(rootHex, "0x"))
+	if err != nil {
+		return fmt.Errorf("could not decode checkpoint block root: %v", err)
+	}
+	if len(root) != 32 {
+		return fmt.Errorf("expected a 32 byte checkpoint block root, received %d bytes", len(root))
+	}
+	block, checkpointState, err := loadCheckpoint(statePath, blockPath, bytesutil.ToBytes32(root))
+	if err != nil {
+		return err
+	}
+	if err := b.db.InitializeStateFromCheckpoint(block, checkpointState); err != nil {
+		return fmt.Errorf("could not initialize beacon chain from checkpoint: %v", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		"slot":      block.Slot - params.BeaconConfig().GenesisSlot,
+		"blockRoot": fmt.Sprintf("%#x", root),
+	}).Info("Initialized beacon chain from checkpoint")
+	return nil
+}
+
+// loadCheckpoint reads the checkpoint block and state from disk, and verifies the
+// block against the trusted block root.
+func loadCheckpoint(statePath string, blockPath string, blockRoot [32]byte) (*pb.BeaconBlock, *pb.BeaconState, error) {
+	block := &pb.BeaconBlock{}
+	if err := decodeCheckpointFile(blockPath, block); err != nil {
+		return nil, nil, fmt.Errorf("could not load checkpoint block: %v", err)
+	}
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not tree hash checkpoint block: %v", err)
+	}
+	if root != blockRoot {
+		return nil, nil, fmt.Errorf("checkpoint block root %#x does not match trusted root %#x", root, blockRoot)
+	}
+
+	beaconState := &pb.BeaconState{}
+	if err := decodeCheckpointFile(statePath, beaconState); err != nil {
+		return nil, nil, fmt.Errorf("could not load checkpoint state: %v", err)
+	}
+	return block, beaconState, nil
+}
+
+// decodeCheckpointFile decodes a file with the .ssz extension as SSZ
+// and any other file as protobuf.
+func decodeCheckpointFile(path string, msg proto.Message) error {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(path) == ".ssz" {
+		return ssz.Decode(bytes.NewReader(enc), msg)
+	}
+	return proto.Unmarshal(enc, msg)
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func writeCheckpointFiles(t *testing.T, dir string) (string, string, *pb.BeaconBlock, *pb.BeaconState) {
+	beaconState := &pb.BeaconState{
+		Slot:           params.BeaconConfig().GenesisSlot + 64,
+		FinalizedEpoch: params.BeaconConfig().GenesisEpoch + 1,
+	}
+	stateRoot, err := hashutil.HashProto(beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pb.BeaconBlock{Slot: beaconState.Slot, StateRootHash32: stateRoot[:]}
+
+	statePath := path.Join(dir, "state.pb")
+	blockPath := path.Join(dir, "block.pb")
+	for file, msg := range map[string]proto.Message{statePath: beaconState, blockPath: block} {
+		enc, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, enc, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return statePath, blockPath, block, beaconState
+}
+
+func TestLoadCheckpoint_OK(t *testing.T) {
+	dir := path.Join(testutil.TempDir(), "checkpointtest1")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	statePath, blockPath, block, beaconState := writeCheckpointFiles(t, dir)
+	blockRoot, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadedBlock, loadedState, err := loadCheckpoint(statePath, blockPath, blockRoot)
+	if err != nil {
+		t.Fatalf("Could not load checkpoint: %v", err)
+	}
+	if !proto.Equal(loadedBlock, block) {
+		t.Errorf("Expected block %v, received %v", block, loadedBlock)
+	}
+	if !proto.Equal(loadedState, beaconState) {
+		t.Errorf("Expected state %v, received %v", beaconState, loadedState)
+	}
+}
+
+func TestLoadCheckpoint_UntrustedBlockRoot(t *testing.T) {
+	dir := path.Join(testutil.TempDir(), "checkpointtest2")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	statePath, blockPath, _, _ := writeCheckpointFiles(t, dir)
+	want := "does not match trusted root"
+	if _, _, err := loadCheckpoint(statePath, blockPath, [32]byte{'a'}); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %s, received %v", want, err)
+	}
+}
@@ -0,0 +1,52 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/utils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// VerifyDB is the action of the db verify command. It checks the consistency of the
+// canonical chain recorded in the DB and reports every problem found. If asked to, it
+// rewinds the chain head to the last consistent block.
+func VerifyDB(ctx *cli.Context) error {
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return fmt.Errorf("could not open beacon chain DB: %v", err)
+	}
+	defer beaconDB.Close()
+
+	report, err := beaconDB.VerifyIntegrity()
+	if err != nil {
+		return fmt.Errorf("could not verify beacon chain DB: %v", err)
+	}
+	if report.Consistent() {
+		log.WithField("headSlot", report.HeadSlot-params.BeaconConfig().GenesisSlot).Info("Beacon chain DB is consistent")
+		return nil
+	}
+	for _, problem := range report.Problems {
+		log.Error(problem)
+	}
+	if !report.Repairable {
+		return fmt.Errorf("found %d problems, and no consistent block to rewind the chain head to", len(report.Problems))
+	}
+
+	fields := logrus.Fields{
+		"problems": len(report.Problems),
+		"slot":     report.LastConsistentSlot - params.BeaconConfig().GenesisSlot,
+		"root":     fmt.Sprintf("%#x", report.LastConsistentRoot),
+	}
+	if !ctx.Bool(utils.DBFixFlag.Name) {
+		log.WithFields(fields).Warn("Beacon chain DB is inconsistent, run with --fix to rewind the chain head to the last consistent block")
+		return errors.New("beacon chain DB is inconsistent")
+	}
+	if err := beaconDB.RewindChainHead(report.LastConsistentRoot); err != nil {
+		return fmt.Errorf("could not rewind chain head: %v", err)
+	}
+	log.WithFields(fields).Info("Rewound chain head to the last consistent block")
+	return nil
+}
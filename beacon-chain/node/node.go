@@ -9,12 +9,15 @@ import (
 	"path"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/beacon-chain/attestation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/dbcleanup"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
@@ -66,6 +69,12 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if ctx.GlobalString(utils.CheckpointStateFlag.Name) != "" {
+		if err := beacon.initializeFromCheckpoint(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := beacon.registerP2P(ctx); err != nil {
 		return nil, err
 	}
@@ -78,10 +87,20 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerAttestationService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerBlockchainService(ctx); err != nil {
 		return nil, err
 	}
 
+	if ctx.GlobalBool(utils.EnableDBCleanup.Name) {
+		if err := beacon.registerDBCleanService(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := beacon.registerSyncService(ctx); err != nil {
 		return nil, err
 	}
@@ -140,6 +159,7 @@ func (b *BeaconNode) Close() {
 
 	log.Info("Stopping beacon node")
 	b.services.StopAll()
+	// Closing the database flushes the writes buffered by write batching.
 	if err := b.db.Close(); err != nil {
 		log.Errorf("Failed to close database: %v", err)
 	}
@@ -147,20 +167,29 @@ func (b *BeaconNode) Close() {
 }
 
 func (b *BeaconNode) startDB(ctx *cli.Context) error {
-	baseDir := ctx.GlobalString(cmd.DataDirFlag.Name)
-
-	db, err := db.NewDB(path.Join(baseDir, beaconChainDBName))
+	db, err := openDB(ctx)
 	if err != nil {
 		return err
 	}
 
+	if batchSize := ctx.GlobalInt(utils.DBBatchSizeFlag.Name); batchSize > 0 {
+		db.EnableWriteBatching(batchSize, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+	}
+
 	log.Info("checking db")
 	b.db = db
 	return nil
 }
 
+// openDB opens the beacon chain DB of the data directory with the configured storage engine.
+func openDB(ctx *cli.Context) (*db.BeaconDB, error) {
+	baseDir := ctx.GlobalString(cmd.DataDirFlag.Name)
+	engine := ctx.GlobalString(utils.DBEngineFlag.Name)
+	return db.NewDBWithEngine(path.Join(baseDir, beaconChainDBName), engine)
+}
+
 func (b *BeaconNode) registerP2P(ctx *cli.Context) error {
-	beaconp2p, err := configureP2P(ctx)
+	beaconp2p, err := configureP2P(ctx, b.db)
 	if err != nil {
 		return fmt.Errorf("could not register p2p service: %v", err)
 	}
@@ -180,13 +209,24 @@ func (b *BeaconNode) registerBlockchainService(ctx *cli.Context) error {
 	if err := b.services.FetchService(&opsService); err != nil {
 		return err
 	}
+	var attsService *attestation.Service
+	if err := b.services.FetchService(&attsService); err != nil {
+		return err
+	}
 
 	blockchainService, err := blockchain.NewChainService(context.TODO(), &blockchain.Config{
 		BeaconDB:         b.db,
 		Web3Service:      web3Service,
 		OpsPoolService:   opsService,
+		AttsService:      attsService,
 		BeaconBlockBuf:   10,
 		IncomingBlockBuf: 100, // Big buffer to accommodate other feed subscribers.
+		HealthConfig: blockchain.HealthConfig{
+			MaxHeadSlotLag:              ctx.GlobalUint64(utils.HealthMaxHeadSlotLagFlag.Name),
+			MaxFinalityEpochLag:         ctx.GlobalUint64(utils.HealthMaxFinalityEpochLagFlag.Name),
+			MaxFullBlockBufferDuration:  ctx.GlobalDuration(utils.HealthMaxFullBlockBufferFlag.Name),
+			MaxConsecutiveBlockFailures: ctx.GlobalInt(utils.HealthMaxBlockFailuresFlag.Name),
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("could not register blockchain service: %v", err)
@@ -194,14 +234,49 @@ func (b *BeaconNode) registerBlockchainService(ctx *cli.Context) error {
 	return b.services.RegisterService(blockchainService)
 }
 
+func (b *BeaconNode) registerDBCleanService() error {
+	var chainService *blockchain.ChainService
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	dbCleanService := dbcleanup.NewCleanupService(context.TODO(), &dbcleanup.Config{
+		BeaconDB:        b.db,
+		ChainService:    chainService,
+		SubscriptionBuf: 100,
+	})
+
+	return b.services.RegisterService(dbCleanService)
+}
+
 func (b *BeaconNode) registerOperationService() error {
+	var p2pService *p2p.Server
+	if err := b.services.FetchService(&p2pService); err != nil {
+		return err
+	}
+
 	operationService := operations.NewOpsPoolService(context.TODO(), &operations.Config{
-		BeaconDB: b.db,
+		BeaconDB:           b.db,
+		P2P:                p2pService,
+		ReceiveExitBuf:     100,
+		ReceiveAttBuf:      100,
+		ReceiveSlashingBuf: 100,
+		ReceiveBlockBuf:    100,
 	})
 
 	return b.services.RegisterService(operationService)
 }
 
+func (b *BeaconNode) registerAttestationService() error {
+	attsService := attestation.NewAttestationService(context.TODO(), &attestation.Config{
+		BeaconDB:                b.db,
+		ReceiveAttestationBuf:   100,
+		BroadcastAttestationBuf: 100,
+	})
+
+	return b.services.RegisterService(attsService)
+}
+
 func (b *BeaconNode) registerPOWChainService(cliCtx *cli.Context) error {
 	if !cliCtx.GlobalBool(utils.EnablePOWChain.Name) {
 		return nil
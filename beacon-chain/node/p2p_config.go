@@ -1,12 +1,19 @@
 package node
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/p2p"
 	"github.com/prysmaticlabs/prysm/shared/p2p/adapter/metric"
 	"github.com/prysmaticlabs/prysm/shared/p2p/adapter/tracer"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/urfave/cli"
 )
 
@@ -22,9 +29,78 @@ var topicMappings = map[pb.Topic]proto.Message{
 	pb.Topic_BEACON_STATE_HASH_ANNOUNCE:          &pb.BeaconStateHashAnnounce{},
 	pb.Topic_BEACON_STATE_REQUEST:                &pb.BeaconStateRequest{},
 	pb.Topic_BEACON_STATE_RESPONSE:               &pb.BeaconStateResponse{},
+	pb.Topic_ATTESTATION_ANNOUNCE:                &pb.AttestationAnnounce{},
+	pb.Topic_ATTESTATION_REQUEST:                 &pb.AttestationRequest{},
+	pb.Topic_ATTESTATION_RESPONSE:                &pb.AttestationResponse{},
+	pb.Topic_PROPOSER_SLASHING_ANNOUNCE:          &pb.ProposerSlashingAnnounce{},
+	pb.Topic_PROPOSER_SLASHING_REQUEST:           &pb.ProposerSlashingRequest{},
+	pb.Topic_PROPOSER_SLASHING_RESPONSE:          &pb.ProposerSlashingResponse{},
+	pb.Topic_ATTESTER_SLASHING_ANNOUNCE:          &pb.AttesterSlashingAnnounce{},
+	pb.Topic_ATTESTER_SLASHING_REQUEST:           &pb.AttesterSlashingRequest{},
+	pb.Topic_ATTESTER_SLASHING_RESPONSE:          &pb.AttesterSlashingResponse{},
+	pb.Topic_EXIT_ANNOUNCE:                       &pb.ExitAnnounce{},
+	pb.Topic_EXIT_REQUEST:                        &pb.ExitRequest{},
+	pb.Topic_EXIT_RESPONSE:                       &pb.ExitResponse{},
+}
+
+// maxSlotDisparity is the number of slots a gossiped block or attestation may be ahead of
+// the current slot, to tolerate the clock differences between nodes.
+const maxSlotDisparity = 1
+
+// topicValidators returns the validators running cheap checks on the messages of topics, so
+// that invalid messages are dropped before they are propagated to other peers.
+//
+// The validators only check the shape and the slot range of messages. Block and attestation
+// signatures are not verified before propagation: they are checked when the block or the
+// attestation is processed.
+func topicValidators(clock *genesisClock) map[pb.Topic]p2p.Validator {
+	return map[pb.Topic]p2p.Validator{
+		pb.Topic_BEACON_BLOCK_ANNOUNCE:      clock.validateBlockAnnounce,
+		pb.Topic_BEACON_BLOCK_RESPONSE:      clock.validateBlockResponse,
+		pb.Topic_ATTESTATION_ANNOUNCE:       validateAnnounce,
+		pb.Topic_ATTESTATION_RESPONSE:       clock.validateAttestationResponse,
+		pb.Topic_PROPOSER_SLASHING_ANNOUNCE: validateAnnounce,
+		pb.Topic_PROPOSER_SLASHING_RESPONSE: validateProposerSlashingResponse,
+		pb.Topic_ATTESTER_SLASHING_ANNOUNCE: validateAnnounce,
+		pb.Topic_ATTESTER_SLASHING_RESPONSE: validateAttesterSlashingResponse,
+		pb.Topic_EXIT_ANNOUNCE:              validateAnnounce,
+		pb.Topic_EXIT_RESPONSE:              validateExitResponse,
+	}
+}
+
+// genesisClock tells the current slot from the genesis time of the chain, which is read
+// from the beacon DB once the chain has started.
+type genesisClock struct {
+	genesisTime func() (time.Time, error)
+	lock        sync.Mutex
+	genesis     time.Time
 }
 
-func configureP2P(ctx *cli.Context) (*p2p.Server, error) {
+// currentSlot returns the current slot, and false if the chain has not started yet.
+func (c *genesisClock) currentSlot() (uint64, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.genesis.IsZero() {
+		genesis, err := c.genesisTime()
+		if err != nil {
+			return 0, false
+		}
+		c.genesis = genesis
+	}
+	return slotutil.CurrentSlot(c.genesis, params.BeaconConfig().SecondsPerSlot, time.Since), true
+}
+
+// isSlotInRange checks that the slot is after genesis, and not ahead of the current slot by
+// more than maxSlotDisparity.
+func (c *genesisClock) isSlotInRange(slot uint64) bool {
+	if slot < params.BeaconConfig().GenesisSlot {
+		return false
+	}
+	currentSlot, started := c.currentSlot()
+	return !started || slot <= currentSlot+maxSlotDisparity
+}
+
+func configureP2P(ctx *cli.Context, beaconDB *db.BeaconDB) (*p2p.Server, error) {
 	s, err := p2p.NewServer(&p2p.ServerConfig{
 		BootstrapNodeAddr: ctx.GlobalString(cmd.BootstrapNode.Name),
 		RelayNodeAddr:     ctx.GlobalString(cmd.RelayNode.Name),
@@ -47,9 +123,66 @@ func configureP2P(ctx *cli.Context) (*p2p.Server, error) {
 		adapters = append(adapters, metric.New())
 	}
 
+	validators := topicValidators(&genesisClock{genesisTime: beaconDB.GenesisTime})
 	for k, v := range topicMappings {
 		s.RegisterTopic(k.String(), v, adapters...)
+		if validator, ok := validators[k]; ok {
+			if err := s.RegisterValidator(v, validator); err != nil {
+				return nil, fmt.Errorf("could not register validator for topic %s: %v", k, err)
+			}
+		}
 	}
 
 	return s, nil
 }
+
+// validateBlockAnnounce checks that a block announcement is for a block root in the slot
+// range.
+func (c *genesisClock) validateBlockAnnounce(msg p2p.Message) bool {
+	announce := msg.Data.(*pb.BeaconBlockAnnounce)
+	return len(announce.Hash) == 32 && c.isSlotInRange(announce.SlotNumber)
+}
+
+// validateBlockResponse checks that a block response holds a complete block in the slot
+// range.
+func (c *genesisClock) validateBlockResponse(msg p2p.Message) bool {
+	block := msg.Data.(*pb.BeaconBlockResponse).Block
+	return block != nil &&
+		block.Body != nil &&
+		len(block.ParentRootHash32) == 32 &&
+		c.isSlotInRange(block.Slot)
+}
+
+// validateAnnounce checks that an operation announcement is for a 32 byte hash.
+func validateAnnounce(msg p2p.Message) bool {
+	announce, ok := msg.Data.(interface{ GetHash() []byte })
+	return ok && len(announce.GetHash()) == 32
+}
+
+// validateAttestationResponse checks that an attestation response holds an attestation
+// for a slot in the slot range.
+func (c *genesisClock) validateAttestationResponse(msg p2p.Message) bool {
+	att := msg.Data.(*pb.AttestationResponse).Attestation
+	return att != nil && att.Data != nil && c.isSlotInRange(att.Data.Slot)
+}
+
+// validateProposerSlashingResponse checks that a proposer slashing response holds both
+// conflicting proposals.
+func validateProposerSlashingResponse(msg p2p.Message) bool {
+	slashing := msg.Data.(*pb.ProposerSlashingResponse).ProposerSlashing
+	return slashing != nil && slashing.ProposalData_1 != nil && slashing.ProposalData_2 != nil
+}
+
+// validateAttesterSlashingResponse checks that an attester slashing response holds both
+// conflicting attestations.
+func validateAttesterSlashingResponse(msg p2p.Message) bool {
+	slashing := msg.Data.(*pb.AttesterSlashingResponse).AttesterSlashing
+	return slashing != nil && slashing.SlashableAttestation_1 != nil && slashing.SlashableAttestation_2 != nil
+}
+
+// validateExitResponse checks that an exit response holds an exit for an epoch after
+// genesis.
+func validateExitResponse(msg p2p.Message) bool {
+	exit := msg.Data.(*pb.ExitResponse).VoluntaryExit
+	return exit != nil && exit.Epoch >= params.BeaconConfig().GenesisEpoch
+}
@@ -0,0 +1,170 @@
+package node
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/p2p"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestTopicValidators(t *testing.T) {
+	genesisSlot := params.BeaconConfig().GenesisSlot
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	// The current slot is genesisSlot + 10.
+	clock := &genesisClock{genesis: time.Now().Add(-10*slotDuration - slotDuration/2)}
+	root := make([]byte, 32)
+	tests := []struct {
+		topic pb.Topic
+		msg   proto.Message
+		valid bool
+	}{
+		{
+			topic: pb.Topic_BEACON_BLOCK_ANNOUNCE,
+			msg:   &pb.BeaconBlockAnnounce{Hash: root, SlotNumber: genesisSlot + 1},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_ANNOUNCE,
+			msg:   &pb.BeaconBlockAnnounce{Hash: []byte("short"), SlotNumber: genesisSlot + 1},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_ANNOUNCE,
+			msg:   &pb.BeaconBlockAnnounce{Hash: root, SlotNumber: 1},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_ANNOUNCE,
+			msg:   &pb.BeaconBlockAnnounce{Hash: root, SlotNumber: genesisSlot + 10 + maxSlotDisparity},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_ANNOUNCE,
+			msg:   &pb.BeaconBlockAnnounce{Hash: root, SlotNumber: genesisSlot + 11 + maxSlotDisparity},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_RESPONSE,
+			msg: &pb.BeaconBlockResponse{Block: &pb.BeaconBlock{
+				Slot:             genesisSlot + 1,
+				ParentRootHash32: root,
+				Body:             &pb.BeaconBlockBody{},
+			}},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_RESPONSE,
+			msg:   &pb.BeaconBlockResponse{},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_RESPONSE,
+			msg:   &pb.BeaconBlockResponse{Block: &pb.BeaconBlock{Slot: genesisSlot + 1, ParentRootHash32: root}},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_RESPONSE,
+			msg: &pb.BeaconBlockResponse{Block: &pb.BeaconBlock{
+				Slot:             1,
+				ParentRootHash32: root,
+				Body:             &pb.BeaconBlockBody{},
+			}},
+		},
+		{
+			topic: pb.Topic_BEACON_BLOCK_RESPONSE,
+			msg: &pb.BeaconBlockResponse{Block: &pb.BeaconBlock{
+				Slot:             genesisSlot + 100,
+				ParentRootHash32: root,
+				Body:             &pb.BeaconBlockBody{},
+			}},
+		},
+		{
+			topic: pb.Topic_ATTESTATION_ANNOUNCE,
+			msg:   &pb.AttestationAnnounce{Hash: root},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_EXIT_ANNOUNCE,
+			msg:   &pb.ExitAnnounce{Hash: []byte("short")},
+		},
+		{
+			topic: pb.Topic_ATTESTATION_RESPONSE,
+			msg: &pb.AttestationResponse{Attestation: &pb.Attestation{
+				Data: &pb.AttestationData{Slot: genesisSlot},
+			}},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_ATTESTATION_RESPONSE,
+			msg:   &pb.AttestationResponse{Attestation: &pb.Attestation{}},
+		},
+		{
+			topic: pb.Topic_ATTESTATION_RESPONSE,
+			msg: &pb.AttestationResponse{Attestation: &pb.Attestation{
+				Data: &pb.AttestationData{Slot: genesisSlot + 100},
+			}},
+		},
+		{
+			topic: pb.Topic_PROPOSER_SLASHING_RESPONSE,
+			msg: &pb.ProposerSlashingResponse{ProposerSlashing: &pb.ProposerSlashing{
+				ProposalData_1: &pb.ProposalSignedData{},
+				ProposalData_2: &pb.ProposalSignedData{},
+			}},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_PROPOSER_SLASHING_RESPONSE,
+			msg: &pb.ProposerSlashingResponse{ProposerSlashing: &pb.ProposerSlashing{
+				ProposalData_1: &pb.ProposalSignedData{},
+			}},
+		},
+		{
+			topic: pb.Topic_ATTESTER_SLASHING_RESPONSE,
+			msg: &pb.AttesterSlashingResponse{AttesterSlashing: &pb.AttesterSlashing{
+				SlashableAttestation_1: &pb.SlashableAttestation{},
+				SlashableAttestation_2: &pb.SlashableAttestation{},
+			}},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_ATTESTER_SLASHING_RESPONSE,
+			msg:   &pb.AttesterSlashingResponse{},
+		},
+		{
+			topic: pb.Topic_EXIT_RESPONSE,
+			msg: &pb.ExitResponse{VoluntaryExit: &pb.VoluntaryExit{
+				Epoch: params.BeaconConfig().GenesisEpoch,
+			}},
+			valid: true,
+		},
+		{
+			topic: pb.Topic_EXIT_RESPONSE,
+			msg:   &pb.ExitResponse{VoluntaryExit: &pb.VoluntaryExit{Epoch: 1}},
+		},
+	}
+	validators := topicValidators(clock)
+	for _, tt := range tests {
+		validator, ok := validators[tt.topic]
+		if !ok {
+			t.Fatalf("No validator for topic %s", tt.topic)
+		}
+		if valid := validator(p2p.Message{Data: tt.msg}); valid != tt.valid {
+			t.Errorf("Expected validity %t for %s message %+v, received %t", tt.valid, tt.topic, tt.msg, valid)
+		}
+	}
+}
+
+func TestTopicValidators_BeforeChainStart(t *testing.T) {
+	clock := &genesisClock{genesisTime: func() (time.Time, error) {
+		return time.Time{}, errors.New("state not found")
+	}}
+	validate := topicValidators(clock)[pb.Topic_BEACON_BLOCK_ANNOUNCE]
+
+	// The slot of a message can not be compared to the current slot before the chain starts.
+	msg := &pb.BeaconBlockAnnounce{Hash: make([]byte, 32), SlotNumber: params.BeaconConfig().GenesisSlot + 100}
+	if !validate(p2p.Message{Data: msg}) {
+		t.Error("Expected a block announcement to be valid before the chain starts")
+	}
+	if _, started := clock.currentSlot(); started {
+		t.Error("Expected the chain not to be started")
+	}
+}
@@ -4,12 +4,12 @@ package operations
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/p2p"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
 )
@@ -19,24 +19,31 @@ var log = logrus.WithField("prefix", "operation")
 // Service represents a service that handles the internal
 // logic of beacon block operations.
 type Service struct {
-	ctx                        context.Context
-	cancel                     context.CancelFunc
-	beaconDB                   *db.BeaconDB
-	incomingExitFeed           *event.Feed
-	incomingValidatorExits     chan *pb.VoluntaryExit
-	incomingAttFeed            *event.Feed
-	incomingAtt                chan *pb.Attestation
-	incomingProcessedBlockFeed *event.Feed
-	incomingProcessedBlock     chan *pb.BeaconBlock
-	error                      error
+	ctx                          context.Context
+	cancel                       context.CancelFunc
+	beaconDB                     *db.BeaconDB
+	p2p                          p2p.Broadcaster
+	incomingExitFeed             *event.Feed
+	incomingValidatorExits       chan *pb.VoluntaryExit
+	incomingAttFeed              *event.Feed
+	incomingAtt                  chan *pb.Attestation
+	incomingProposerSlashingFeed *event.Feed
+	incomingProposerSlashings    chan *pb.ProposerSlashing
+	incomingAttesterSlashingFeed *event.Feed
+	incomingAttesterSlashings    chan *pb.AttesterSlashing
+	incomingProcessedBlockFeed   *event.Feed
+	incomingProcessedBlock       chan *pb.BeaconBlock
+	error                        error
 }
 
 // Config options for the service.
 type Config struct {
-	BeaconDB        *db.BeaconDB
-	ReceiveExitBuf  int
-	ReceiveAttBuf   int
-	ReceiveBlockBuf int
+	BeaconDB           *db.BeaconDB
+	P2P                p2p.Broadcaster
+	ReceiveExitBuf     int
+	ReceiveAttBuf      int
+	ReceiveSlashingBuf int
+	ReceiveBlockBuf    int
 }
 
 // NewOpsPoolService instantiates a new service instance that will
@@ -44,15 +51,20 @@ type Config struct {
 func NewOpsPoolService(ctx context.Context, cfg *Config) *Service {
 	ctx, cancel := context.WithCancel(ctx)
 	return &Service{
-		ctx:                        ctx,
-		cancel:                     cancel,
-		beaconDB:                   cfg.BeaconDB,
-		incomingExitFeed:           new(event.Feed),
-		incomingValidatorExits:     make(chan *pb.VoluntaryExit, cfg.ReceiveExitBuf),
-		incomingAttFeed:            new(event.Feed),
-		incomingAtt:                make(chan *pb.Attestation, cfg.ReceiveAttBuf),
-		incomingProcessedBlockFeed: new(event.Feed),
-		incomingProcessedBlock:     make(chan *pb.BeaconBlock, cfg.ReceiveBlockBuf),
+		ctx:                          ctx,
+		cancel:                       cancel,
+		beaconDB:                     cfg.BeaconDB,
+		p2p:                          cfg.P2P,
+		incomingExitFeed:             new(event.Feed),
+		incomingValidatorExits:       make(chan *pb.VoluntaryExit, cfg.ReceiveExitBuf),
+		incomingAttFeed:              new(event.Feed),
+		incomingAtt:                  make(chan *pb.Attestation, cfg.ReceiveAttBuf),
+		incomingProposerSlashingFeed: new(event.Feed),
+		incomingProposerSlashings:    make(chan *pb.ProposerSlashing, cfg.ReceiveSlashingBuf),
+		incomingAttesterSlashingFeed: new(event.Feed),
+		incomingAttesterSlashings:    make(chan *pb.AttesterSlashing, cfg.ReceiveSlashingBuf),
+		incomingProcessedBlockFeed:   new(event.Feed),
+		incomingProcessedBlock:       make(chan *pb.BeaconBlock, cfg.ReceiveBlockBuf),
 	}
 }
 
@@ -91,6 +103,18 @@ func (s *Service) IncomingAttFeed() *event.Feed {
 	return s.incomingAttFeed
 }
 
+// IncomingProposerSlashingFeed returns a feed that any service can send incoming p2p proposer slashings into.
+// The beacon block operation pool service will subscribe to this feed in order to relay incoming proposer slashings.
+func (s *Service) IncomingProposerSlashingFeed() *event.Feed {
+	return s.incomingProposerSlashingFeed
+}
+
+// IncomingAttesterSlashingFeed returns a feed that any service can send incoming p2p attester slashings into.
+// The beacon block operation pool service will subscribe to this feed in order to relay incoming attester slashings.
+func (s *Service) IncomingAttesterSlashingFeed() *event.Feed {
+	return s.incomingAttesterSlashingFeed
+}
+
 // IncomingProcessedBlockFeed returns a feed that any service can send incoming p2p beacon blocks into.
 // The beacon block operation pool service will subscribe to this feed in order to receive incoming beacon blocks.
 func (s *Service) IncomingProcessedBlockFeed() *event.Feed {
@@ -101,33 +125,28 @@ func (s *Service) IncomingProcessedBlockFeed() *event.Feed {
 // returns in slot ascending order and up to MaxAttestations capacity. The attestations get
 // deleted in DB after they have been retrieved.
 func (s *Service) PendingAttestations() ([]*pb.Attestation, error) {
-	var attestations []*pb.Attestation
-	attestationsFromDB, err := s.beaconDB.Attestations()
+	attestations, err := s.beaconDB.AttestationsFromSlot(0, int(params.BeaconConfig().MaxAttestations))
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve attestations from DB")
 	}
-	sort.Slice(attestationsFromDB, func(i, j int) bool {
-		return attestationsFromDB[i].Data.Slot < attestationsFromDB[j].Data.Slot
-	})
-	for i := range attestationsFromDB {
-		// Stop the max attestation number per beacon block is reached.
-		if uint64(i) == params.BeaconConfig().MaxAttestations {
-			break
-		}
-		attestations = append(attestations, attestationsFromDB[i])
-	}
 	log.Infof("%d Attestations obtained from DB in operations service", len(attestations))
 	return attestations, nil
 }
 
 // saveOperations saves the newly broadcasted beacon block operations
-// that was received from sync service.
+// that was received from sync service or from local validators. New attestations,
+// exits and slashings are announced to peers once saved, which request them if
+// they have not seen them.
 func (s *Service) saveOperations() {
-	// TODO(1438): Add rest of operations (slashings, attestation, exists...etc)
+	// TODO(1438): Add rest of operations (deposits...etc)
 	incomingSub := s.incomingExitFeed.Subscribe(s.incomingValidatorExits)
 	defer incomingSub.Unsubscribe()
 	incomingAttSub := s.incomingAttFeed.Subscribe(s.incomingAtt)
 	defer incomingAttSub.Unsubscribe()
+	incomingProposerSlashingSub := s.incomingProposerSlashingFeed.Subscribe(s.incomingProposerSlashings)
+	defer incomingProposerSlashingSub.Unsubscribe()
+	incomingAttesterSlashingSub := s.incomingAttesterSlashingFeed.Subscribe(s.incomingAttesterSlashings)
+	defer incomingAttesterSlashingSub.Unsubscribe()
 
 	for {
 		select {
@@ -144,22 +163,64 @@ func (s *Service) saveOperations() {
 				log.Errorf("Could not hash exit req proto: %v", err)
 				continue
 			}
+			if s.beaconDB.HasExit(hash) {
+				log.Debugf("Exit request %#x already saved in DB", hash)
+				continue
+			}
 			if err := s.beaconDB.SaveExit(exit); err != nil {
 				log.Errorf("Could not save exit request: %v", err)
 				continue
 			}
 			log.Infof("Exit request %#x saved in DB", hash)
+			s.p2p.Broadcast(&pb.ExitAnnounce{Hash: hash[:]})
 		case attestation := <-s.incomingAtt:
 			hash, err := hashutil.HashProto(attestation)
 			if err != nil {
 				log.Errorf("Could not hash attestation proto: %v", err)
 				continue
 			}
+			if s.beaconDB.HasAttestation(hash) {
+				log.Debugf("Attestation %#x already saved in DB", hash)
+				continue
+			}
 			if err := s.beaconDB.SaveAttestation(attestation); err != nil {
 				log.Errorf("Could not save attestation: %v", err)
 				continue
 			}
 			log.Infof("Attestation %#x saved in DB", hash)
+			s.p2p.Broadcast(&pb.AttestationAnnounce{Hash: hash[:]})
+		case slashing := <-s.incomingProposerSlashings:
+			hash, err := hashutil.HashProto(slashing)
+			if err != nil {
+				log.Errorf("Could not hash proposer slashing proto: %v", err)
+				continue
+			}
+			if s.beaconDB.HasProposerSlashing(hash) {
+				log.Debugf("Proposer slashing %#x already saved in DB", hash)
+				continue
+			}
+			if err := s.beaconDB.SaveProposerSlashing(slashing); err != nil {
+				log.Errorf("Could not save proposer slashing: %v", err)
+				continue
+			}
+			log.Infof("Proposer slashing %#x saved in DB", hash)
+			s.p2p.Broadcast(&pb.ProposerSlashingAnnounce{Hash: hash[:]})
+		case slashing := <-s.incomingAttesterSlashings:
+			hash, err := hashutil.HashProto(slashing)
+			if err != nil {
+				log.Errorf("Could not hash attester slashing proto: %v", err)
+				continue
+			}
+			if s.beaconDB.HasAttesterSlashing(hash) {
+				log.Debugf("Attester slashing %#x already saved in DB", hash)
+				continue
+			}
+			if err := s.beaconDB.SaveAttesterSlashing(slashing); err != nil {
+				log.Errorf("Could not save attester slashing: %v", err)
+				continue
+			}
+			log.Infof("Attester slashing %#x saved in DB", hash)
+			s.p2p.Broadcast(&pb.AttesterSlashingAnnounce{Hash: hash[:]})
 		}
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
@@ -20,6 +21,14 @@ func init() {
 	logrus.SetLevel(logrus.DebugLevel)
 }
 
+type mockBroadcaster struct {
+	broadcasted []proto.Message
+}
+
+func (mb *mockBroadcaster) Broadcast(msg proto.Message) {
+	mb.broadcasted = append(mb.broadcasted, msg)
+}
+
 func TestStop_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	opsService := NewOpsPoolService(context.Background(), &Config{})
@@ -76,7 +85,8 @@ func TestIncomingExits_Ok(t *testing.T) {
 	hook := logTest.NewGlobal()
 	beaconDB := internal.SetupDB(t)
 	defer internal.TeardownDB(t, beaconDB)
-	service := NewOpsPoolService(context.Background(), &Config{BeaconDB: beaconDB})
+	mb := &mockBroadcaster{}
+	service := NewOpsPoolService(context.Background(), &Config{BeaconDB: beaconDB, P2P: mb})
 
 	exitRoutine := make(chan bool)
 	go func() {
@@ -95,13 +105,18 @@ func TestIncomingExits_Ok(t *testing.T) {
 
 	want := fmt.Sprintf("Exit request %#x saved in DB", hash)
 	testutil.AssertLogsContain(t, hook, want)
+	wantBroadcast := []proto.Message{&pb.ExitAnnounce{Hash: hash[:]}}
+	if !reflect.DeepEqual(mb.broadcasted, wantBroadcast) {
+		t.Errorf("Expected the exit to be announced, broadcasted %v", mb.broadcasted)
+	}
 }
 
 func TestIncomingAttestation_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	beaconDB := internal.SetupDB(t)
 	defer internal.TeardownDB(t, beaconDB)
-	service := NewOpsPoolService(context.Background(), &Config{BeaconDB: beaconDB})
+	mb := &mockBroadcaster{}
+	service := NewOpsPoolService(context.Background(), &Config{BeaconDB: beaconDB, P2P: mb})
 
 	exitRoutine := make(chan bool)
 	go func() {
@@ -118,12 +133,64 @@ func TestIncomingAttestation_OK(t *testing.T) {
 		t.Fatalf("Could not hash exit proto: %v", err)
 	}
 
+	service.incomingAtt <- attestation
+	// Attestations which were already saved are not announced again.
 	service.incomingAtt <- attestation
 	service.cancel()
 	exitRoutine <- true
 
 	want := fmt.Sprintf("Attestation %#x saved in DB", hash)
 	testutil.AssertLogsContain(t, hook, want)
+	wantBroadcast := []proto.Message{&pb.AttestationAnnounce{Hash: hash[:]}}
+	if !reflect.DeepEqual(mb.broadcasted, wantBroadcast) {
+		t.Errorf("Expected the attestation to be announced once, broadcasted %v", mb.broadcasted)
+	}
+}
+
+func TestIncomingSlashings_OK(t *testing.T) {
+	hook := logTest.NewGlobal()
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	mb := &mockBroadcaster{}
+	service := NewOpsPoolService(context.Background(), &Config{BeaconDB: beaconDB, P2P: mb})
+
+	exitRoutine := make(chan bool)
+	go func() {
+		service.saveOperations()
+		<-exitRoutine
+	}()
+	proposerSlashing := &pb.ProposerSlashing{ProposerIndex: 5}
+	proposerHash, err := hashutil.HashProto(proposerSlashing)
+	if err != nil {
+		t.Fatalf("Could not hash proposer slashing proto: %v", err)
+	}
+	attesterSlashing := &pb.AttesterSlashing{
+		SlashableAttestation_1: &pb.SlashableAttestation{ValidatorIndices: []uint64{1}},
+	}
+	attesterHash, err := hashutil.HashProto(attesterSlashing)
+	if err != nil {
+		t.Fatalf("Could not hash attester slashing proto: %v", err)
+	}
+
+	service.incomingProposerSlashings <- proposerSlashing
+	service.incomingAttesterSlashings <- attesterSlashing
+	// Slashings which were already saved are not announced again.
+	service.incomingProposerSlashings <- proposerSlashing
+	service.cancel()
+	exitRoutine <- true
+
+	testutil.AssertLogsContain(t, hook, fmt.Sprintf("Proposer slashing %#x saved in DB", proposerHash))
+	testutil.AssertLogsContain(t, hook, fmt.Sprintf("Attester slashing %#x saved in DB", attesterHash))
+	if !beaconDB.HasProposerSlashing(proposerHash) || !beaconDB.HasAttesterSlashing(attesterHash) {
+		t.Error("Expected the slashings to be saved in DB")
+	}
+	wantBroadcast := []proto.Message{
+		&pb.ProposerSlashingAnnounce{Hash: proposerHash[:]},
+		&pb.AttesterSlashingAnnounce{Hash: attesterHash[:]},
+	}
+	if !reflect.DeepEqual(mb.broadcasted, wantBroadcast) {
+		t.Errorf("Expected the slashings to be announced once, broadcasted %v", mb.broadcasted)
+	}
 }
 
 func TestRetrieveAttestations_OK(t *testing.T) {
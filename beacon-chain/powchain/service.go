@@ -290,7 +290,8 @@ func (w *Web3Service) ProcessDepositLog(depositLog gethTypes.Log) {
 		return
 	}
 	deposit := &pb.Deposit{
-		DepositData: depositData,
+		DepositData:     depositData,
+		MerkleTreeIndex: index,
 	}
 	// If chain has not started, do not update the merkle trie
 	if !w.chainStarted {
@@ -361,6 +362,12 @@ func (w *Web3Service) run(done <-chan struct{}) {
 		return
 	}
 
+	restored, err := w.restoreDepositTrie()
+	if err != nil {
+		log.Errorf("Unable to restore deposit trie %v", err)
+		return
+	}
+
 	headSub, err := w.reader.SubscribeNewHead(w.ctx, w.headerChan)
 	if err != nil {
 		log.Errorf("Unable to subscribe to incoming ETH1.0 chain headers: %v", err)
@@ -376,8 +383,9 @@ func (w *Web3Service) run(done <-chan struct{}) {
 	w.blockHeight = header.Number
 	w.blockHash = header.Hash()
 
-	// Only process logs if the chain start delay flag is not enabled.
-	if w.chainStartDelay == 0 {
+	// Only process logs if the chain start delay flag is not enabled. When the deposit trie
+	// was restored, the logs after the last processed block are requested in batches instead.
+	if w.chainStartDelay == 0 && !restored {
 		if err := w.processPastLogs(); err != nil {
 			log.Errorf("Unable to process past logs %v", err)
 			return
@@ -428,6 +436,42 @@ func (w *Web3Service) initDataFromContract() error {
 	return nil
 }
 
+// restoreDepositTrie resumes from the deposit trie and the last processed ETH1.0 block
+// saved in the DB, so the deposit logs are not processed again after a restart. The
+// deposit trie is only saved once the chain has started. Returns true if a deposit
+// trie was restored.
+func (w *Web3Service) restoreDepositTrie() (bool, error) {
+	trie, lastProcessedBlock, err := w.beaconDB.DepositTrie(w.ctx)
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve deposit trie %v", err)
+	}
+	if trie == nil {
+		return false, nil
+	}
+	w.depositTrie = trie
+	w.lastRequestedBlock.Set(lastProcessedBlock)
+	w.lastReceivedMerkleIndex = int64(trie.DepositCount()) - 1
+	w.chainStarted = true
+	log.WithFields(logrus.Fields{
+		"depositCount":       trie.DepositCount(),
+		"lastProcessedBlock": lastProcessedBlock,
+	}).Info("Resuming from the deposit trie saved in the DB")
+	return true, nil
+}
+
+// saveDepositTrie saves the deposit trie and the last processed ETH1.0 block in the DB.
+// Before the chain starts, the deposit logs are processed again on restart to gather the
+// chain start deposits, so nothing is saved.
+func (w *Web3Service) saveDepositTrie() error {
+	if !w.chainStarted {
+		return nil
+	}
+	if err := w.beaconDB.SaveDepositTrie(w.ctx, w.depositTrie, w.lastRequestedBlock); err != nil {
+		return fmt.Errorf("could not save deposit trie %v", err)
+	}
+	return nil
+}
+
 // saveInTrie saves in the in-memory deposit trie.
 func (w *Web3Service) saveInTrie(depositData []byte, merkleRoot common.Hash) error {
 	w.depositTrie.UpdateDepositTrie(depositData)
@@ -455,7 +499,7 @@ func (w *Web3Service) processPastLogs() error {
 		w.ProcessLog(log)
 	}
 	w.lastRequestedBlock.Set(w.blockHeight)
-	return nil
+	return w.saveDepositTrie()
 }
 
 // requestBatchedLogs requests and processes all the logs from the period
@@ -485,5 +529,5 @@ func (w *Web3Service) requestBatchedLogs() error {
 	}
 
 	w.lastRequestedBlock.Set(requestedBlock)
-	return nil
+	return w.saveDepositTrie()
 }
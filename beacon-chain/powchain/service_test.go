@@ -21,7 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -208,6 +208,8 @@ func TestStart_OK(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to set up simulated backend %v", err)
 	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
 	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
 		Endpoint:        endpoint,
 		DepositContract: testAcc.contractAddr,
@@ -215,6 +217,7 @@ func TestStart_OK(t *testing.T) {
 		Logger:          &goodLogger{},
 		BlockFetcher:    &goodFetcher{},
 		ContractBackend: testAcc.backend,
+		BeaconDB:        beaconDB,
 	})
 	if err != nil {
 		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
@@ -352,12 +355,15 @@ func TestWeb3Service_BadReader(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to set up simulated backend %v", err)
 	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
 	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
 		Endpoint:        endpoint,
 		DepositContract: testAcc.contractAddr,
 		Reader:          &badReader{},
 		Logger:          &goodLogger{},
 		ContractBackend: testAcc.backend,
+		BeaconDB:        beaconDB,
 	})
 	if err != nil {
 		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
@@ -381,6 +387,8 @@ func TestLatestMainchainInfo_OK(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to set up simulated backend %v", err)
 	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
 	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
 		Endpoint:        endpoint,
 		DepositContract: testAcc.contractAddr,
@@ -388,6 +396,7 @@ func TestLatestMainchainInfo_OK(t *testing.T) {
 		Logger:          &goodLogger{},
 		BlockFetcher:    &goodFetcher{},
 		ContractBackend: testAcc.backend,
+		BeaconDB:        beaconDB,
 	})
 	if err != nil {
 		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
@@ -488,13 +497,15 @@ func TestProcessDepositLog_InsertsPendingDeposit(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to set up simulated backend %v", err)
 	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
 	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
 		Endpoint:        endpoint,
 		DepositContract: testAcc.contractAddr,
 		Reader:          &goodReader{},
 		Logger:          &goodLogger{},
 		ContractBackend: testAcc.backend,
-		BeaconDB:        &db.BeaconDB{},
+		BeaconDB:        beaconDB,
 	})
 	if err != nil {
 		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
@@ -551,13 +562,15 @@ func TestProcessDepositLog_SkipDuplicateLog(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to set up simulated backend %v", err)
 	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
 	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
 		Endpoint:        endpoint,
 		DepositContract: testAcc.contractAddr,
 		Reader:          &goodReader{},
 		Logger:          &goodLogger{},
 		ContractBackend: testAcc.backend,
-		BeaconDB:        &db.BeaconDB{},
+		BeaconDB:        beaconDB,
 	})
 	if err != nil {
 		t.Fatalf("Unable to setup web3 ETH1.0 chain service: %v", err)
@@ -990,3 +1003,73 @@ func TestBlockExists_InvalidHash(t *testing.T) {
 		t.Fatal("Expected BlockExists to error with invalid hash")
 	}
 }
+
+func TestRestoreDepositTrie_ResumesFromSavedTrie(t *testing.T) {
+	endpoint := "ws://127.0.0.1"
+	testAcc, err := setup()
+	if err != nil {
+		t.Fatalf("Unable to set up simulated backend %v", err)
+	}
+	beaconDB := internal.SetupDB(t)
+	defer internal.TeardownDB(t, beaconDB)
+	web3Service, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
+		Endpoint:        endpoint,
+		DepositContract: testAcc.contractAddr,
+		Reader:          &goodReader{},
+		Logger:          &goodLogger{},
+		ContractBackend: testAcc.backend,
+		BeaconDB:        beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("Unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+
+	// Nothing is saved before the chain starts.
+	web3Service.depositTrie = trieutil.NewDepositTrie()
+	web3Service.depositTrie.UpdateDepositTrie([]byte{'A'})
+	web3Service.depositTrie.UpdateDepositTrie([]byte{'B'})
+	web3Service.lastRequestedBlock.SetInt64(100)
+	if err := web3Service.saveDepositTrie(); err != nil {
+		t.Fatalf("Could not save deposit trie: %v", err)
+	}
+	if restored, err := web3Service.restoreDepositTrie(); err != nil || restored {
+		t.Fatalf("Expected no deposit trie to be restored, received %v, %v", restored, err)
+	}
+
+	web3Service.chainStarted = true
+	if err := web3Service.saveDepositTrie(); err != nil {
+		t.Fatalf("Could not save deposit trie: %v", err)
+	}
+	root := web3Service.depositTrie.Root()
+
+	restarted, err := NewWeb3Service(context.Background(), &Web3ServiceConfig{
+		Endpoint:        endpoint,
+		DepositContract: testAcc.contractAddr,
+		Reader:          &goodReader{},
+		Logger:          &goodLogger{},
+		ContractBackend: testAcc.backend,
+		BeaconDB:        beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("Unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	restored, err := restarted.restoreDepositTrie()
+	if err != nil {
+		t.Fatalf("Could not restore deposit trie: %v", err)
+	}
+	if !restored {
+		t.Fatal("Expected the deposit trie to be restored")
+	}
+	if restarted.depositTrie.Root() != root {
+		t.Errorf("Expected deposit root %#x, received %#x", root, restarted.depositTrie.Root())
+	}
+	if restarted.lastRequestedBlock.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected to resume from block 100, received %v", restarted.lastRequestedBlock)
+	}
+	if restarted.lastReceivedMerkleIndex != 1 {
+		t.Errorf("Expected last received Merkle index 1, received %d", restarted.lastReceivedMerkleIndex)
+	}
+	if !restarted.chainStarted {
+		t.Error("Expected the chain to be started")
+	}
+}
@@ -8,8 +8,14 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// maxValidatorIndicesKeys is the most public keys a validator client can look up in
+// a single ValidatorIndices request.
+const maxValidatorIndicesKeys = 1024
+
 // ValidatorServer defines a server implementation of the gRPC Validator service,
 // providing RPC endpoints for obtaining validator assignments per epoch, the slots
 // and shards in which particular validators need to perform their responsibilities,
@@ -29,6 +35,24 @@ func (vs *ValidatorServer) ValidatorIndex(ctx context.Context, req *pb.Validator
 	return &pb.ValidatorIndexResponse{Index: uint64(index)}, nil
 }
 
+// ValidatorIndices is called by a validator client to get the indices of all the validators
+// it runs in a single request. The indices are in the order of the public keys.
+func (vs *ValidatorServer) ValidatorIndices(ctx context.Context, req *pb.ValidatorIndicesRequest) (*pb.ValidatorIndicesResponse, error) {
+	if len(req.PublicKeys) > maxValidatorIndicesKeys {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"requested %d public keys, at most %d can be requested", len(req.PublicKeys), maxValidatorIndicesKeys)
+	}
+	indices, err := vs.beaconDB.ValidatorIndices(req.PublicKeys)
+	if err != nil {
+		if notFound, ok := err.(*db.ValidatorNotFoundError); ok {
+			return nil, status.Errorf(codes.NotFound, "validator %#x does not exist", notFound.PubKey)
+		}
+		return nil, fmt.Errorf("could not get validator indices: %v", err)
+	}
+
+	return &pb.ValidatorIndicesResponse{Indices: indices}, nil
+}
+
 // ValidatorEpochAssignments fetches an assignment object for a validator by public key
 // such as the slot the validator needs to attest in during the epoch as well as a slot
 // in which the validator may need to propose during the epoch in addition to the assigned shard.
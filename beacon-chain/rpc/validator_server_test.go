@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -16,6 +17,8 @@ import (
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestValidatorIndex_OK(t *testing.T) {
@@ -39,6 +42,75 @@ func TestValidatorIndex_OK(t *testing.T) {
 	}
 }
 
+func TestValidatorIndices_OK(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	pubKeys := [][]byte{{'A'}, {'B'}, {'C'}}
+	for i, pubKey := range pubKeys {
+		if err := db.SaveValidatorIndex(pubKey, i); err != nil {
+			t.Fatalf("Could not save validator index: %v", err)
+		}
+	}
+
+	validatorServer := &ValidatorServer{
+		beaconDB: db,
+	}
+
+	req := &pb.ValidatorIndicesRequest{
+		PublicKeys: [][]byte{pubKeys[2], pubKeys[0]},
+	}
+	res, err := validatorServer.ValidatorIndices(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Could not get validator indices: %v", err)
+	}
+	if !reflect.DeepEqual(res.Indices, []uint64{2, 0}) {
+		t.Errorf("Expected indices [2 0] in the order of the public keys, received %v", res.Indices)
+	}
+}
+
+func TestValidatorIndices_UnknownValidator(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	if err := db.SaveValidatorIndex([]byte{'A'}, 0); err != nil {
+		t.Fatalf("Could not save validator index: %v", err)
+	}
+
+	validatorServer := &ValidatorServer{
+		beaconDB: db,
+	}
+
+	req := &pb.ValidatorIndicesRequest{
+		PublicKeys: [][]byte{{'A'}, {'B'}},
+	}
+	_, err := validatorServer.ValidatorIndices(context.Background(), req)
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.NotFound {
+		t.Fatalf("Expected a not found error, received %v", err)
+	}
+	want := fmt.Sprintf("validator %#x does not exist", []byte{'B'})
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %q, received %v", want, err)
+	}
+}
+
+func TestValidatorIndices_TooManyKeys(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	validatorServer := &ValidatorServer{
+		beaconDB: db,
+	}
+
+	req := &pb.ValidatorIndicesRequest{
+		PublicKeys: make([][]byte, maxValidatorIndicesKeys+1),
+	}
+	_, err := validatorServer.ValidatorIndices(context.Background(), req)
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected an invalid argument error, received %v", err)
+	}
+}
+
 func TestValidatorEpochAssignments_OK(t *testing.T) {
 	db := internal.SetupDB(t)
 	defer internal.TeardownDB(t, db)
@@ -63,6 +63,7 @@ type p2pAPI interface {
 	Subscribe(msg proto.Message, channel chan p2p.Message) event.Subscription
 	Send(msg proto.Message, peer p2p.Peer)
 	Broadcast(msg proto.Message)
+	Request(ctx context.Context, peer p2p.Peer, req proto.Message, resp proto.Message) error
 }
 
 type chainService interface {
@@ -96,6 +97,7 @@ type InitialSync struct {
 	syncedFeed                     *event.Feed
 	atGenesis                      bool
 	stateRootOfHighestObservedSlot [32]byte
+	syncPeer                       p2p.Peer
 	mutex                          *sync.Mutex
 }
 
@@ -173,6 +175,11 @@ func (s *InitialSync) InitializeStateRoot(root [32]byte) {
 	s.stateRootOfHighestObservedSlot = root
 }
 
+// InitializeSyncPeer sets the peer which blocks are requested from.
+func (s *InitialSync) InitializeSyncPeer(peer p2p.Peer) {
+	s.syncPeer = peer
+}
+
 // SyncedFeed returns a feed which fires a message once the node is synced
 func (s *InitialSync) SyncedFeed() *event.Feed {
 	return s.syncedFeed
@@ -184,15 +191,12 @@ func (s *InitialSync) SyncedFeed() *event.Feed {
 func (s *InitialSync) run(delayChan <-chan time.Time) {
 
 	blockSub := s.p2p.Subscribe(&pb.BeaconBlockResponse{}, s.blockBuf)
-	batchedBlocksub := s.p2p.Subscribe(&pb.BatchedBeaconBlockResponse{}, s.batchedBlockBuf)
 	blockAnnounceSub := s.p2p.Subscribe(&pb.BeaconBlockAnnounce{}, s.blockAnnounceBuf)
 	beaconStateSub := s.p2p.Subscribe(&pb.BeaconStateResponse{}, s.stateBuf)
 	defer func() {
 		blockSub.Unsubscribe()
 		blockAnnounceSub.Unsubscribe()
 		beaconStateSub.Unsubscribe()
-		batchedBlocksub.Unsubscribe()
-		close(s.batchedBlockBuf)
 		close(s.blockBuf)
 		close(s.stateBuf)
 	}()
@@ -233,6 +237,7 @@ func (s *InitialSync) run(delayChan <-chan time.Time) {
 
 			if data.SlotNumber > s.highestObservedSlot {
 				s.highestObservedSlot = data.SlotNumber
+				s.syncPeer = msg.Peer
 			}
 
 			s.requestBatchedBlocks(s.currentSlot+1, s.highestObservedSlot)
@@ -355,7 +360,7 @@ func (s *InitialSync) requestStateFromPeer(stateRoot []byte, peer p2p.Peer) erro
 	return nil
 }
 
-// requestNextBlock broadcasts a request for a block with the entered slotnumber.
+// requestNextBlockBySlot requests the block with the entered slot number from the sync peer.
 func (s *InitialSync) requestNextBlockBySlot(slotNumber uint64) {
 	log.Debugf("Requesting block %d ", slotNumber)
 	s.mutex.Lock()
@@ -364,21 +369,33 @@ func (s *InitialSync) requestNextBlockBySlot(slotNumber uint64) {
 		s.processBlock(block, p2p.Peer{})
 		return
 	}
-	s.p2p.Broadcast(&pb.BeaconBlockRequestBySlotNumber{SlotNumber: slotNumber})
+	s.requestBatchedBlocks(slotNumber, slotNumber)
 }
 
-// requestBatchedBlocks sends out a request for multiple blocks till a
-// specified bound slot number.
+// requestBatchedBlocks requests multiple blocks till a specified bound slot number from
+// the sync peer. The response is processed by the main routine.
 func (s *InitialSync) requestBatchedBlocks(startSlot uint64, endSlot uint64) {
 	blockLimit := params.BeaconConfig().BatchBlockLimit
 	if startSlot+blockLimit < endSlot {
 		endSlot = startSlot + blockLimit
 	}
 	log.Debugf("Requesting batched blocks from slot %d to %d", startSlot, endSlot)
-	s.p2p.Broadcast(&pb.BatchedBeaconBlockRequest{
+	req := &pb.BatchedBeaconBlockRequest{
 		StartSlot: startSlot,
 		EndSlot:   endSlot,
-	})
+	}
+	peer := s.syncPeer
+	go func() {
+		resp := &pb.BatchedBeaconBlockResponse{}
+		if err := s.p2p.Request(s.ctx, peer, req, resp); err != nil {
+			log.Errorf("Could not request batched blocks from peer: %v", err)
+			return
+		}
+		select {
+		case s.batchedBlockBuf <- p2p.Message{Ctx: s.ctx, Peer: peer, Data: resp}:
+		case <-s.ctx.Done():
+		}
+	}()
 }
 
 // validateAndSaveNextBlock will validate whether blocks received from the blockfetcher
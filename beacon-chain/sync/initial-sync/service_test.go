@@ -21,6 +21,8 @@ import (
 )
 
 type mockP2P struct {
+	requestedPeer p2p.Peer
+	blocks        []*pb.BeaconBlock
 }
 
 func (mp *mockP2P) Subscribe(msg proto.Message, channel chan p2p.Message) event.Subscription {
@@ -32,6 +34,14 @@ func (mp *mockP2P) Broadcast(msg proto.Message) {}
 func (mp *mockP2P) Send(msg proto.Message, peer p2p.Peer) {
 }
 
+func (mp *mockP2P) Request(ctx context.Context, peer p2p.Peer, req proto.Message, resp proto.Message) error {
+	mp.requestedPeer = peer
+	if batched, ok := resp.(*pb.BatchedBeaconBlockResponse); ok {
+		batched.BatchedBlocks = mp.blocks
+	}
+	return nil
+}
+
 type mockSyncService struct {
 	hasStarted bool
 	isSynced   bool
@@ -385,3 +395,27 @@ func TestRequestBlocksBySlot_OK(t *testing.T) {
 
 	hook.Reset()
 }
+
+func TestRequestBatchedBlocks_RequestsFromSyncPeer(t *testing.T) {
+	block := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot + 1}
+	mp := &mockP2P{blocks: []*pb.BeaconBlock{block}}
+	cfg := &Config{
+		P2P:                    mp,
+		BatchedBlockBufferSize: 1,
+	}
+	ss := NewInitialSyncService(context.Background(), cfg)
+	defer ss.Stop()
+
+	syncPeer := p2p.Peer{ID: "peer"}
+	ss.InitializeSyncPeer(syncPeer)
+	ss.requestBatchedBlocks(block.Slot, block.Slot)
+
+	msg := <-ss.batchedBlockBuf
+	if mp.requestedPeer != syncPeer || msg.Peer != syncPeer {
+		t.Errorf("Expected the blocks to be requested from %v, requested from %v", syncPeer, mp.requestedPeer)
+	}
+	resp := msg.Data.(*pb.BatchedBeaconBlockResponse)
+	if len(resp.BatchedBlocks) != 1 || !proto.Equal(resp.BatchedBlocks[0], block) {
+		t.Errorf("Unexpected batched blocks: %v", resp.BatchedBlocks)
+	}
+}
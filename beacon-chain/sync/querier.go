@@ -49,6 +49,7 @@ type Querier struct {
 	currentHeadSlot  uint64
 	currentHeadHash  []byte
 	currentStateRoot [32]byte
+	currentHeadPeer  p2p.Peer
 	responseBuf      chan p2p.Message
 	chainStartBuf    chan time.Time
 	powchain         powChainService
@@ -157,6 +158,7 @@ func (q *Querier) run() {
 			q.currentHeadSlot = response.Slot
 			q.currentHeadHash = response.Hash
 			q.currentStateRoot = bytesutil.ToBytes32(response.Block.StateRootHash32)
+			q.currentHeadPeer = msg.Peer
 
 			ticker.Stop()
 			responseSub.Unsubscribe()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -22,19 +23,30 @@ var log = logrus.WithField("prefix", "regular-sync")
 type chainService interface {
 	IncomingBlockFeed() *event.Feed
 	StateInitializedFeed() *event.Feed
+	BlockRequestFeed() *event.Feed
+	InvalidBlockFeed() *event.Feed
 }
 
 type operationService interface {
 	IncomingExitFeed() *event.Feed
 	IncomingAttFeed() *event.Feed
+	IncomingProposerSlashingFeed() *event.Feed
+	IncomingAttesterSlashingFeed() *event.Feed
 }
 
 type p2pAPI interface {
 	Subscribe(msg proto.Message, channel chan p2p.Message) event.Subscription
 	Send(msg proto.Message, peer p2p.Peer)
 	Broadcast(msg proto.Message)
+	ReportPeer(peer p2p.Peer, behavior p2p.PeerBehavior)
+	RegisterRequestHandler(request proto.Message, response proto.Message, handler p2p.RequestHandler)
+	Request(ctx context.Context, peer p2p.Peer, req proto.Message, resp proto.Message) error
 }
 
+// maxBlockSenders is the number of received blocks whose sender is remembered, so that
+// the sender can be penalized if the block turns out to be invalid.
+const maxBlockSenders = 1024
+
 // RegularSync is the gateway and the bridge between the p2p network and the local beacon chain.
 // In broad terms, a new block is synced in 4 steps:
 //     1. Receive a block hash from a peer
@@ -48,59 +60,94 @@ type p2pAPI interface {
 //     *  Drop peers that send invalid data
 //     *  Throttle incoming requests
 type RegularSync struct {
-	ctx                      context.Context
-	cancel                   context.CancelFunc
-	p2p                      p2pAPI
-	chainService             chainService
-	operationsService        operationService
-	db                       *db.BeaconDB
-	blockAnnouncementFeed    *event.Feed
-	announceBlockBuf         chan p2p.Message
-	blockBuf                 chan p2p.Message
-	blockRequestBySlot       chan p2p.Message
-	blockRequestByHash       chan p2p.Message
-	batchedRequestBuf        chan p2p.Message
-	stateRequestBuf          chan p2p.Message
-	chainHeadReqBuf          chan p2p.Message
-	attestationBuf           chan p2p.Message
-	attestationReqByHashBuf  chan p2p.Message
-	unseenAttestationsReqBuf chan p2p.Message
-	exitBuf                  chan p2p.Message
+	ctx                         context.Context
+	cancel                      context.CancelFunc
+	p2p                         p2pAPI
+	chainService                chainService
+	operationsService           operationService
+	db                          *db.BeaconDB
+	blockAnnouncementFeed       *event.Feed
+	announceBlockBuf            chan p2p.Message
+	blockBuf                    chan p2p.Message
+	blockRequestBySlot          chan p2p.Message
+	blockRequestByHash          chan p2p.Message
+	batchedRequestBuf           chan p2p.Message
+	stateRequestBuf             chan p2p.Message
+	chainHeadReqBuf             chan p2p.Message
+	attestationAnnounceBuf      chan p2p.Message
+	attestationBuf              chan p2p.Message
+	attestationReqByHashBuf     chan p2p.Message
+	unseenAttestationsReqBuf    chan p2p.Message
+	exitAnnounceBuf             chan p2p.Message
+	exitReqBuf                  chan p2p.Message
+	exitBuf                     chan p2p.Message
+	proposerSlashingAnnounceBuf chan p2p.Message
+	proposerSlashingReqBuf      chan p2p.Message
+	proposerSlashingBuf         chan p2p.Message
+	attesterSlashingAnnounceBuf chan p2p.Message
+	attesterSlashingReqBuf      chan p2p.Message
+	attesterSlashingBuf         chan p2p.Message
+	missingParentBuf            chan [32]byte
+	invalidBlockBuf             chan *blockchain.InvalidBlockEvent
+	blockSenders                map[[32]byte]p2p.Peer
+	blockSenderRoots            [][32]byte
 }
 
 // RegularSyncConfig allows the channel's buffer sizes to be changed.
 type RegularSyncConfig struct {
-	BlockAnnounceBufferSize      int
-	BlockBufferSize              int
-	BlockReqSlotBufferSize       int
-	BlockReqHashBufferSize       int
-	BatchedBufferSize            int
-	StateReqBufferSize           int
-	AttestationBufferSize        int
-	AttestationReqHashBufSize    int
-	UnseenAttestationsReqBufSize int
-	ExitBufferSize               int
-	ChainHeadReqBufferSize       int
-	ChainService                 chainService
-	OperationService             operationService
-	BeaconDB                     *db.BeaconDB
-	P2P                          p2pAPI
+	BlockAnnounceBufferSize         int
+	BlockBufferSize                 int
+	BlockReqSlotBufferSize          int
+	BlockReqHashBufferSize          int
+	BatchedBufferSize               int
+	StateReqBufferSize              int
+	AttestationAnnounceBufSize      int
+	AttestationBufferSize           int
+	AttestationReqHashBufSize       int
+	UnseenAttestationsReqBufSize    int
+	ExitAnnounceBufSize             int
+	ExitReqBufSize                  int
+	ExitBufferSize                  int
+	ProposerSlashingAnnounceBufSize int
+	ProposerSlashingReqBufSize      int
+	ProposerSlashingBufferSize      int
+	AttesterSlashingAnnounceBufSize int
+	AttesterSlashingReqBufSize      int
+	AttesterSlashingBufferSize      int
+	ChainHeadReqBufferSize          int
+	MissingParentBufferSize         int
+	InvalidBlockBufferSize          int
+	ChainService                    chainService
+	OperationService                operationService
+	BeaconDB                        *db.BeaconDB
+	P2P                             p2pAPI
 }
 
 // DefaultRegularSyncConfig provides the default configuration for a sync service.
 func DefaultRegularSyncConfig() *RegularSyncConfig {
 	return &RegularSyncConfig{
-		BlockAnnounceBufferSize:      100,
-		BlockBufferSize:              100,
-		BlockReqSlotBufferSize:       100,
-		BlockReqHashBufferSize:       100,
-		BatchedBufferSize:            100,
-		StateReqBufferSize:           100,
-		ChainHeadReqBufferSize:       100,
-		AttestationBufferSize:        100,
-		AttestationReqHashBufSize:    100,
-		UnseenAttestationsReqBufSize: 100,
-		ExitBufferSize:               100,
+		BlockAnnounceBufferSize:         100,
+		BlockBufferSize:                 100,
+		BlockReqSlotBufferSize:          100,
+		BlockReqHashBufferSize:          100,
+		BatchedBufferSize:               100,
+		StateReqBufferSize:              100,
+		ChainHeadReqBufferSize:          100,
+		AttestationAnnounceBufSize:      100,
+		AttestationBufferSize:           100,
+		AttestationReqHashBufSize:       100,
+		UnseenAttestationsReqBufSize:    100,
+		ExitAnnounceBufSize:             100,
+		ExitReqBufSize:                  100,
+		ExitBufferSize:                  100,
+		ProposerSlashingAnnounceBufSize: 100,
+		ProposerSlashingReqBufSize:      100,
+		ProposerSlashingBufferSize:      100,
+		AttesterSlashingAnnounceBufSize: 100,
+		AttesterSlashingReqBufSize:      100,
+		AttesterSlashingBufferSize:      100,
+		MissingParentBufferSize:         100,
+		InvalidBlockBufferSize:          100,
 	}
 }
 
@@ -108,24 +155,36 @@ func DefaultRegularSyncConfig() *RegularSyncConfig {
 func NewRegularSyncService(ctx context.Context, cfg *RegularSyncConfig) *RegularSync {
 	ctx, cancel := context.WithCancel(ctx)
 	return &RegularSync{
-		ctx:                      ctx,
-		cancel:                   cancel,
-		p2p:                      cfg.P2P,
-		chainService:             cfg.ChainService,
-		db:                       cfg.BeaconDB,
-		operationsService:        cfg.OperationService,
-		blockAnnouncementFeed:    new(event.Feed),
-		announceBlockBuf:         make(chan p2p.Message, cfg.BlockAnnounceBufferSize),
-		blockBuf:                 make(chan p2p.Message, cfg.BlockBufferSize),
-		blockRequestBySlot:       make(chan p2p.Message, cfg.BlockReqSlotBufferSize),
-		blockRequestByHash:       make(chan p2p.Message, cfg.BlockReqHashBufferSize),
-		batchedRequestBuf:        make(chan p2p.Message, cfg.BatchedBufferSize),
-		stateRequestBuf:          make(chan p2p.Message, cfg.StateReqBufferSize),
-		attestationBuf:           make(chan p2p.Message, cfg.AttestationBufferSize),
-		attestationReqByHashBuf:  make(chan p2p.Message, cfg.AttestationReqHashBufSize),
-		unseenAttestationsReqBuf: make(chan p2p.Message, cfg.UnseenAttestationsReqBufSize),
-		exitBuf:                  make(chan p2p.Message, cfg.ExitBufferSize),
-		chainHeadReqBuf:          make(chan p2p.Message, cfg.ChainHeadReqBufferSize),
+		ctx:                         ctx,
+		cancel:                      cancel,
+		p2p:                         cfg.P2P,
+		chainService:                cfg.ChainService,
+		db:                          cfg.BeaconDB,
+		operationsService:           cfg.OperationService,
+		blockAnnouncementFeed:       new(event.Feed),
+		announceBlockBuf:            make(chan p2p.Message, cfg.BlockAnnounceBufferSize),
+		blockBuf:                    make(chan p2p.Message, cfg.BlockBufferSize),
+		blockRequestBySlot:          make(chan p2p.Message, cfg.BlockReqSlotBufferSize),
+		blockRequestByHash:          make(chan p2p.Message, cfg.BlockReqHashBufferSize),
+		batchedRequestBuf:           make(chan p2p.Message, cfg.BatchedBufferSize),
+		stateRequestBuf:             make(chan p2p.Message, cfg.StateReqBufferSize),
+		attestationAnnounceBuf:      make(chan p2p.Message, cfg.AttestationAnnounceBufSize),
+		attestationBuf:              make(chan p2p.Message, cfg.AttestationBufferSize),
+		attestationReqByHashBuf:     make(chan p2p.Message, cfg.AttestationReqHashBufSize),
+		unseenAttestationsReqBuf:    make(chan p2p.Message, cfg.UnseenAttestationsReqBufSize),
+		exitAnnounceBuf:             make(chan p2p.Message, cfg.ExitAnnounceBufSize),
+		exitReqBuf:                  make(chan p2p.Message, cfg.ExitReqBufSize),
+		exitBuf:                     make(chan p2p.Message, cfg.ExitBufferSize),
+		proposerSlashingAnnounceBuf: make(chan p2p.Message, cfg.ProposerSlashingAnnounceBufSize),
+		proposerSlashingReqBuf:      make(chan p2p.Message, cfg.ProposerSlashingReqBufSize),
+		proposerSlashingBuf:         make(chan p2p.Message, cfg.ProposerSlashingBufferSize),
+		attesterSlashingAnnounceBuf: make(chan p2p.Message, cfg.AttesterSlashingAnnounceBufSize),
+		attesterSlashingReqBuf:      make(chan p2p.Message, cfg.AttesterSlashingReqBufSize),
+		attesterSlashingBuf:         make(chan p2p.Message, cfg.AttesterSlashingBufferSize),
+		chainHeadReqBuf:             make(chan p2p.Message, cfg.ChainHeadReqBufferSize),
+		missingParentBuf:            make(chan [32]byte, cfg.MissingParentBufferSize),
+		invalidBlockBuf:             make(chan *blockchain.InvalidBlockEvent, cfg.InvalidBlockBufferSize),
+		blockSenders:                make(map[[32]byte]p2p.Peer),
 	}
 }
 
@@ -154,17 +213,30 @@ func (rs *RegularSync) BlockAnnouncementFeed() *event.Feed {
 
 // run handles incoming block sync.
 func (rs *RegularSync) run() {
+	rs.registerRequestHandlers()
+
 	announceBlockSub := rs.p2p.Subscribe(&pb.BeaconBlockAnnounce{}, rs.announceBlockBuf)
 	blockSub := rs.p2p.Subscribe(&pb.BeaconBlockResponse{}, rs.blockBuf)
 	blockRequestSub := rs.p2p.Subscribe(&pb.BeaconBlockRequestBySlotNumber{}, rs.blockRequestBySlot)
 	blockRequestHashSub := rs.p2p.Subscribe(&pb.BeaconBlockRequest{}, rs.blockRequestByHash)
 	batchedBlockRequestSub := rs.p2p.Subscribe(&pb.BatchedBeaconBlockRequest{}, rs.batchedRequestBuf)
 	stateRequestSub := rs.p2p.Subscribe(&pb.BeaconStateRequest{}, rs.stateRequestBuf)
+	attestationAnnounceSub := rs.p2p.Subscribe(&pb.AttestationAnnounce{}, rs.attestationAnnounceBuf)
 	attestationSub := rs.p2p.Subscribe(&pb.AttestationResponse{}, rs.attestationBuf)
 	attestationReqSub := rs.p2p.Subscribe(&pb.AttestationRequest{}, rs.attestationReqByHashBuf)
 	unseenAttestationsReqSub := rs.p2p.Subscribe(&pb.UnseenAttestationsRequest{}, rs.unseenAttestationsReqBuf)
-	exitSub := rs.p2p.Subscribe(&pb.VoluntaryExit{}, rs.exitBuf)
+	exitAnnounceSub := rs.p2p.Subscribe(&pb.ExitAnnounce{}, rs.exitAnnounceBuf)
+	exitReqSub := rs.p2p.Subscribe(&pb.ExitRequest{}, rs.exitReqBuf)
+	exitSub := rs.p2p.Subscribe(&pb.ExitResponse{}, rs.exitBuf)
+	proposerSlashingAnnounceSub := rs.p2p.Subscribe(&pb.ProposerSlashingAnnounce{}, rs.proposerSlashingAnnounceBuf)
+	proposerSlashingReqSub := rs.p2p.Subscribe(&pb.ProposerSlashingRequest{}, rs.proposerSlashingReqBuf)
+	proposerSlashingSub := rs.p2p.Subscribe(&pb.ProposerSlashingResponse{}, rs.proposerSlashingBuf)
+	attesterSlashingAnnounceSub := rs.p2p.Subscribe(&pb.AttesterSlashingAnnounce{}, rs.attesterSlashingAnnounceBuf)
+	attesterSlashingReqSub := rs.p2p.Subscribe(&pb.AttesterSlashingRequest{}, rs.attesterSlashingReqBuf)
+	attesterSlashingSub := rs.p2p.Subscribe(&pb.AttesterSlashingResponse{}, rs.attesterSlashingBuf)
 	chainHeadReqSub := rs.p2p.Subscribe(&pb.ChainHeadRequest{}, rs.chainHeadReqBuf)
+	missingParentSub := rs.chainService.BlockRequestFeed().Subscribe(rs.missingParentBuf)
+	invalidBlockSub := rs.chainService.InvalidBlockFeed().Subscribe(rs.invalidBlockBuf)
 
 	defer announceBlockSub.Unsubscribe()
 	defer blockSub.Unsubscribe()
@@ -173,10 +245,21 @@ func (rs *RegularSync) run() {
 	defer batchedBlockRequestSub.Unsubscribe()
 	defer stateRequestSub.Unsubscribe()
 	defer chainHeadReqSub.Unsubscribe()
+	defer attestationAnnounceSub.Unsubscribe()
 	defer attestationSub.Unsubscribe()
 	defer attestationReqSub.Unsubscribe()
 	defer unseenAttestationsReqSub.Unsubscribe()
+	defer exitAnnounceSub.Unsubscribe()
+	defer exitReqSub.Unsubscribe()
 	defer exitSub.Unsubscribe()
+	defer proposerSlashingAnnounceSub.Unsubscribe()
+	defer proposerSlashingReqSub.Unsubscribe()
+	defer proposerSlashingSub.Unsubscribe()
+	defer attesterSlashingAnnounceSub.Unsubscribe()
+	defer attesterSlashingReqSub.Unsubscribe()
+	defer attesterSlashingSub.Unsubscribe()
+	defer missingParentSub.Unsubscribe()
+	defer invalidBlockSub.Unsubscribe()
 
 	for {
 		select {
@@ -185,14 +268,32 @@ func (rs *RegularSync) run() {
 			return
 		case msg := <-rs.announceBlockBuf:
 			rs.receiveBlockAnnounce(msg)
+		case msg := <-rs.attestationAnnounceBuf:
+			rs.receiveAttestationAnnounce(msg)
 		case msg := <-rs.attestationBuf:
 			rs.receiveAttestation(msg)
 		case msg := <-rs.attestationReqByHashBuf:
 			rs.handleAttestationRequestByHash(msg)
 		case msg := <-rs.unseenAttestationsReqBuf:
 			rs.handleUnseenAttestationsRequest(msg)
+		case msg := <-rs.exitAnnounceBuf:
+			rs.receiveExitAnnounce(msg)
+		case msg := <-rs.exitReqBuf:
+			rs.handleExitRequest(msg)
 		case msg := <-rs.exitBuf:
 			rs.receiveExitRequest(msg)
+		case msg := <-rs.proposerSlashingAnnounceBuf:
+			rs.receiveProposerSlashingAnnounce(msg)
+		case msg := <-rs.proposerSlashingReqBuf:
+			rs.handleProposerSlashingRequest(msg)
+		case msg := <-rs.proposerSlashingBuf:
+			rs.receiveProposerSlashing(msg)
+		case msg := <-rs.attesterSlashingAnnounceBuf:
+			rs.receiveAttesterSlashingAnnounce(msg)
+		case msg := <-rs.attesterSlashingReqBuf:
+			rs.handleAttesterSlashingRequest(msg)
+		case msg := <-rs.attesterSlashingBuf:
+			rs.receiveAttesterSlashing(msg)
 		case msg := <-rs.blockBuf:
 			rs.receiveBlock(msg)
 		case msg := <-rs.blockRequestBySlot:
@@ -205,6 +306,10 @@ func (rs *RegularSync) run() {
 			rs.handleStateRequest(msg)
 		case msg := <-rs.chainHeadReqBuf:
 			rs.handleChainHeadRequest(msg)
+		case root := <-rs.missingParentBuf:
+			rs.requestMissingParent(root)
+		case event := <-rs.invalidBlockBuf:
+			rs.penalizeBlockSender(event)
 		}
 	}
 }
@@ -231,6 +336,16 @@ func (rs *RegularSync) receiveBlockAnnounce(msg p2p.Message) {
 	sendBlockRequestSpan.End()
 }
 
+// requestMissingParent requests a block from the network which the chain
+// service needs as the parent of a block it is holding.
+func (rs *RegularSync) requestMissingParent(root [32]byte) {
+	if rs.db.HasBlock(root) {
+		return
+	}
+	log.WithField("blockRoot", fmt.Sprintf("%#x", root)).Debug("Requesting missing parent block from peers")
+	rs.p2p.Broadcast(&pb.BeaconBlockRequest{Hash: root[:]})
+}
+
 // receiveBlock processes a block from the p2p layer.
 func (rs *RegularSync) receiveBlock(msg p2p.Message) {
 	ctx, receiveBlockSpan := trace.StartSpan(msg.Ctx, "RegularSync_receiveBlock")
@@ -261,12 +376,46 @@ func (rs *RegularSync) receiveBlock(msg p2p.Message) {
 		return
 	}
 
+	rs.recordBlockSender(blockRoot, msg.Peer)
+
 	_, sendBlockSpan := trace.StartSpan(ctx, "sendBlock")
 	log.WithField("blockRoot", fmt.Sprintf("%#x", blockRoot)).Debug("Sending newly received block to subscribers")
 	rs.chainService.IncomingBlockFeed().Send(block)
 	sendBlockSpan.End()
 }
 
+// recordBlockSender remembers the peer which sent a block, forgetting the oldest
+// recorded sender once maxBlockSenders are recorded.
+func (rs *RegularSync) recordBlockSender(blockRoot [32]byte, peer p2p.Peer) {
+	if _, ok := rs.blockSenders[blockRoot]; ok {
+		return
+	}
+	if len(rs.blockSenderRoots) >= maxBlockSenders {
+		delete(rs.blockSenders, rs.blockSenderRoots[0])
+		rs.blockSenderRoots = rs.blockSenderRoots[1:]
+	}
+	rs.blockSenders[blockRoot] = peer
+	rs.blockSenderRoots = append(rs.blockSenderRoots, blockRoot)
+}
+
+// penalizeBlockSender lowers the score of the peer which sent a block that the chain
+// service found to be invalid.
+func (rs *RegularSync) penalizeBlockSender(event *blockchain.InvalidBlockEvent) {
+	peer, ok := rs.blockSenders[event.BlockRoot]
+	if !ok {
+		return
+	}
+	behavior := p2p.InvalidBlock
+	if event.BadSignature {
+		behavior = p2p.InvalidSignature
+	}
+	log.WithFields(logrus.Fields{
+		"blockRoot": fmt.Sprintf("%#x", event.BlockRoot),
+		"peer":      peer.ID.Pretty(),
+	}).Debug("Penalizing peer for sending an invalid block")
+	rs.p2p.ReportPeer(peer, behavior)
+}
+
 // handleBlockRequestBySlot processes a block request from the p2p layer.
 // if found, the block is sent to the requesting peer.
 func (rs *RegularSync) handleBlockRequestBySlot(msg p2p.Message) {
@@ -330,25 +479,51 @@ func (rs *RegularSync) handleChainHeadRequest(msg p2p.Message) {
 		return
 	}
 
-	block, err := rs.db.ChainHead()
+	resp, err := rs.chainHead()
 	if err != nil {
-		log.Errorf("Could not retrieve chain head %v", err)
+		log.Error(err)
 		return
 	}
+	rs.p2p.Send(resp, msg.Peer)
+}
+
+// chainHead returns the response to a chain head request.
+func (rs *RegularSync) chainHead() (*pb.ChainHeadResponse, error) {
+	block, err := rs.db.ChainHead()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chain head: %v", err)
+	}
 
 	blockRoot, err := hashutil.HashBeaconBlock(block)
 	if err != nil {
-		log.Errorf("Could not tree hash block %v", err)
-		return
+		return nil, fmt.Errorf("could not tree hash block: %v", err)
 	}
 
-	req := &pb.ChainHeadResponse{
+	return &pb.ChainHeadResponse{
 		Slot:  block.Slot,
 		Hash:  blockRoot[:],
 		Block: block,
+	}, nil
+}
+
+// receiveAttestationAnnounce accepts an attestation hash announced by a peer, and
+// requests the attestation from the peer if it has not been seen before.
+func (rs *RegularSync) receiveAttestationAnnounce(msg p2p.Message) {
+	ctx, receiveAttestationAnnounceSpan := trace.StartSpan(msg.Ctx, "RegularSync_receiveAttestationAnnounce")
+	defer receiveAttestationAnnounceSpan.End()
+
+	data := msg.Data.(*pb.AttestationAnnounce)
+	h := bytesutil.ToBytes32(data.Hash)
+
+	if rs.db.HasAttestation(h) {
+		log.Debugf("Received, skipping attestation announcement #%x", h)
+		return
 	}
 
-	rs.p2p.Send(req, msg.Peer)
+	log.WithField("attestationHash", fmt.Sprintf("%#x", h)).Debug("Received incoming attestation hash, requesting full attestation from sender")
+	_, sendAttestationRequestSpan := trace.StartSpan(ctx, "sendAttestationRequest")
+	rs.p2p.Send(&pb.AttestationRequest{Hash: h[:]}, msg.Peer)
+	sendAttestationRequestSpan.End()
 }
 
 // receiveAttestation accepts an broadcasted attestation from the p2p layer,
@@ -358,10 +533,11 @@ func (rs *RegularSync) receiveAttestation(msg p2p.Message) {
 	ctx, receiveAttestationSpan := trace.StartSpan(msg.Ctx, "RegularSync_receiveAttestation")
 	defer receiveAttestationSpan.End()
 
-	attestation := msg.Data.(*pb.Attestation)
+	attestation := msg.Data.(*pb.AttestationResponse).Attestation
 	attestationRoot, err := hashutil.HashProto(attestation)
 	if err != nil {
 		log.Errorf("Could not hash received attestation: %v", err)
+		return
 	}
 
 	// Skip if attestation has been seen before.
@@ -394,7 +570,7 @@ func (rs *RegularSync) receiveAttestation(msg p2p.Message) {
 // discard the exit if we have gotten before, send it to operation
 // service if we have not.
 func (rs *RegularSync) receiveExitRequest(msg p2p.Message) {
-	exit := msg.Data.(*pb.VoluntaryExit)
+	exit := msg.Data.(*pb.ExitResponse).VoluntaryExit
 	h, err := hashutil.HashProto(exit)
 	if err != nil {
 		log.Errorf("Could not hash incoming exit request: %v", err)
@@ -411,54 +587,209 @@ func (rs *RegularSync) receiveExitRequest(msg p2p.Message) {
 	rs.operationsService.IncomingExitFeed().Send(exit)
 }
 
+// receiveExitAnnounce accepts an exit hash announced by a peer, and requests the exit
+// from the peer if it has not been seen before.
+func (rs *RegularSync) receiveExitAnnounce(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.ExitAnnounce).Hash)
+	if rs.db.HasExit(h) {
+		log.Debugf("Received, skipping exit announcement #%x", h)
+		return
+	}
+	log.WithField("exitReqHash", fmt.Sprintf("%#x", h)).Debug("Requesting announced exit request from sender")
+	rs.p2p.Send(&pb.ExitRequest{Hash: h[:]}, msg.Peer)
+}
+
+// handleExitRequest sends the requested exit to the peer, if it is in the db.
+func (rs *RegularSync) handleExitRequest(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.ExitRequest).Hash)
+	exit, err := rs.db.Exit(h)
+	if err != nil {
+		log.Errorf("Could not retrieve exit request: %v", err)
+		return
+	}
+	if exit == nil {
+		log.Debugf("Exit request %#x is not in db", h)
+		return
+	}
+	log.Debugf("Sending exit request %#x to peer %v", h, msg.Peer)
+	rs.p2p.Send(&pb.ExitResponse{Hash: h[:], VoluntaryExit: exit}, msg.Peer)
+}
+
+// receiveProposerSlashingAnnounce accepts a proposer slashing hash announced by a peer,
+// and requests the slashing from the peer if it has not been seen before.
+func (rs *RegularSync) receiveProposerSlashingAnnounce(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.ProposerSlashingAnnounce).Hash)
+	if rs.db.HasProposerSlashing(h) {
+		log.Debugf("Received, skipping proposer slashing announcement #%x", h)
+		return
+	}
+	log.WithField("proposerSlashingHash", fmt.Sprintf("%#x", h)).Debug("Requesting announced proposer slashing from sender")
+	rs.p2p.Send(&pb.ProposerSlashingRequest{Hash: h[:]}, msg.Peer)
+}
+
+// handleProposerSlashingRequest sends the requested proposer slashing to the peer, if it
+// is in the db.
+func (rs *RegularSync) handleProposerSlashingRequest(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.ProposerSlashingRequest).Hash)
+	slashing, err := rs.db.ProposerSlashing(h)
+	if err != nil {
+		log.Errorf("Could not retrieve proposer slashing: %v", err)
+		return
+	}
+	if slashing == nil {
+		log.Debugf("Proposer slashing %#x is not in db", h)
+		return
+	}
+	log.Debugf("Sending proposer slashing %#x to peer %v", h, msg.Peer)
+	rs.p2p.Send(&pb.ProposerSlashingResponse{Hash: h[:], ProposerSlashing: slashing}, msg.Peer)
+}
+
+// receiveProposerSlashing accepts a proposer slashing from the p2p layer, and sends it to
+// the operation service if it has not been seen before.
+func (rs *RegularSync) receiveProposerSlashing(msg p2p.Message) {
+	slashing := msg.Data.(*pb.ProposerSlashingResponse).ProposerSlashing
+	h, err := hashutil.HashProto(slashing)
+	if err != nil {
+		log.Errorf("Could not hash incoming proposer slashing: %v", err)
+		return
+	}
+	if rs.db.HasProposerSlashing(h) {
+		log.Debugf("Received, skipping proposer slashing #%x", h)
+		return
+	}
+	log.WithField("proposerSlashingHash", fmt.Sprintf("%#x", h)).
+		Debug("Forwarding proposer slashing to subscribed services")
+	rs.operationsService.IncomingProposerSlashingFeed().Send(slashing)
+}
+
+// receiveAttesterSlashingAnnounce accepts an attester slashing hash announced by a peer,
+// and requests the slashing from the peer if it has not been seen before.
+func (rs *RegularSync) receiveAttesterSlashingAnnounce(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.AttesterSlashingAnnounce).Hash)
+	if rs.db.HasAttesterSlashing(h) {
+		log.Debugf("Received, skipping attester slashing announcement #%x", h)
+		return
+	}
+	log.WithField("attesterSlashingHash", fmt.Sprintf("%#x", h)).Debug("Requesting announced attester slashing from sender")
+	rs.p2p.Send(&pb.AttesterSlashingRequest{Hash: h[:]}, msg.Peer)
+}
+
+// handleAttesterSlashingRequest sends the requested attester slashing to the peer, if it
+// is in the db.
+func (rs *RegularSync) handleAttesterSlashingRequest(msg p2p.Message) {
+	h := bytesutil.ToBytes32(msg.Data.(*pb.AttesterSlashingRequest).Hash)
+	slashing, err := rs.db.AttesterSlashing(h)
+	if err != nil {
+		log.Errorf("Could not retrieve attester slashing: %v", err)
+		return
+	}
+	if slashing == nil {
+		log.Debugf("Attester slashing %#x is not in db", h)
+		return
+	}
+	log.Debugf("Sending attester slashing %#x to peer %v", h, msg.Peer)
+	rs.p2p.Send(&pb.AttesterSlashingResponse{Hash: h[:], AttesterSlashing: slashing}, msg.Peer)
+}
+
+// receiveAttesterSlashing accepts an attester slashing from the p2p layer, and sends it to
+// the operation service if it has not been seen before.
+func (rs *RegularSync) receiveAttesterSlashing(msg p2p.Message) {
+	slashing := msg.Data.(*pb.AttesterSlashingResponse).AttesterSlashing
+	h, err := hashutil.HashProto(slashing)
+	if err != nil {
+		log.Errorf("Could not hash incoming attester slashing: %v", err)
+		return
+	}
+	if rs.db.HasAttesterSlashing(h) {
+		log.Debugf("Received, skipping attester slashing #%x", h)
+		return
+	}
+	log.WithField("attesterSlashingHash", fmt.Sprintf("%#x", h)).
+		Debug("Forwarding attester slashing to subscribed services")
+	rs.operationsService.IncomingAttesterSlashingFeed().Send(slashing)
+}
+
 func (rs *RegularSync) handleBlockRequestByHash(msg p2p.Message) {
-	data := msg.Data.(*pb.BeaconBlockRequest)
+	resp, err := rs.blockByHash(msg.Data.(*pb.BeaconBlockRequest))
+	if err != nil {
+		log.Debugf("Could not respond to block request: %v", err)
+		return
+	}
+	rs.p2p.Send(resp, msg.Peer)
+}
 
-	root := bytesutil.ToBytes32(data.Hash)
+// blockByHash returns the response to a request for a block by its root.
+func (rs *RegularSync) blockByHash(req *pb.BeaconBlockRequest) (*pb.BeaconBlockResponse, error) {
+	root := bytesutil.ToBytes32(req.Hash)
 
 	block, err := rs.db.Block(root)
 	if err != nil {
-		log.Error(err)
-		return
+		return nil, fmt.Errorf("could not retrieve block: %v", err)
 	}
 	if block == nil {
-		log.Debug("Block does not exist")
-		return
+		return nil, p2p.NewRPCError(p2p.RPCResourceUnavailable, "block %#x does not exist", root)
 	}
 
-	rs.p2p.Send(&pb.BeaconBlockResponse{
+	return &pb.BeaconBlockResponse{
 		Block: block,
-	}, msg.Peer)
+	}, nil
 }
 
 // handleBatchedBlockRequest receives p2p messages which consist of requests for batched blocks
 // which are bounded by a start slot and end slot.
 func (rs *RegularSync) handleBatchedBlockRequest(msg p2p.Message) {
+	resp, err := rs.batchedBlocks(msg)
+	if err != nil {
+		log.Debugf("Could not respond to batched block request: %v", err)
+		return
+	}
+	log.Debugf("Sending response for batch blocks to peer %v", msg.Peer)
+	rs.p2p.Send(resp, msg.Peer)
+}
+
+// batchedBlocks returns the response to a request for the blocks between a start slot and
+// an end slot, holding at most BatchBlockLimit+1 slots. The sender of a request whose start
+// slot is before genesis or past its end slot is reported.
+func (rs *RegularSync) batchedBlocks(msg p2p.Message) (*pb.BatchedBeaconBlockResponse, error) {
 	data := msg.Data.(*pb.BatchedBeaconBlockRequest)
 	startSlot, endSlot := data.StartSlot, data.EndSlot
+	if startSlot < params.BeaconConfig().GenesisSlot {
+		rs.p2p.ReportPeer(msg.Peer, p2p.Spam)
+		return nil, p2p.NewRPCError(p2p.RPCInvalidRequest, "start slot %d is before genesis", startSlot)
+	}
+	if startSlot > endSlot {
+		rs.p2p.ReportPeer(msg.Peer, p2p.Spam)
+		return nil, p2p.NewRPCError(p2p.RPCInvalidRequest, "start slot %d > end slot %d", startSlot, endSlot)
+	}
 
 	block, err := rs.db.ChainHead()
 	if err != nil {
-		log.Errorf("Could not retrieve chain head %v", err)
-		return
+		return nil, fmt.Errorf("could not retrieve chain head: %v", err)
 	}
 
 	finalizedSlot, err := rs.db.CleanedFinalizedSlot()
 	if err != nil {
-		log.Errorf("Could not retrieve last finalized slot %v", err)
-		return
+		return nil, fmt.Errorf("could not retrieve last finalized slot: %v", err)
 	}
 
 	currentSlot := block.Slot
 
 	if currentSlot < startSlot || finalizedSlot > endSlot {
-		log.Debugf(
-			"invalid batch request: current slot < start slot || finalized slot > end slot."+
+		return nil, p2p.NewRPCError(
+			p2p.RPCResourceUnavailable,
+			"current slot < start slot || finalized slot > end slot. "+
 				"currentSlot %d startSlot %d endSlot %d finalizedSlot %d", currentSlot, startSlot, endSlot, finalizedSlot)
-		return
+	}
+	// There are no blocks past the chain head to look up.
+	if endSlot > currentSlot {
+		endSlot = currentSlot
+	}
+	// Requests for more blocks than a batch holds are truncated, as initial sync does.
+	if blockLimit := params.BeaconConfig().BatchBlockLimit; endSlot-startSlot > blockLimit {
+		endSlot = startSlot + blockLimit
 	}
 
-	response := make([]*pb.BeaconBlock, 0, endSlot-startSlot)
+	var response []*pb.BeaconBlock
 
 	for i := startSlot; i <= endSlot; i++ {
 		retBlock, err := rs.db.BlockBySlot(i)
@@ -475,10 +806,23 @@ func (rs *RegularSync) handleBatchedBlockRequest(msg p2p.Message) {
 		response = append(response, retBlock)
 	}
 
-	log.Debugf("Sending response for batch blocks to peer %v", msg.Peer)
-	rs.p2p.Send(&pb.BatchedBeaconBlockResponse{
+	return &pb.BatchedBeaconBlockResponse{
 		BatchedBlocks: response,
-	}, msg.Peer)
+	}, nil
+}
+
+// registerRequestHandlers registers the handlers of the block and chain head requests
+// which peers send over the RPC protocol rather than as topic messages.
+func (rs *RegularSync) registerRequestHandlers() {
+	rs.p2p.RegisterRequestHandler(&pb.BeaconBlockRequest{}, &pb.BeaconBlockResponse{}, func(msg p2p.Message) (proto.Message, error) {
+		return rs.blockByHash(msg.Data.(*pb.BeaconBlockRequest))
+	})
+	rs.p2p.RegisterRequestHandler(&pb.BatchedBeaconBlockRequest{}, &pb.BatchedBeaconBlockResponse{}, func(msg p2p.Message) (proto.Message, error) {
+		return rs.batchedBlocks(msg)
+	})
+	rs.p2p.RegisterRequestHandler(&pb.ChainHeadRequest{}, &pb.ChainHeadResponse{}, func(msg p2p.Message) (proto.Message, error) {
+		return rs.chainHead()
+	})
 }
 
 func (rs *RegularSync) handleAttestationRequestByHash(msg p2p.Message) {
@@ -501,6 +845,7 @@ func (rs *RegularSync) handleAttestationRequestByHash(msg p2p.Message) {
 	_, sendAttestationSpan := trace.StartSpan(ctx, "sendAttestation")
 	log.Debugf("Sending attestation %#x to peer %v", root, msg.Peer)
 	rs.p2p.Send(&pb.AttestationResponse{
+		Hash:        root[:],
 		Attestation: att,
 	}, msg.Peer)
 	sendAttestationSpan.End()
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -28,6 +31,9 @@ func init() {
 }
 
 type mockP2P struct {
+	reports  []p2p.PeerBehavior
+	sent     []proto.Message
+	handlers map[string]p2p.RequestHandler
 }
 
 func (mp *mockP2P) Subscribe(msg proto.Message, channel chan p2p.Message) event.Subscription {
@@ -37,11 +43,28 @@ func (mp *mockP2P) Subscribe(msg proto.Message, channel chan p2p.Message) event.
 func (mp *mockP2P) Broadcast(msg proto.Message) {}
 
 func (mp *mockP2P) Send(msg proto.Message, peer p2p.Peer) {
+	mp.sent = append(mp.sent, msg)
+}
+
+func (mp *mockP2P) RegisterRequestHandler(request proto.Message, response proto.Message, handler p2p.RequestHandler) {
+	if mp.handlers == nil {
+		mp.handlers = make(map[string]p2p.RequestHandler)
+	}
+	mp.handlers[proto.MessageName(request)] = handler
+}
+
+func (mp *mockP2P) Request(ctx context.Context, peer p2p.Peer, req proto.Message, resp proto.Message) error {
+	return nil
+}
+
+func (mp *mockP2P) ReportPeer(peer p2p.Peer, behavior p2p.PeerBehavior) {
+	mp.reports = append(mp.reports, behavior)
 }
 
 type mockChainService struct {
 	bFeed *event.Feed
 	sFeed *event.Feed
+	rFeed *event.Feed
 }
 
 func (ms *mockChainService) IncomingBlockFeed() *event.Feed {
@@ -58,7 +81,21 @@ func (ms *mockChainService) StateInitializedFeed() *event.Feed {
 	return ms.sFeed
 }
 
-type mockOperationService struct{}
+func (ms *mockChainService) BlockRequestFeed() *event.Feed {
+	if ms.rFeed == nil {
+		return new(event.Feed)
+	}
+	return ms.rFeed
+}
+
+func (ms *mockChainService) InvalidBlockFeed() *event.Feed {
+	return new(event.Feed)
+}
+
+type mockOperationService struct {
+	psFeed *event.Feed
+	asFeed *event.Feed
+}
 
 func (ms *mockOperationService) IncomingAttFeed() *event.Feed {
 	return new(event.Feed)
@@ -68,6 +105,20 @@ func (ms *mockOperationService) IncomingExitFeed() *event.Feed {
 	return new(event.Feed)
 }
 
+func (ms *mockOperationService) IncomingProposerSlashingFeed() *event.Feed {
+	if ms.psFeed == nil {
+		return new(event.Feed)
+	}
+	return ms.psFeed
+}
+
+func (ms *mockOperationService) IncomingAttesterSlashingFeed() *event.Feed {
+	if ms.asFeed == nil {
+		return new(event.Feed)
+	}
+	return ms.asFeed
+}
+
 func setupService(t *testing.T, db *db.BeaconDB) *RegularSync {
 	cfg := &RegularSyncConfig{
 		BlockAnnounceBufferSize: 0,
@@ -123,6 +174,38 @@ func TestProcessBlockRoot_OK(t *testing.T) {
 	hook.Reset()
 }
 
+func TestRequestMissingParent_OK(t *testing.T) {
+	hook := logTest.NewGlobal()
+
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	ms := &mockChainService{rFeed: new(event.Feed)}
+	cfg := &RegularSyncConfig{
+		ChainService: ms,
+		P2P:          &mockP2P{},
+		BeaconDB:     db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	exitRoutine := make(chan bool)
+
+	go func() {
+		ss.run()
+		exitRoutine <- true
+	}()
+
+	// Wait for the sync service to subscribe to the block request feed.
+	for ms.rFeed.Send([32]byte{'a'}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The request is buffered, so the service is only stopped once it has been handled.
+	testutil.WaitForLog(t, hook, "Requesting missing parent block from peers")
+
+	ss.cancel()
+	<-exitRoutine
+}
+
 func TestProcessBlock_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 
@@ -394,9 +477,11 @@ func TestReceiveAttestation_OK(t *testing.T) {
 		exitRoutine <- true
 	}()
 
-	request1 := &pb.Attestation{
-		Data: &pb.AttestationData{
-			Slot: params.BeaconConfig().GenesisSlot + 1,
+	request1 := &pb.AttestationResponse{
+		Attestation: &pb.Attestation{
+			Data: &pb.AttestationData{
+				Slot: params.BeaconConfig().GenesisSlot + 1,
+			},
 		},
 	}
 
@@ -437,9 +522,11 @@ func TestReceiveAttestation_OlderThanFinalizedEpoch(t *testing.T) {
 		exitRoutine <- true
 	}()
 
-	request1 := &pb.Attestation{
-		Data: &pb.AttestationData{
-			Slot: params.BeaconConfig().GenesisSlot + 1,
+	request1 := &pb.AttestationResponse{
+		Attestation: &pb.Attestation{
+			Data: &pb.AttestationData{
+				Slot: params.BeaconConfig().GenesisSlot + 1,
+			},
 		},
 	}
 
@@ -454,7 +541,7 @@ func TestReceiveAttestation_OlderThanFinalizedEpoch(t *testing.T) {
 	<-exitRoutine
 	want := fmt.Sprintf(
 		"Skipping received attestation with slot smaller than last finalized slot, %d < %d",
-		request1.Data.Slot, state.FinalizedEpoch*params.BeaconConfig().SlotsPerEpoch)
+		request1.Attestation.Data.Slot, state.FinalizedEpoch*params.BeaconConfig().SlotsPerEpoch)
 	testutil.AssertLogsContain(t, hook, want)
 }
 
@@ -477,8 +564,10 @@ func TestReceiveExitReq_OK(t *testing.T) {
 		exitRoutine <- true
 	}()
 
-	request1 := &pb.VoluntaryExit{
-		Epoch: 100,
+	request1 := &pb.ExitResponse{
+		VoluntaryExit: &pb.VoluntaryExit{
+			Epoch: 100,
+		},
 	}
 
 	msg1 := p2p.Message{
@@ -493,6 +582,148 @@ func TestReceiveExitReq_OK(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Forwarding validator exit request to subscribed services")
 }
 
+func TestReceiveAttestationAnnounce_RequestsUnseenAttestation(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	seen := &pb.Attestation{AggregationBitfield: []byte{'A'}}
+	seenRoot, err := hashutil.HashProto(seen)
+	if err != nil {
+		t.Fatalf("Could not hash attestation: %v", err)
+	}
+	if err := db.SaveAttestation(seen); err != nil {
+		t.Fatalf("Could not save attestation: %v", err)
+	}
+	unseenRoot := hashutil.Hash([]byte("unseen"))
+
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		OperationService: &mockOperationService{},
+		P2P:              mp,
+		BeaconDB:         db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	exitRoutine := make(chan bool)
+	go func() {
+		ss.run()
+		exitRoutine <- true
+	}()
+
+	for _, root := range [][32]byte{seenRoot, unseenRoot} {
+		ss.attestationAnnounceBuf <- p2p.Message{
+			Ctx:  context.Background(),
+			Data: &pb.AttestationAnnounce{Hash: root[:]},
+		}
+	}
+	ss.cancel()
+	<-exitRoutine
+
+	want := []proto.Message{&pb.AttestationRequest{Hash: unseenRoot[:]}}
+	if !reflect.DeepEqual(mp.sent, want) {
+		t.Errorf("Expected only the unseen attestation to be requested, sent %v", mp.sent)
+	}
+}
+
+func TestHandleExitRequest_OK(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	exit := &pb.VoluntaryExit{Epoch: 100}
+	exitRoot, err := hashutil.HashProto(exit)
+	if err != nil {
+		t.Fatalf("Could not hash exit request: %v", err)
+	}
+	if err := db.SaveExit(exit); err != nil {
+		t.Fatalf("Could not save exit request: %v", err)
+	}
+
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		OperationService: &mockOperationService{},
+		P2P:              mp,
+		BeaconDB:         db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	exitRoutine := make(chan bool)
+	go func() {
+		ss.run()
+		exitRoutine <- true
+	}()
+
+	ss.exitReqBuf <- p2p.Message{
+		Ctx:  context.Background(),
+		Data: &pb.ExitRequest{Hash: exitRoot[:]},
+	}
+	ss.cancel()
+	<-exitRoutine
+
+	want := &pb.ExitResponse{Hash: exitRoot[:], VoluntaryExit: exit}
+	if len(mp.sent) != 1 || !proto.Equal(mp.sent[0], want) {
+		t.Errorf("Expected exit response %v to be sent, sent %v", want, mp.sent)
+	}
+}
+
+func TestReceiveSlashings_ForwardsToOperations(t *testing.T) {
+	hook := logTest.NewGlobal()
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+
+	os := &mockOperationService{psFeed: new(event.Feed), asFeed: new(event.Feed)}
+	proposerSlashings := make(chan *pb.ProposerSlashing, 1)
+	psSub := os.psFeed.Subscribe(proposerSlashings)
+	defer psSub.Unsubscribe()
+	attesterSlashings := make(chan *pb.AttesterSlashing, 1)
+	asSub := os.asFeed.Subscribe(attesterSlashings)
+	defer asSub.Unsubscribe()
+
+	seen := &pb.ProposerSlashing{ProposerIndex: 1}
+	if err := db.SaveProposerSlashing(seen); err != nil {
+		t.Fatalf("Could not save proposer slashing: %v", err)
+	}
+
+	cfg := &RegularSyncConfig{
+		OperationService: os,
+		P2P:              &mockP2P{},
+		BeaconDB:         db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	exitRoutine := make(chan bool)
+	go func() {
+		ss.run()
+		exitRoutine <- true
+	}()
+
+	proposerSlashing := &pb.ProposerSlashing{ProposerIndex: 2}
+	attesterSlashing := &pb.AttesterSlashing{
+		SlashableAttestation_1: &pb.SlashableAttestation{ValidatorIndices: []uint64{3}},
+	}
+	ss.proposerSlashingBuf <- p2p.Message{
+		Ctx:  context.Background(),
+		Data: &pb.ProposerSlashingResponse{ProposerSlashing: seen},
+	}
+	ss.proposerSlashingBuf <- p2p.Message{
+		Ctx:  context.Background(),
+		Data: &pb.ProposerSlashingResponse{ProposerSlashing: proposerSlashing},
+	}
+	ss.attesterSlashingBuf <- p2p.Message{
+		Ctx:  context.Background(),
+		Data: &pb.AttesterSlashingResponse{AttesterSlashing: attesterSlashing},
+	}
+	ss.cancel()
+	<-exitRoutine
+
+	if received := <-proposerSlashings; !proto.Equal(received, proposerSlashing) {
+		t.Errorf("Expected proposer slashing %v to be forwarded, received %v", proposerSlashing, received)
+	}
+	if received := <-attesterSlashings; !proto.Equal(received, attesterSlashing) {
+		t.Errorf("Expected attester slashing %v to be forwarded, received %v", attesterSlashing, received)
+	}
+	testutil.AssertLogsContain(t, hook, "Received, skipping proposer slashing")
+}
+
 func TestHandleAttReq_HashNotFound(t *testing.T) {
 	hook := logTest.NewGlobal()
 	os := &mockOperationService{}
@@ -730,3 +961,168 @@ func TestHandleStateReq_OK(t *testing.T) {
 
 	testutil.AssertLogsContain(t, hook, "Sending beacon state to peer")
 }
+
+func TestPenalizeBlockSender_ReportsInvalidBlocks(t *testing.T) {
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		ChainService:     &mockChainService{},
+		P2P:              mp,
+		OperationService: &mockOperationService{},
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	sender := p2p.Peer{ID: "sender"}
+	ss.recordBlockSender([32]byte{'A'}, sender)
+	ss.recordBlockSender([32]byte{'B'}, sender)
+
+	ss.penalizeBlockSender(&blockchain.InvalidBlockEvent{BlockRoot: [32]byte{'A'}})
+	ss.penalizeBlockSender(&blockchain.InvalidBlockEvent{BlockRoot: [32]byte{'B'}, BadSignature: true})
+	// Blocks which were not received from a peer are not reported.
+	ss.penalizeBlockSender(&blockchain.InvalidBlockEvent{BlockRoot: [32]byte{'C'}})
+
+	want := []p2p.PeerBehavior{p2p.InvalidBlock, p2p.InvalidSignature}
+	if !reflect.DeepEqual(mp.reports, want) {
+		t.Errorf("Expected reports %v, received %v", want, mp.reports)
+	}
+}
+
+func TestRecordBlockSender_ForgetsOldestSender(t *testing.T) {
+	cfg := &RegularSyncConfig{
+		ChainService:     &mockChainService{},
+		P2P:              &mockP2P{},
+		OperationService: &mockOperationService{},
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	for i := 0; i <= maxBlockSenders; i++ {
+		ss.recordBlockSender([32]byte{byte(i), byte(i >> 8)}, p2p.Peer{ID: "sender"})
+	}
+	if len(ss.blockSenders) != maxBlockSenders {
+		t.Errorf("Expected %d recorded senders, received %d", maxBlockSenders, len(ss.blockSenders))
+	}
+	if _, ok := ss.blockSenders[[32]byte{0, 0}]; ok {
+		t.Error("Expected the oldest sender to be forgotten")
+	}
+}
+
+func TestHandleBatchedBlockRequest_ReportsInvertedRange(t *testing.T) {
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		ChainService:     &mockChainService{},
+		P2P:              mp,
+		OperationService: &mockOperationService{},
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	ss.handleBatchedBlockRequest(p2p.Message{
+		Ctx:  context.Background(),
+		Peer: p2p.Peer{ID: "sender"},
+		Data: &pb.BatchedBeaconBlockRequest{StartSlot: params.BeaconConfig().GenesisSlot + 10, EndSlot: params.BeaconConfig().GenesisSlot + 5},
+	})
+
+	if len(mp.reports) != 1 || mp.reports[0] != p2p.Spam {
+		t.Errorf("Expected the request to be reported as spam, received %v", mp.reports)
+	}
+}
+
+func TestRegisterRequestHandlers_ServesBlockRequests(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		ChainService:     &mockChainService{},
+		P2P:              mp,
+		OperationService: &mockOperationService{},
+		BeaconDB:         db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+	ss.registerRequestHandlers()
+
+	block := &pb.BeaconBlock{Slot: params.BeaconConfig().GenesisSlot + 7}
+	if err := db.SaveBlock(block); err != nil {
+		t.Fatalf("Could not save block: %v", err)
+	}
+	root, err := hashutil.HashBeaconBlock(block)
+	if err != nil {
+		t.Fatalf("Could not hash block: %v", err)
+	}
+
+	handleBlockRequest := mp.handlers[proto.MessageName(&pb.BeaconBlockRequest{})]
+	if handleBlockRequest == nil {
+		t.Fatal("Expected a handler for block requests")
+	}
+	resp, err := handleBlockRequest(p2p.Message{Ctx: context.Background(), Data: &pb.BeaconBlockRequest{Hash: root[:]}})
+	if err != nil {
+		t.Fatalf("Block request failed: %v", err)
+	}
+	if !proto.Equal(resp.(*pb.BeaconBlockResponse).Block, block) {
+		t.Errorf("Unexpected block: %v. Wanted %v.", resp, block)
+	}
+
+	_, err = handleBlockRequest(p2p.Message{Ctx: context.Background(), Data: &pb.BeaconBlockRequest{Hash: []byte("missing")}})
+	if rpcErr, ok := err.(*p2p.RPCError); !ok || rpcErr.Code != p2p.RPCResourceUnavailable {
+		t.Errorf("Expected a resource unavailable error for a missing block, received %v", err)
+	}
+
+	handleBatchedRequest := mp.handlers[proto.MessageName(&pb.BatchedBeaconBlockRequest{})]
+	if handleBatchedRequest == nil {
+		t.Fatal("Expected a handler for batched block requests")
+	}
+	_, err = handleBatchedRequest(p2p.Message{
+		Ctx:  context.Background(),
+		Peer: p2p.Peer{ID: "sender"},
+		Data: &pb.BatchedBeaconBlockRequest{StartSlot: params.BeaconConfig().GenesisSlot + 10, EndSlot: params.BeaconConfig().GenesisSlot + 5},
+	})
+	if rpcErr, ok := err.(*p2p.RPCError); !ok || rpcErr.Code != p2p.RPCInvalidRequest {
+		t.Errorf("Expected an invalid request error for an inverted range, received %v", err)
+	}
+	if len(mp.reports) != 1 || mp.reports[0] != p2p.Spam {
+		t.Errorf("Expected the request to be reported as spam, received %v", mp.reports)
+	}
+
+	if mp.handlers[proto.MessageName(&pb.ChainHeadRequest{})] == nil {
+		t.Error("Expected a handler for chain head requests")
+	}
+}
+
+func TestBatchedBlocks_BoundsRequestedRange(t *testing.T) {
+	db := internal.SetupDB(t)
+	defer internal.TeardownDB(t, db)
+	if err := db.InitializeState(uint64(time.Now().Unix()), []*pb.Deposit{}); err != nil {
+		t.Fatalf("Could not initialize beacon state: %v", err)
+	}
+	mp := &mockP2P{}
+	cfg := &RegularSyncConfig{
+		ChainService:     &mockChainService{},
+		P2P:              mp,
+		OperationService: &mockOperationService{},
+		BeaconDB:         db,
+	}
+	ss := NewRegularSyncService(context.Background(), cfg)
+
+	// A range starting before genesis would span about 2^63 slots.
+	_, err := ss.batchedBlocks(p2p.Message{
+		Ctx:  context.Background(),
+		Peer: p2p.Peer{ID: "sender"},
+		Data: &pb.BatchedBeaconBlockRequest{StartSlot: 0, EndSlot: math.MaxUint64},
+	})
+	if rpcErr, ok := err.(*p2p.RPCError); !ok || rpcErr.Code != p2p.RPCInvalidRequest {
+		t.Errorf("Expected an invalid request error for a start slot before genesis, received %v", err)
+	}
+	if len(mp.reports) != 1 || mp.reports[0] != p2p.Spam {
+		t.Errorf("Expected the request to be reported as spam, received %v", mp.reports)
+	}
+
+	// An unbounded range is served up to the chain head, which is the genesis block.
+	resp, err := ss.batchedBlocks(p2p.Message{
+		Ctx:  context.Background(),
+		Peer: p2p.Peer{ID: "sender"},
+		Data: &pb.BatchedBeaconBlockRequest{StartSlot: params.BeaconConfig().GenesisSlot, EndSlot: math.MaxUint64},
+	})
+	if err != nil {
+		t.Fatalf("Batched block request failed: %v", err)
+	}
+	if len(resp.BatchedBlocks) != 1 || resp.BatchedBlocks[0].Slot != params.BeaconConfig().GenesisSlot {
+		t.Errorf("Expected the genesis block, received %v", resp.BatchedBlocks)
+	}
+}
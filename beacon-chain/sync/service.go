@@ -43,6 +43,7 @@ func NewSyncService(ctx context.Context, cfg *Config) *Service {
 
 	rsCfg := DefaultRegularSyncConfig()
 	rsCfg.ChainService = cfg.ChainService
+	rsCfg.OperationService = cfg.OperationService
 	rsCfg.BeaconDB = cfg.BeaconDB
 	rsCfg.P2P = cfg.P2P
 
@@ -108,5 +109,8 @@ func (ss *Service) run() {
 	// Sets the state root of the highest observed slot.
 	ss.InitialSync.InitializeStateRoot(ss.Querier.currentStateRoot)
 
+	// Sets the peer which responded with the chain head as the peer to sync from.
+	ss.InitialSync.InitializeSyncPeer(ss.Querier.currentHeadPeer)
+
 	ss.InitialSync.Start()
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/chaintest/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/internal"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -20,9 +22,11 @@ import (
 )
 
 type simulatedP2P struct {
-	subsChannels map[reflect.Type]*event.Feed
-	mutex        *sync.RWMutex
-	ctx          context.Context
+	subsChannels    map[reflect.Type]*event.Feed
+	subscribers     map[reflect.Type]int
+	requestHandlers map[string]p2p.RequestHandler
+	mutex           *sync.RWMutex
+	ctx             context.Context
 }
 
 func (sim *simulatedP2P) Subscribe(msg proto.Message, channel chan p2p.Message) event.Subscription {
@@ -30,6 +34,10 @@ func (sim *simulatedP2P) Subscribe(msg proto.Message, channel chan p2p.Message)
 	defer sim.mutex.Unlock()
 
 	protoType := reflect.TypeOf(msg)
+	if sim.subscribers == nil {
+		sim.subscribers = make(map[reflect.Type]int)
+	}
+	sim.subscribers[protoType]++
 
 	feed, ok := sim.subsChannels[protoType]
 	if !ok {
@@ -40,6 +48,25 @@ func (sim *simulatedP2P) Subscribe(msg proto.Message, channel chan p2p.Message)
 	return feed.Subscribe(channel)
 }
 
+// waitForSubscribers waits until each of the messages has the given number of subscribers.
+func (sim *simulatedP2P) waitForSubscribers(t *testing.T, count int, msgs ...proto.Message) {
+	deadline := time.Now().Add(5 * time.Second)
+	for _, msg := range msgs {
+		for {
+			sim.mutex.RLock()
+			n := sim.subscribers[reflect.TypeOf(msg)]
+			sim.mutex.RUnlock()
+			if n >= count {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d subscribers to %T, received %d", count, msg, n)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
 func (sim *simulatedP2P) Broadcast(msg proto.Message) {
 	sim.mutex.Lock()
 	defer sim.mutex.Unlock()
@@ -54,6 +81,39 @@ func (sim *simulatedP2P) Broadcast(msg proto.Message) {
 	feed.Send(p2p.Message{Ctx: sim.ctx, Data: msg})
 }
 
+func (sim *simulatedP2P) ReportPeer(peer p2p.Peer, behavior p2p.PeerBehavior) {}
+
+func (sim *simulatedP2P) RegisterRequestHandler(request proto.Message, response proto.Message, handler p2p.RequestHandler) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	if sim.requestHandlers == nil {
+		sim.requestHandlers = make(map[string]p2p.RequestHandler)
+	}
+	sim.requestHandlers[proto.MessageName(request)] = handler
+}
+
+// Request passes the request to the handler registered for it, and copies the response
+// of the handler into resp.
+func (sim *simulatedP2P) Request(ctx context.Context, peer p2p.Peer, req proto.Message, resp proto.Message) error {
+	sim.mutex.RLock()
+	handler, ok := sim.requestHandlers[proto.MessageName(req)]
+	sim.mutex.RUnlock()
+	if !ok {
+		return p2p.NewRPCError(p2p.RPCUnknownRequest, "no handler for %s", proto.MessageName(req))
+	}
+
+	out, err := handler(p2p.Message{Ctx: ctx, Peer: peer, Data: req})
+	if err != nil {
+		return err
+	}
+	if proto.MessageName(out) != proto.MessageName(resp) {
+		return p2p.NewRPCError(p2p.RPCInvalidResponse, "expected response of type %s, received %s", proto.MessageName(resp), proto.MessageName(out))
+	}
+	proto.Merge(resp, out)
+	return nil
+}
+
 func (sim *simulatedP2P) Send(msg proto.Message, peer p2p.Peer) {
 	sim.mutex.Lock()
 	defer sim.mutex.Unlock()
@@ -260,3 +320,81 @@ func TestSyncing_AFullySyncedNode(t *testing.T) {
 			uint64(numOfBlocks)+params.BeaconConfig().GenesisSlot, highestSlot2)
 	}
 }
+
+// setUpOperationsNode sets up a regular sync service and an operations service sharing a
+// beacon DB, as they are in a beacon node.
+func setUpOperationsNode(t *testing.T, simP2P *simulatedP2P) (*RegularSync, *operations.Service, *db.BeaconDB) {
+	beaconDB := internal.SetupDB(t)
+
+	ops := operations.NewOpsPoolService(context.Background(), &operations.Config{
+		BeaconDB: beaconDB,
+		P2P:      simP2P,
+	})
+	ops.Start()
+
+	cfg := DefaultRegularSyncConfig()
+	cfg.ChainService = &mockChainService{}
+	cfg.OperationService = ops
+	cfg.BeaconDB = beaconDB
+	cfg.P2P = simP2P
+	rs := NewRegularSyncService(context.Background(), cfg)
+	rs.Start()
+
+	return rs, ops, beaconDB
+}
+
+func TestOperations_PropagateBetweenNodes(t *testing.T) {
+	simP2P := &simulatedP2P{
+		subsChannels: make(map[reflect.Type]*event.Feed),
+		mutex:        new(sync.RWMutex),
+		ctx:          context.Background(),
+	}
+
+	rsA, opsA, dbA := setUpOperationsNode(t, simP2P)
+	defer internal.TeardownDB(t, dbA)
+	defer opsA.Stop()
+	defer rsA.Stop()
+	rsB, opsB, dbB := setUpOperationsNode(t, simP2P)
+	defer internal.TeardownDB(t, dbB)
+	defer opsB.Stop()
+	defer rsB.Stop()
+
+	simP2P.waitForSubscribers(t, 2,
+		&pb.ExitAnnounce{}, &pb.ExitRequest{}, &pb.ExitResponse{},
+		&pb.ProposerSlashingAnnounce{}, &pb.ProposerSlashingRequest{}, &pb.ProposerSlashingResponse{},
+		&pb.AttesterSlashingAnnounce{}, &pb.AttesterSlashingRequest{}, &pb.AttesterSlashingResponse{},
+	)
+
+	// The operations enter node A, which announces them once they are saved. Node B
+	// requests them from node A and saves them in turn.
+	exit := &pb.VoluntaryExit{Epoch: params.BeaconConfig().GenesisEpoch + 1, ValidatorIndex: 3}
+	proposerSlashing := &pb.ProposerSlashing{ProposerIndex: 5}
+	attesterSlashing := &pb.AttesterSlashing{
+		SlashableAttestation_1: &pb.SlashableAttestation{ValidatorIndices: []uint64{1}},
+	}
+	opsA.IncomingExitFeed().Send(exit)
+	opsA.IncomingProposerSlashingFeed().Send(proposerSlashing)
+	opsA.IncomingAttesterSlashingFeed().Send(attesterSlashing)
+
+	exitHash, err := hashutil.HashProto(exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposerSlashingHash, err := hashutil.HashProto(proposerSlashing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attesterSlashingHash, err := hashutil.HashProto(attesterSlashing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !dbB.HasExit(exitHash) || !dbB.HasProposerSlashing(proposerSlashingHash) || !dbB.HasAttesterSlashing(attesterSlashingHash) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Operations were not propagated to node B: exit %t, proposer slashing %t, attester slashing %t",
+				dbB.HasExit(exitHash), dbB.HasProposerSlashing(proposerSlashingHash), dbB.HasAttesterSlashing(attesterSlashingHash))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 )
 
@@ -49,11 +51,92 @@ var (
 		Name:  "enable-powchain",
 		Usage: "Enable a real, web3 proof-of-work chain endpoint in the beacon node",
 	}
-	// EnableDBCleanup tells the beacon node to automatically clean DB content such as block vote cache.
+	// EnableDBCleanup tells the beacon node to automatically prune DB content made obsolete by finality.
 	EnableDBCleanup = cli.BoolFlag{
 		Name:  "enable-db-cleanup",
 		Usage: "Enable automatic DB cleanup routine",
 	}
+	// DBEngineFlag defines the storage engine of the beacon chain DB.
+	DBEngineFlag = cli.StringFlag{
+		Name:  "db-engine",
+		Usage: "Storage engine of the beacon chain DB: bolt, or leveldb for a write optimized engine suited to archive nodes",
+		Value: "bolt",
+	}
+	// DBBatchSizeFlag defines the size of the DB writes the beacon node buffers before writing them to disk.
+	DBBatchSizeFlag = cli.IntFlag{
+		Name:  "db-batch-size",
+		Usage: "Size in bytes of the DB writes buffered in memory and written to disk in a single transaction, at least once per slot. 0 disables write batching",
+		Value: 16 << 20,
+	}
+	// CheckpointStateFlag defines the path to a trusted finalized beacon state which
+	// the beacon node starts from instead of the ChainStart log.
+	CheckpointStateFlag = cli.StringFlag{
+		Name:  "checkpoint-state",
+		Usage: "Path to a trusted finalized beacon state to start the beacon node from instead of genesis. Files with a .ssz extension are decoded as SSZ, other files as protobuf.",
+	}
+	// CheckpointBlockFlag defines the path to the block whose post-state is the checkpoint state.
+	CheckpointBlockFlag = cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "Path to the block whose post-state is the checkpoint state, encoded the same way as the checkpoint state.",
+	}
+	// CheckpointBlockRootFlag defines the trusted root of the checkpoint block.
+	CheckpointBlockRootFlag = cli.StringFlag{
+		Name:  "checkpoint-block-root",
+		Usage: "Hex encoded root of the checkpoint block, obtained from a trusted source.",
+	}
+	// HealthMaxHeadSlotLagFlag defines how far the chain head can fall behind the current slot
+	// before the blockchain service is reported unhealthy.
+	HealthMaxHeadSlotLagFlag = cli.Uint64Flag{
+		Name:  "health-max-head-slot-lag",
+		Usage: "Number of slots the chain head can fall behind the current slot before the node is reported unhealthy, 0 disables the check",
+		Value: 64,
+	}
+	// HealthMaxFinalityEpochLagFlag defines how far the finalized epoch can fall behind the current
+	// epoch before the blockchain service is reported unhealthy.
+	HealthMaxFinalityEpochLagFlag = cli.Uint64Flag{
+		Name:  "health-max-finality-epoch-lag",
+		Usage: "Number of epochs the finalized epoch can fall behind the current epoch before the node is reported unhealthy, 0 disables the check",
+		Value: 8,
+	}
+	// HealthMaxFullBlockBufferFlag defines how long the incoming block buffer can stay full
+	// before the blockchain service is reported unhealthy.
+	HealthMaxFullBlockBufferFlag = cli.DurationFlag{
+		Name:  "health-max-full-block-buffer",
+		Usage: "How long the incoming block buffer can stay full before the node is reported unhealthy, 0 disables the check",
+		Value: time.Minute,
+	}
+	// HealthMaxBlockFailuresFlag defines how many consecutive blocks can fail processing
+	// before the blockchain service is reported unhealthy.
+	HealthMaxBlockFailuresFlag = cli.IntFlag{
+		Name:  "health-max-block-failures",
+		Usage: "Number of consecutive blocks which can fail processing before the node is reported unhealthy, 0 disables the check",
+		Value: 10,
+	}
+	// DBFileFlag defines the file the db export command writes to, and the db import command reads from.
+	DBFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "Path to the chain export file. Files with a .ssz extension are exported as SSZ, other files as protobuf.",
+	}
+	// DBExportStartSlotFlag defines the first slot of the blocks exported by the db export command.
+	DBExportStartSlotFlag = cli.Uint64Flag{
+		Name:  "start-slot",
+		Usage: "First slot since genesis of the exported blocks",
+	}
+	// DBExportEndSlotFlag defines the last slot of the blocks exported by the db export command.
+	DBExportEndSlotFlag = cli.Uint64Flag{
+		Name:  "end-slot",
+		Usage: "Last slot since genesis of the exported blocks, the chain head by default",
+	}
+	// DBExportStateFlag tells the db export command to include the post-state of the first exported block.
+	DBExportStateFlag = cli.BoolFlag{
+		Name:  "with-state",
+		Usage: "Export the post-state of the first exported block, so the file can be imported into an empty DB",
+	}
+	// DBFixFlag tells the db verify command to rewind the chain head to the last consistent block.
+	DBFixFlag = cli.BoolFlag{
+		Name:  "fix",
+		Usage: "Rewind the chain head to the last consistent block if the DB is inconsistent",
+	}
 	// ChainStartDelay tells the beacon node to wait for a period of time from the current time, before
 	// logging chainstart.
 	ChainStartDelay = cli.Uint64Flag{
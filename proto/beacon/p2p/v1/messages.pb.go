@@ -38,6 +38,15 @@ const (
 	Topic_ATTESTATION_ANNOUNCE                Topic = 12
 	Topic_ATTESTATION_REQUEST                 Topic = 13
 	Topic_ATTESTATION_RESPONSE                Topic = 14
+	Topic_PROPOSER_SLASHING_ANNOUNCE          Topic = 15
+	Topic_PROPOSER_SLASHING_REQUEST           Topic = 16
+	Topic_PROPOSER_SLASHING_RESPONSE          Topic = 17
+	Topic_ATTESTER_SLASHING_ANNOUNCE          Topic = 18
+	Topic_ATTESTER_SLASHING_REQUEST           Topic = 19
+	Topic_ATTESTER_SLASHING_RESPONSE          Topic = 20
+	Topic_EXIT_ANNOUNCE                       Topic = 21
+	Topic_EXIT_REQUEST                        Topic = 22
+	Topic_EXIT_RESPONSE                       Topic = 23
 )
 
 var Topic_name = map[int32]string{
@@ -56,6 +65,15 @@ var Topic_name = map[int32]string{
 	12: "ATTESTATION_ANNOUNCE",
 	13: "ATTESTATION_REQUEST",
 	14: "ATTESTATION_RESPONSE",
+	15: "PROPOSER_SLASHING_ANNOUNCE",
+	16: "PROPOSER_SLASHING_REQUEST",
+	17: "PROPOSER_SLASHING_RESPONSE",
+	18: "ATTESTER_SLASHING_ANNOUNCE",
+	19: "ATTESTER_SLASHING_REQUEST",
+	20: "ATTESTER_SLASHING_RESPONSE",
+	21: "EXIT_ANNOUNCE",
+	22: "EXIT_REQUEST",
+	23: "EXIT_RESPONSE",
 }
 var Topic_value = map[string]int32{
 	"UNKNOWN":                             0,
@@ -73,13 +91,22 @@ var Topic_value = map[string]int32{
 	"ATTESTATION_ANNOUNCE":                12,
 	"ATTESTATION_REQUEST":                 13,
 	"ATTESTATION_RESPONSE":                14,
+	"PROPOSER_SLASHING_ANNOUNCE":          15,
+	"PROPOSER_SLASHING_REQUEST":           16,
+	"PROPOSER_SLASHING_RESPONSE":          17,
+	"ATTESTER_SLASHING_ANNOUNCE":          18,
+	"ATTESTER_SLASHING_REQUEST":           19,
+	"ATTESTER_SLASHING_RESPONSE":          20,
+	"EXIT_ANNOUNCE":                       21,
+	"EXIT_REQUEST":                        22,
+	"EXIT_RESPONSE":                       23,
 }
 
 func (x Topic) String() string {
 	return proto.EnumName(Topic_name, int32(x))
 }
 func (Topic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{0}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{0}
 }
 
 type BeaconBlockAnnounce struct {
@@ -94,7 +121,7 @@ func (m *BeaconBlockAnnounce) Reset()         { *m = BeaconBlockAnnounce{} }
 func (m *BeaconBlockAnnounce) String() string { return proto.CompactTextString(m) }
 func (*BeaconBlockAnnounce) ProtoMessage()    {}
 func (*BeaconBlockAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{0}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{0}
 }
 func (m *BeaconBlockAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -148,7 +175,7 @@ func (m *BeaconBlockRequest) Reset()         { *m = BeaconBlockRequest{} }
 func (m *BeaconBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*BeaconBlockRequest) ProtoMessage()    {}
 func (*BeaconBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{1}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{1}
 }
 func (m *BeaconBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -195,7 +222,7 @@ func (m *BeaconBlockRequestBySlotNumber) Reset()         { *m = BeaconBlockReque
 func (m *BeaconBlockRequestBySlotNumber) String() string { return proto.CompactTextString(m) }
 func (*BeaconBlockRequestBySlotNumber) ProtoMessage()    {}
 func (*BeaconBlockRequestBySlotNumber) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{2}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{2}
 }
 func (m *BeaconBlockRequestBySlotNumber) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -243,7 +270,7 @@ func (m *BeaconBlockResponse) Reset()         { *m = BeaconBlockResponse{} }
 func (m *BeaconBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*BeaconBlockResponse) ProtoMessage()    {}
 func (*BeaconBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{3}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{3}
 }
 func (m *BeaconBlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -298,7 +325,7 @@ func (m *BatchedBeaconBlockRequest) Reset()         { *m = BatchedBeaconBlockReq
 func (m *BatchedBeaconBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchedBeaconBlockRequest) ProtoMessage()    {}
 func (*BatchedBeaconBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{4}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{4}
 }
 func (m *BatchedBeaconBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -352,7 +379,7 @@ func (m *BatchedBeaconBlockResponse) Reset()         { *m = BatchedBeaconBlockRe
 func (m *BatchedBeaconBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchedBeaconBlockResponse) ProtoMessage()    {}
 func (*BatchedBeaconBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{5}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{5}
 }
 func (m *BatchedBeaconBlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -398,7 +425,7 @@ func (m *ChainHeadRequest) Reset()         { *m = ChainHeadRequest{} }
 func (m *ChainHeadRequest) String() string { return proto.CompactTextString(m) }
 func (*ChainHeadRequest) ProtoMessage()    {}
 func (*ChainHeadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{6}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{6}
 }
 func (m *ChainHeadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -440,7 +467,7 @@ func (m *ChainHeadResponse) Reset()         { *m = ChainHeadResponse{} }
 func (m *ChainHeadResponse) String() string { return proto.CompactTextString(m) }
 func (*ChainHeadResponse) ProtoMessage()    {}
 func (*ChainHeadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{7}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{7}
 }
 func (m *ChainHeadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -501,7 +528,7 @@ func (m *BeaconStateHashAnnounce) Reset()         { *m = BeaconStateHashAnnounce
 func (m *BeaconStateHashAnnounce) String() string { return proto.CompactTextString(m) }
 func (*BeaconStateHashAnnounce) ProtoMessage()    {}
 func (*BeaconStateHashAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{8}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{8}
 }
 func (m *BeaconStateHashAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -548,7 +575,7 @@ func (m *BeaconStateRequest) Reset()         { *m = BeaconStateRequest{} }
 func (m *BeaconStateRequest) String() string { return proto.CompactTextString(m) }
 func (*BeaconStateRequest) ProtoMessage()    {}
 func (*BeaconStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{9}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{9}
 }
 func (m *BeaconStateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -595,7 +622,7 @@ func (m *BeaconStateResponse) Reset()         { *m = BeaconStateResponse{} }
 func (m *BeaconStateResponse) String() string { return proto.CompactTextString(m) }
 func (*BeaconStateResponse) ProtoMessage()    {}
 func (*BeaconStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{10}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{10}
 }
 func (m *BeaconStateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -642,7 +669,7 @@ func (m *AttestationAnnounce) Reset()         { *m = AttestationAnnounce{} }
 func (m *AttestationAnnounce) String() string { return proto.CompactTextString(m) }
 func (*AttestationAnnounce) ProtoMessage()    {}
 func (*AttestationAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{11}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{11}
 }
 func (m *AttestationAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -689,7 +716,7 @@ func (m *AttestationRequest) Reset()         { *m = AttestationRequest{} }
 func (m *AttestationRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestationRequest) ProtoMessage()    {}
 func (*AttestationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{12}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{12}
 }
 func (m *AttestationRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -737,7 +764,7 @@ func (m *AttestationResponse) Reset()         { *m = AttestationResponse{} }
 func (m *AttestationResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestationResponse) ProtoMessage()    {}
 func (*AttestationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{13}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{13}
 }
 func (m *AttestationResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -790,7 +817,7 @@ func (m *UnseenAttestationsRequest) Reset()         { *m = UnseenAttestationsReq
 func (m *UnseenAttestationsRequest) String() string { return proto.CompactTextString(m) }
 func (*UnseenAttestationsRequest) ProtoMessage()    {}
 func (*UnseenAttestationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{14}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{14}
 }
 func (m *UnseenAttestationsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -830,7 +857,7 @@ func (m *UnseenAttestationResponse) Reset()         { *m = UnseenAttestationResp
 func (m *UnseenAttestationResponse) String() string { return proto.CompactTextString(m) }
 func (*UnseenAttestationResponse) ProtoMessage()    {}
 func (*UnseenAttestationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{15}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{15}
 }
 func (m *UnseenAttestationResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -877,7 +904,7 @@ func (m *ProposerSlashingAnnounce) Reset()         { *m = ProposerSlashingAnnoun
 func (m *ProposerSlashingAnnounce) String() string { return proto.CompactTextString(m) }
 func (*ProposerSlashingAnnounce) ProtoMessage()    {}
 func (*ProposerSlashingAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{16}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{16}
 }
 func (m *ProposerSlashingAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -924,7 +951,7 @@ func (m *ProposerSlashingRequest) Reset()         { *m = ProposerSlashingRequest
 func (m *ProposerSlashingRequest) String() string { return proto.CompactTextString(m) }
 func (*ProposerSlashingRequest) ProtoMessage()    {}
 func (*ProposerSlashingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{17}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{17}
 }
 func (m *ProposerSlashingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -972,7 +999,7 @@ func (m *ProposerSlashingResponse) Reset()         { *m = ProposerSlashingRespon
 func (m *ProposerSlashingResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposerSlashingResponse) ProtoMessage()    {}
 func (*ProposerSlashingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{18}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{18}
 }
 func (m *ProposerSlashingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1026,7 +1053,7 @@ func (m *AttesterSlashingAnnounce) Reset()         { *m = AttesterSlashingAnnoun
 func (m *AttesterSlashingAnnounce) String() string { return proto.CompactTextString(m) }
 func (*AttesterSlashingAnnounce) ProtoMessage()    {}
 func (*AttesterSlashingAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{19}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{19}
 }
 func (m *AttesterSlashingAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1073,7 +1100,7 @@ func (m *AttesterSlashingRequest) Reset()         { *m = AttesterSlashingRequest
 func (m *AttesterSlashingRequest) String() string { return proto.CompactTextString(m) }
 func (*AttesterSlashingRequest) ProtoMessage()    {}
 func (*AttesterSlashingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{20}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{20}
 }
 func (m *AttesterSlashingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1121,7 +1148,7 @@ func (m *AttesterSlashingResponse) Reset()         { *m = AttesterSlashingRespon
 func (m *AttesterSlashingResponse) String() string { return proto.CompactTextString(m) }
 func (*AttesterSlashingResponse) ProtoMessage()    {}
 func (*AttesterSlashingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{21}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{21}
 }
 func (m *AttesterSlashingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1175,7 +1202,7 @@ func (m *DepositAnnounce) Reset()         { *m = DepositAnnounce{} }
 func (m *DepositAnnounce) String() string { return proto.CompactTextString(m) }
 func (*DepositAnnounce) ProtoMessage()    {}
 func (*DepositAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{22}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{22}
 }
 func (m *DepositAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1222,7 +1249,7 @@ func (m *DepositRequest) Reset()         { *m = DepositRequest{} }
 func (m *DepositRequest) String() string { return proto.CompactTextString(m) }
 func (*DepositRequest) ProtoMessage()    {}
 func (*DepositRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{23}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{23}
 }
 func (m *DepositRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1270,7 +1297,7 @@ func (m *DepositResponse) Reset()         { *m = DepositResponse{} }
 func (m *DepositResponse) String() string { return proto.CompactTextString(m) }
 func (*DepositResponse) ProtoMessage()    {}
 func (*DepositResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{24}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{24}
 }
 func (m *DepositResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1324,7 +1351,7 @@ func (m *ExitAnnounce) Reset()         { *m = ExitAnnounce{} }
 func (m *ExitAnnounce) String() string { return proto.CompactTextString(m) }
 func (*ExitAnnounce) ProtoMessage()    {}
 func (*ExitAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{25}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{25}
 }
 func (m *ExitAnnounce) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1371,7 +1398,7 @@ func (m *ExitRequest) Reset()         { *m = ExitRequest{} }
 func (m *ExitRequest) String() string { return proto.CompactTextString(m) }
 func (*ExitRequest) ProtoMessage()    {}
 func (*ExitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{26}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{26}
 }
 func (m *ExitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1419,7 +1446,7 @@ func (m *ExitResponse) Reset()         { *m = ExitResponse{} }
 func (m *ExitResponse) String() string { return proto.CompactTextString(m) }
 func (*ExitResponse) ProtoMessage()    {}
 func (*ExitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_messages_d3945b1b4945a296, []int{27}
+	return fileDescriptor_messages_c8e1d3e7df20a72d, []int{27}
 }
 func (m *ExitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5449,60 +5476,64 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("proto/beacon/p2p/v1/messages.proto", fileDescriptor_messages_d3945b1b4945a296)
-}
-
-var fileDescriptor_messages_d3945b1b4945a296 = []byte{
-	// 813 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0xe2, 0x46,
-	0x14, 0xae, 0x03, 0x09, 0xc9, 0x81, 0x50, 0x67, 0xd2, 0x26, 0x90, 0x36, 0x84, 0x38, 0x8d, 0x4a,
-	0x2b, 0x05, 0x94, 0xf4, 0x2a, 0x97, 0x36, 0x71, 0x4b, 0x7e, 0x6a, 0x52, 0xdb, 0xb4, 0xea, 0xc5,
-	0xca, 0x6b, 0x60, 0x14, 0xa3, 0x25, 0xb6, 0x97, 0x19, 0x50, 0xf2, 0x00, 0xfb, 0x0c, 0xfb, 0x04,
-	0xfb, 0x2e, 0x7b, 0xb9, 0x8f, 0xb0, 0xca, 0x93, 0xac, 0x6c, 0x8f, 0xc1, 0x80, 0x31, 0xac, 0xb4,
-	0x77, 0x9e, 0x73, 0xbe, 0xef, 0x9b, 0xef, 0x3b, 0x73, 0x90, 0x00, 0xc1, 0x1d, 0x38, 0xd4, 0xa9,
-	0xb5, 0xb1, 0xd9, 0x71, 0xec, 0x9a, 0x7b, 0xe1, 0xd6, 0x46, 0xe7, 0xb5, 0x47, 0x4c, 0x88, 0xf9,
-	0x80, 0x49, 0xd5, 0x6f, 0xa2, 0x3d, 0x4c, 0x2d, 0x3c, 0xc0, 0xc3, 0xc7, 0x6a, 0x00, 0xab, 0xba,
-	0x17, 0x6e, 0x75, 0x74, 0x7e, 0x70, 0x14, 0xc7, 0xa5, 0xcf, 0x6e, 0x48, 0x14, 0x6e, 0x60, 0x57,
-	0xf2, 0x9b, 0x52, 0xdf, 0xe9, 0xbc, 0x11, 0x6d, 0xdb, 0x19, 0xda, 0x1d, 0x8c, 0x10, 0xa4, 0x2d,
-	0x93, 0x58, 0x05, 0xae, 0xcc, 0x55, 0x72, 0xaa, 0xff, 0x8d, 0x8e, 0x20, 0x4b, 0xfa, 0x0e, 0x35,
-	0xec, 0xe1, 0x63, 0x1b, 0x0f, 0x0a, 0x6b, 0x65, 0xae, 0x92, 0x56, 0xc1, 0x2b, 0x29, 0x7e, 0x45,
-	0xa8, 0x00, 0x8a, 0x68, 0xa9, 0xf8, 0xed, 0x10, 0x13, 0x1a, 0x27, 0x25, 0x88, 0x50, 0x9a, 0x47,
-	0x4a, 0xcf, 0xda, 0x58, 0x6b, 0xf6, 0x32, 0x6e, 0xee, 0xb2, 0xf7, 0xdc, 0x94, 0x73, 0x15, 0x13,
-	0xd7, 0xb1, 0x09, 0x46, 0x97, 0xb0, 0xde, 0xf6, 0x0a, 0x3e, 0x25, 0x7b, 0x71, 0x52, 0x8d, 0x9f,
-	0x4c, 0x35, 0xca, 0x0d, 0x18, 0x48, 0x86, 0xac, 0x49, 0x29, 0x26, 0xd4, 0xa4, 0x3d, 0xc7, 0xf6,
-	0x03, 0x26, 0x08, 0x88, 0x13, 0xa8, 0x1a, 0xe5, 0x09, 0x2d, 0x28, 0x4a, 0x26, 0xed, 0x58, 0xb8,
-	0x1b, 0x33, 0x8d, 0x43, 0x00, 0x42, 0xcd, 0x01, 0x35, 0xbc, 0x28, 0x2c, 0xd6, 0x96, 0x5f, 0xf1,
-	0xc2, 0xa3, 0x22, 0x6c, 0x62, 0xbb, 0x1b, 0x34, 0x83, 0x01, 0x67, 0xb0, 0xdd, 0xf5, 0x5a, 0x82,
-	0x05, 0x07, 0x71, 0xb2, 0x2c, 0xf6, 0x0d, 0xe4, 0xdb, 0x41, 0xd7, 0xf0, 0xc3, 0x90, 0x02, 0x57,
-	0x4e, 0xad, 0x9a, 0x7f, 0x9b, 0x51, 0xfd, 0x13, 0x11, 0x10, 0xf0, 0x75, 0xcb, 0xec, 0xd9, 0x0d,
-	0x6c, 0x76, 0x99, 0x6f, 0x61, 0x04, 0x3b, 0x91, 0x1a, 0xbb, 0x34, 0x6e, 0x4b, 0x10, 0xa4, 0x23,
-	0xee, 0xfd, 0xef, 0xc9, 0x9b, 0xa4, 0xbe, 0xf6, 0x4d, 0x84, 0x33, 0xd8, 0x0f, 0xaa, 0x1a, 0x35,
-	0x29, 0x6e, 0x98, 0xc4, 0x4a, 0xda, 0xd1, 0xc9, 0x0a, 0xfa, 0xf0, 0xa4, 0x15, 0x7c, 0x15, 0xae,
-	0x0f, 0x43, 0xb2, 0x48, 0x7f, 0x42, 0x2e, 0xf0, 0x64, 0x78, 0xcf, 0x89, 0x57, 0xdb, 0xa2, 0x40,
-	0x22, 0xdb, 0x9e, 0x1c, 0x84, 0xdf, 0x60, 0x37, 0xb2, 0x20, 0xcb, 0x3c, 0x47, 0x77, 0x29, 0xc1,
-	0xb3, 0x3b, 0x25, 0x9a, 0xf8, 0x0c, 0xdf, 0x68, 0x97, 0x7f, 0x82, 0x62, 0xcb, 0x26, 0x18, 0xdb,
-	0x11, 0x04, 0x09, 0x77, 0xa2, 0x1b, 0xd3, 0x1c, 0x9b, 0xfa, 0x0b, 0x72, 0x11, 0xa1, 0xa5, 0xeb,
-	0x18, 0x95, 0x98, 0x22, 0x0a, 0x55, 0x28, 0xdc, 0x0f, 0x1c, 0xd7, 0x21, 0x78, 0xa0, 0xf5, 0x4d,
-	0x62, 0xf5, 0xec, 0x87, 0xc4, 0x71, 0x9e, 0xc1, 0xfe, 0x2c, 0x3e, 0x69, 0xa6, 0xef, 0xb8, 0x79,
-	0xfd, 0xc4, 0xc9, 0xb6, 0x60, 0xc7, 0x65, 0x78, 0x83, 0x30, 0x02, 0x9b, 0x6f, 0x65, 0x51, 0xba,
-	0xb9, 0x0b, 0x78, 0x77, 0xa6, 0xe2, 0xc5, 0x0c, 0x66, 0xb0, 0x7a, 0xcc, 0x59, 0xfc, 0xb2, 0x98,
-	0xf3, 0xf8, 0xe4, 0x98, 0x21, 0x7e, 0xe5, 0x98, 0x73, 0x17, 0xf0, 0xb3, 0x15, 0xe1, 0x14, 0xbe,
-	0xbf, 0xc2, 0xae, 0x43, 0x7a, 0x34, 0x31, 0xdd, 0x2f, 0x90, 0x67, 0xb0, 0xa4, 0x50, 0xaf, 0xc7,
-	0x62, 0x89, 0x51, 0x2e, 0x21, 0xd3, 0x0d, 0x60, 0x2c, 0xc0, 0xd1, 0xa2, 0x00, 0xa1, 0x5a, 0x88,
-	0x17, 0x04, 0xc8, 0xc9, 0x4f, 0x4b, 0xbc, 0x1e, 0x43, 0xd6, 0xc3, 0x24, 0xff, 0x70, 0x73, 0x01,
-	0x24, 0xc1, 0xe5, 0x1d, 0xe4, 0x47, 0x4e, 0x7f, 0x68, 0x53, 0x73, 0xf0, 0x6c, 0xe0, 0xa7, 0xb1,
-	0xd9, 0xd3, 0x45, 0x66, 0xff, 0x0d, 0xd1, 0xbe, 0xf4, 0xf6, 0x28, 0x7a, 0xfc, 0xfd, 0x43, 0x0a,
-	0xd6, 0x75, 0xc7, 0xed, 0x75, 0x50, 0x16, 0x32, 0x2d, 0xe5, 0x56, 0x69, 0xfe, 0xa7, 0xf0, 0xdf,
-	0xa1, 0x22, 0xfc, 0x28, 0xc9, 0x62, 0xbd, 0xa9, 0x18, 0xd2, 0x5d, 0xb3, 0x7e, 0x6b, 0x88, 0x8a,
-	0xd2, 0x6c, 0x29, 0x75, 0x99, 0xe7, 0x50, 0x01, 0x7e, 0x98, 0x6a, 0xa9, 0xf2, 0x3f, 0x2d, 0x59,
-	0xd3, 0xf9, 0x35, 0xf4, 0x2b, 0x9c, 0xc4, 0x75, 0x0c, 0xe9, 0x7f, 0x43, 0xbb, 0x6b, 0xea, 0x86,
-	0xd2, 0xfa, 0x5b, 0x92, 0x55, 0x3e, 0x35, 0xa7, 0xae, 0xca, 0xda, 0x7d, 0x53, 0xd1, 0x64, 0x3e,
-	0x8d, 0xca, 0xf0, 0xb3, 0x24, 0xea, 0xf5, 0x86, 0x7c, 0x65, 0xc4, 0xde, 0xb2, 0x8e, 0x8e, 0xe1,
-	0x70, 0x01, 0x82, 0x89, 0x6c, 0xa0, 0x3d, 0x40, 0xf5, 0x86, 0x78, 0xad, 0x18, 0x0d, 0x59, 0xbc,
-	0x1a, 0x53, 0x33, 0x68, 0x1f, 0x76, 0xa7, 0xea, 0x8c, 0xb0, 0x89, 0x4a, 0x70, 0xc0, 0xb4, 0x34,
-	0x5d, 0xd4, 0x65, 0xa3, 0x21, 0x6a, 0x8d, 0x49, 0xe6, 0xad, 0x48, 0xe6, 0xa0, 0x1f, 0x4a, 0x42,
-	0x24, 0x4a, 0xd8, 0x61, 0xa2, 0x59, 0x8f, 0x24, 0xea, 0xba, 0xec, 0xd5, 0xaf, 0x9b, 0xca, 0x44,
-	0x2e, 0xe7, 0xf9, 0x88, 0x76, 0x42, 0xb5, 0xed, 0x59, 0xca, 0x58, 0x2c, 0x2f, 0xe5, 0x3e, 0xbe,
-	0x94, 0xb8, 0x4f, 0x2f, 0x25, 0xee, 0xf3, 0x4b, 0x89, 0x6b, 0x6f, 0xf8, 0x7f, 0xca, 0xfe, 0xf8,
-	0x12, 0x00, 0x00, 0xff, 0xff, 0xf5, 0x91, 0x83, 0xcb, 0xf3, 0x09, 0x00, 0x00,
+	proto.RegisterFile("proto/beacon/p2p/v1/messages.proto", fileDescriptor_messages_c8e1d3e7df20a72d)
+}
+
+var fileDescriptor_messages_c8e1d3e7df20a72d = []byte{
+	// 871 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xad, 0x56, 0xcb, 0x6e, 0xda, 0x50,
+	0x10, 0x2d, 0x49, 0x08, 0xc9, 0x00, 0x89, 0xb9, 0xe4, 0x01, 0x69, 0xf3, 0x72, 0x1a, 0x35, 0xad,
+	0x54, 0x50, 0xd2, 0x55, 0x96, 0x36, 0x71, 0x03, 0x49, 0x6a, 0xa8, 0x6d, 0xfa, 0x58, 0x54, 0x2e,
+	0x8f, 0xab, 0x80, 0x4a, 0x6c, 0x17, 0x1b, 0x94, 0x7c, 0x40, 0xbf, 0xa1, 0x8b, 0xfe, 0x50, 0x97,
+	0xfd, 0x84, 0xaa, 0x5f, 0xd2, 0xeb, 0x27, 0x06, 0x1b, 0x43, 0xa5, 0x2e, 0x2c, 0xd9, 0x73, 0xce,
+	0x9c, 0x99, 0x33, 0x77, 0x2c, 0x1b, 0x68, 0xad, 0xaf, 0x1a, 0x6a, 0xb1, 0x89, 0x1b, 0x2d, 0x55,
+	0x29, 0x6a, 0x67, 0x5a, 0x71, 0x78, 0x5a, 0xbc, 0xc3, 0xba, 0xde, 0xb8, 0xc5, 0x7a, 0xc1, 0x02,
+	0xd1, 0x16, 0x36, 0x3a, 0xb8, 0x8f, 0x07, 0x77, 0x05, 0x9b, 0x56, 0x20, 0xb4, 0xc2, 0xf0, 0x74,
+	0x67, 0x3f, 0x2c, 0xd7, 0x78, 0xd0, 0xdc, 0x44, 0xfa, 0x0a, 0xb2, 0xac, 0x05, 0xb2, 0x3d, 0xb5,
+	0xf5, 0x85, 0x51, 0x14, 0x75, 0xa0, 0xb4, 0x30, 0x42, 0xb0, 0xd4, 0x69, 0xe8, 0x9d, 0x5c, 0xec,
+	0x20, 0x76, 0x92, 0x12, 0xac, 0x7b, 0xb4, 0x0f, 0x49, 0xbd, 0xa7, 0x1a, 0xb2, 0x32, 0xb8, 0x6b,
+	0xe2, 0x7e, 0x6e, 0x81, 0x40, 0x4b, 0x02, 0x98, 0x21, 0xde, 0x8a, 0xd0, 0x27, 0x80, 0x7c, 0x5a,
+	0x02, 0xfe, 0x3a, 0xc0, 0xba, 0x11, 0x26, 0x45, 0x33, 0xb0, 0x17, 0x64, 0xb2, 0x0f, 0xa2, 0xa7,
+	0x35, 0x59, 0x2c, 0x16, 0x28, 0xf6, 0x3d, 0x36, 0xd6, 0xb9, 0x80, 0x75, 0x4d, 0x55, 0x74, 0x8c,
+	0xce, 0x21, 0xde, 0x34, 0x03, 0x56, 0x4a, 0xf2, 0xec, 0xa8, 0x10, 0x3e, 0x99, 0x82, 0x3f, 0xd7,
+	0xce, 0x40, 0x1c, 0x24, 0x1b, 0x86, 0x41, 0x3a, 0x69, 0x18, 0x5d, 0x55, 0xb1, 0x0c, 0x46, 0x08,
+	0x30, 0x23, 0xaa, 0xe0, 0xcf, 0xa3, 0xeb, 0x90, 0x67, 0x1b, 0x46, 0xab, 0x83, 0xdb, 0x21, 0xd3,
+	0xd8, 0x05, 0x20, 0xbc, 0xbe, 0x21, 0x9b, 0x56, 0x1c, 0x5b, 0xab, 0x56, 0xc4, 0x34, 0x8f, 0xf2,
+	0xb0, 0x82, 0x95, 0xb6, 0x0d, 0xda, 0x03, 0x4e, 0x90, 0x67, 0x13, 0xa2, 0x3b, 0xb0, 0x13, 0x26,
+	0xeb, 0xd8, 0xbe, 0x82, 0xb5, 0xa6, 0x8d, 0xca, 0x96, 0x19, 0x9d, 0x68, 0x2f, 0xce, 0xeb, 0x3f,
+	0xed, 0xa4, 0x5a, 0x4f, 0x3a, 0x8d, 0x80, 0x2a, 0x75, 0x1a, 0x5d, 0xa5, 0x8c, 0x1b, 0x6d, 0xa7,
+	0x6f, 0x7a, 0x08, 0x19, 0x5f, 0xcc, 0x29, 0x1a, 0xb6, 0x25, 0x24, 0xe6, 0xeb, 0xde, 0xba, 0x1f,
+	0x9d, 0xc9, 0xe2, 0xbf, 0x9e, 0x09, 0xfd, 0x12, 0xb6, 0xed, 0xa8, 0x48, 0xa6, 0x8b, 0xcb, 0xa4,
+	0x42, 0xd4, 0x8e, 0x8e, 0x56, 0xd0, 0xa2, 0x47, 0xad, 0xe0, 0x27, 0x77, 0x7d, 0x1c, 0xa6, 0x63,
+	0xe9, 0x35, 0xa4, 0xec, 0x9e, 0x64, 0xf3, 0x38, 0xf1, 0x7c, 0x5b, 0x64, 0x4b, 0x24, 0x9b, 0xa3,
+	0x07, 0xfa, 0x39, 0x64, 0x7d, 0x0b, 0x32, 0xab, 0x67, 0xff, 0x2e, 0x45, 0xf4, 0xac, 0x8d, 0x89,
+	0x46, 0x1e, 0xc3, 0x7f, 0xda, 0xe5, 0xc7, 0x90, 0xaf, 0x93, 0x12, 0x58, 0xf1, 0x31, 0x74, 0x77,
+	0x27, 0xda, 0x21, 0xa0, 0xd7, 0xd4, 0x25, 0xa4, 0x7c, 0x42, 0x33, 0xd7, 0xd1, 0x2f, 0x31, 0x96,
+	0x48, 0x17, 0x20, 0x57, 0xeb, 0xab, 0x9a, 0xaa, 0xe3, 0xbe, 0xd8, 0x23, 0xd6, 0xba, 0xca, 0x6d,
+	0xe4, 0x38, 0xc9, 0xc6, 0x4c, 0xf2, 0xa3, 0x66, 0xfa, 0x2d, 0x16, 0xd4, 0x8f, 0x9c, 0x6c, 0x1d,
+	0x32, 0x9a, 0xc3, 0x27, 0xef, 0xa9, 0x9d, 0xe0, 0xcc, 0xf7, 0x64, 0x9a, 0xbb, 0x40, 0x01, 0x4a,
+	0x9b, 0x88, 0x98, 0x36, 0xed, 0x19, 0xcc, 0x6f, 0x73, 0x92, 0x3f, 0xcb, 0x66, 0x90, 0x1f, 0x6d,
+	0xd3, 0xe5, 0xcf, 0x6d, 0x33, 0x50, 0x80, 0x9a, 0x8c, 0xd0, 0xc7, 0xb0, 0x7e, 0x81, 0x89, 0xf3,
+	0xae, 0x11, 0xe9, 0xee, 0x29, 0xac, 0x39, 0xb4, 0x28, 0x53, 0x9f, 0x3d, 0xb1, 0x48, 0x2b, 0xe7,
+	0x90, 0x68, 0xdb, 0x34, 0xc7, 0xc0, 0xfe, 0x34, 0x03, 0xae, 0x9a, 0xcb, 0xa7, 0x69, 0x48, 0x71,
+	0xf7, 0x33, 0x7a, 0x3d, 0x84, 0xa4, 0xc9, 0x89, 0x7e, 0x71, 0x53, 0x36, 0x25, 0xa2, 0xcb, 0x1b,
+	0x58, 0x1b, 0xaa, 0xbd, 0x81, 0x42, 0xbe, 0x05, 0x0f, 0x32, 0xbe, 0xf7, 0x9a, 0x3d, 0x9e, 0xd6,
+	0xec, 0x3b, 0x97, 0x6d, 0x49, 0xa7, 0x87, 0xfe, 0xc7, 0x17, 0x3f, 0xe2, 0x10, 0x97, 0x54, 0xad,
+	0xdb, 0x42, 0x49, 0x48, 0xd4, 0xf9, 0x6b, 0xbe, 0xfa, 0x9e, 0xa7, 0x1e, 0x91, 0xef, 0xcb, 0x26,
+	0xcb, 0x31, 0xa5, 0x2a, 0x2f, 0xb3, 0x37, 0xd5, 0xd2, 0xb5, 0xcc, 0xf0, 0x7c, 0xb5, 0xce, 0x97,
+	0x38, 0x2a, 0x86, 0x72, 0xb0, 0x31, 0x06, 0x09, 0xdc, 0xdb, 0x3a, 0x27, 0x4a, 0xd4, 0x02, 0x7a,
+	0x06, 0x47, 0x61, 0x88, 0xcc, 0x7e, 0x94, 0xc5, 0x9b, 0xaa, 0x24, 0xf3, 0xf5, 0x37, 0x2c, 0x27,
+	0x50, 0x8b, 0x01, 0x75, 0x81, 0x13, 0x6b, 0x55, 0x5e, 0xe4, 0xa8, 0x25, 0x74, 0x00, 0x4f, 0x58,
+	0x46, 0x2a, 0x95, 0xb9, 0x0b, 0x39, 0xb4, 0x4a, 0x1c, 0x1d, 0xc2, 0xee, 0x14, 0x86, 0x23, 0xb2,
+	0x8c, 0xb6, 0x00, 0x95, 0xca, 0x4c, 0x85, 0x97, 0xcb, 0x1c, 0x73, 0xe1, 0xa5, 0x26, 0xd0, 0x36,
+	0x64, 0xc7, 0xe2, 0x4e, 0xc2, 0x0a, 0xda, 0x23, 0xdf, 0x4c, 0x5b, 0x4b, 0x94, 0x18, 0x89, 0x93,
+	0xcb, 0x8c, 0x58, 0x1e, 0x79, 0x5e, 0xf5, 0x79, 0xb6, 0x71, 0x57, 0x12, 0x7c, 0x56, 0x5c, 0xc4,
+	0x11, 0x4d, 0x9a, 0x49, 0x8c, 0x24, 0x71, 0x66, 0xbc, 0x42, 0x70, 0x4f, 0x2e, 0x65, 0xf6, 0xe1,
+	0x47, 0x5c, 0xb5, 0xf4, 0x64, 0x8a, 0x27, 0xb6, 0x66, 0x76, 0x58, 0x13, 0xaa, 0xb5, 0xaa, 0xc8,
+	0x09, 0x64, 0x98, 0xa4, 0xbd, 0x0a, 0x7f, 0x39, 0x92, 0x5c, 0x27, 0xff, 0x0b, 0xf9, 0x20, 0xee,
+	0x0a, 0x53, 0xe1, 0xe9, 0x9e, 0x7c, 0xc6, 0xc4, 0xed, 0xc2, 0xa1, 0xf2, 0xc8, 0x94, 0x0f, 0xe2,
+	0xae, 0x7c, 0x36, 0x3c, 0xdd, 0x93, 0xdf, 0x40, 0x19, 0x48, 0x73, 0x1f, 0x2a, 0xd2, 0x48, 0x71,
+	0x13, 0x51, 0x64, 0xd5, 0xcd, 0x90, 0x2b, 0xb2, 0xe5, 0x91, 0xbc, 0xbc, 0x6d, 0x36, 0xf5, 0xf3,
+	0xcf, 0x5e, 0xec, 0x17, 0xb9, 0x7e, 0x93, 0xab, 0xb9, 0x6c, 0xfd, 0x8a, 0xbe, 0xfa, 0x0b, 0x31,
+	0x1d, 0xaf, 0xf0, 0xe9, 0x0a, 0x00, 0x00,
 }
@@ -53,7 +53,7 @@ func (x ValidatorRole) String() string {
 	return proto.EnumName(ValidatorRole_name, int32(x))
 }
 func (ValidatorRole) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{0}
+	return fileDescriptor_services_62743181e5545e6e, []int{0}
 }
 
 type CommitteeRequest struct {
@@ -68,7 +68,7 @@ func (m *CommitteeRequest) Reset()         { *m = CommitteeRequest{} }
 func (m *CommitteeRequest) String() string { return proto.CompactTextString(m) }
 func (*CommitteeRequest) ProtoMessage()    {}
 func (*CommitteeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{0}
+	return fileDescriptor_services_62743181e5545e6e, []int{0}
 }
 func (m *CommitteeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -123,7 +123,7 @@ func (m *CommitteeResponse) Reset()         { *m = CommitteeResponse{} }
 func (m *CommitteeResponse) String() string { return proto.CompactTextString(m) }
 func (*CommitteeResponse) ProtoMessage()    {}
 func (*CommitteeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{1}
+	return fileDescriptor_services_62743181e5545e6e, []int{1}
 }
 func (m *CommitteeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -177,7 +177,7 @@ func (m *AttestationInfoRequest) Reset()         { *m = AttestationInfoRequest{}
 func (m *AttestationInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestationInfoRequest) ProtoMessage()    {}
 func (*AttestationInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{2}
+	return fileDescriptor_services_62743181e5545e6e, []int{2}
 }
 func (m *AttestationInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -228,7 +228,7 @@ func (m *AttestationInfoResponse) Reset()         { *m = AttestationInfoResponse
 func (m *AttestationInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestationInfoResponse) ProtoMessage()    {}
 func (*AttestationInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{3}
+	return fileDescriptor_services_62743181e5545e6e, []int{3}
 }
 func (m *AttestationInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -303,7 +303,7 @@ func (m *PendingAttestationsRequest) Reset()         { *m = PendingAttestationsR
 func (m *PendingAttestationsRequest) String() string { return proto.CompactTextString(m) }
 func (*PendingAttestationsRequest) ProtoMessage()    {}
 func (*PendingAttestationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{4}
+	return fileDescriptor_services_62743181e5545e6e, []int{4}
 }
 func (m *PendingAttestationsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -350,7 +350,7 @@ func (m *PendingAttestationsResponse) Reset()         { *m = PendingAttestations
 func (m *PendingAttestationsResponse) String() string { return proto.CompactTextString(m) }
 func (*PendingAttestationsResponse) ProtoMessage()    {}
 func (*PendingAttestationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{5}
+	return fileDescriptor_services_62743181e5545e6e, []int{5}
 }
 func (m *PendingAttestationsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -397,7 +397,7 @@ func (m *CrosslinkCommitteeRequest) Reset()         { *m = CrosslinkCommitteeReq
 func (m *CrosslinkCommitteeRequest) String() string { return proto.CompactTextString(m) }
 func (*CrosslinkCommitteeRequest) ProtoMessage()    {}
 func (*CrosslinkCommitteeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{6}
+	return fileDescriptor_services_62743181e5545e6e, []int{6}
 }
 func (m *CrosslinkCommitteeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -445,7 +445,7 @@ func (m *CrosslinkCommitteeResponse) Reset()         { *m = CrosslinkCommitteeRe
 func (m *CrosslinkCommitteeResponse) String() string { return proto.CompactTextString(m) }
 func (*CrosslinkCommitteeResponse) ProtoMessage()    {}
 func (*CrosslinkCommitteeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{7}
+	return fileDescriptor_services_62743181e5545e6e, []int{7}
 }
 func (m *CrosslinkCommitteeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,7 +500,7 @@ func (m *ChainStartResponse) Reset()         { *m = ChainStartResponse{} }
 func (m *ChainStartResponse) String() string { return proto.CompactTextString(m) }
 func (*ChainStartResponse) ProtoMessage()    {}
 func (*ChainStartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{8}
+	return fileDescriptor_services_62743181e5545e6e, []int{8}
 }
 func (m *ChainStartResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -559,7 +559,7 @@ func (m *ProposeRequest) Reset()         { *m = ProposeRequest{} }
 func (m *ProposeRequest) String() string { return proto.CompactTextString(m) }
 func (*ProposeRequest) ProtoMessage()    {}
 func (*ProposeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{9}
+	return fileDescriptor_services_62743181e5545e6e, []int{9}
 }
 func (m *ProposeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -641,7 +641,7 @@ func (m *ProposeResponse) Reset()         { *m = ProposeResponse{} }
 func (m *ProposeResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposeResponse) ProtoMessage()    {}
 func (*ProposeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{10}
+	return fileDescriptor_services_62743181e5545e6e, []int{10}
 }
 func (m *ProposeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -688,7 +688,7 @@ func (m *ProposerIndexRequest) Reset()         { *m = ProposerIndexRequest{} }
 func (m *ProposerIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*ProposerIndexRequest) ProtoMessage()    {}
 func (*ProposerIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{11}
+	return fileDescriptor_services_62743181e5545e6e, []int{11}
 }
 func (m *ProposerIndexRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -735,7 +735,7 @@ func (m *ProposerIndexResponse) Reset()         { *m = ProposerIndexResponse{} }
 func (m *ProposerIndexResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposerIndexResponse) ProtoMessage()    {}
 func (*ProposerIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{12}
+	return fileDescriptor_services_62743181e5545e6e, []int{12}
 }
 func (m *ProposerIndexResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -782,7 +782,7 @@ func (m *StateRootResponse) Reset()         { *m = StateRootResponse{} }
 func (m *StateRootResponse) String() string { return proto.CompactTextString(m) }
 func (*StateRootResponse) ProtoMessage()    {}
 func (*StateRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{13}
+	return fileDescriptor_services_62743181e5545e6e, []int{13}
 }
 func (m *StateRootResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -829,7 +829,7 @@ func (m *AttestResponse) Reset()         { *m = AttestResponse{} }
 func (m *AttestResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestResponse) ProtoMessage()    {}
 func (*AttestResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{14}
+	return fileDescriptor_services_62743181e5545e6e, []int{14}
 }
 func (m *AttestResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -879,7 +879,7 @@ func (m *Assignment) Reset()         { *m = Assignment{} }
 func (m *Assignment) String() string { return proto.CompactTextString(m) }
 func (*Assignment) ProtoMessage()    {}
 func (*Assignment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{15}
+	return fileDescriptor_services_62743181e5545e6e, []int{15}
 }
 func (m *Assignment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -947,7 +947,7 @@ func (m *ValidatorIndexRequest) Reset()         { *m = ValidatorIndexRequest{} }
 func (m *ValidatorIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*ValidatorIndexRequest) ProtoMessage()    {}
 func (*ValidatorIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{16}
+	return fileDescriptor_services_62743181e5545e6e, []int{16}
 }
 func (m *ValidatorIndexRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -994,7 +994,7 @@ func (m *ValidatorIndexResponse) Reset()         { *m = ValidatorIndexResponse{}
 func (m *ValidatorIndexResponse) String() string { return proto.CompactTextString(m) }
 func (*ValidatorIndexResponse) ProtoMessage()    {}
 func (*ValidatorIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{17}
+	return fileDescriptor_services_62743181e5545e6e, []int{17}
 }
 func (m *ValidatorIndexResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1030,6 +1030,100 @@ func (m *ValidatorIndexResponse) GetIndex() uint64 {
 	return 0
 }
 
+type ValidatorIndicesRequest struct {
+	PublicKeys           [][]byte `protobuf:"bytes,1,rep,name=public_keys,json=publicKeys,proto3" json:"public_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorIndicesRequest) Reset()         { *m = ValidatorIndicesRequest{} }
+func (m *ValidatorIndicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidatorIndicesRequest) ProtoMessage()    {}
+func (*ValidatorIndicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_services_62743181e5545e6e, []int{18}
+}
+func (m *ValidatorIndicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorIndicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorIndicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ValidatorIndicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorIndicesRequest.Merge(dst, src)
+}
+func (m *ValidatorIndicesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorIndicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorIndicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorIndicesRequest proto.InternalMessageInfo
+
+func (m *ValidatorIndicesRequest) GetPublicKeys() [][]byte {
+	if m != nil {
+		return m.PublicKeys
+	}
+	return nil
+}
+
+type ValidatorIndicesResponse struct {
+	Indices              []uint64 `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorIndicesResponse) Reset()         { *m = ValidatorIndicesResponse{} }
+func (m *ValidatorIndicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidatorIndicesResponse) ProtoMessage()    {}
+func (*ValidatorIndicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_services_62743181e5545e6e, []int{19}
+}
+func (m *ValidatorIndicesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorIndicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorIndicesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ValidatorIndicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorIndicesResponse.Merge(dst, src)
+}
+func (m *ValidatorIndicesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorIndicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorIndicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorIndicesResponse proto.InternalMessageInfo
+
+func (m *ValidatorIndicesResponse) GetIndices() []uint64 {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
 type ValidatorEpochAssignmentsRequest struct {
 	EpochStart           uint64   `protobuf:"varint,1,opt,name=epoch_start,json=epochStart,proto3" json:"epoch_start,omitempty"`
 	PublicKey            []byte   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
@@ -1042,7 +1136,7 @@ func (m *ValidatorEpochAssignmentsRequest) Reset()         { *m = ValidatorEpoch
 func (m *ValidatorEpochAssignmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ValidatorEpochAssignmentsRequest) ProtoMessage()    {}
 func (*ValidatorEpochAssignmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{18}
+	return fileDescriptor_services_62743181e5545e6e, []int{20}
 }
 func (m *ValidatorEpochAssignmentsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1096,7 +1190,7 @@ func (m *ValidatorEpochAssignmentsResponse) Reset()         { *m = ValidatorEpoc
 func (m *ValidatorEpochAssignmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ValidatorEpochAssignmentsResponse) ProtoMessage()    {}
 func (*ValidatorEpochAssignmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{19}
+	return fileDescriptor_services_62743181e5545e6e, []int{21}
 }
 func (m *ValidatorEpochAssignmentsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1143,7 +1237,7 @@ func (m *PendingDepositsResponse) Reset()         { *m = PendingDepositsResponse
 func (m *PendingDepositsResponse) String() string { return proto.CompactTextString(m) }
 func (*PendingDepositsResponse) ProtoMessage()    {}
 func (*PendingDepositsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{20}
+	return fileDescriptor_services_62743181e5545e6e, []int{22}
 }
 func (m *PendingDepositsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1193,7 +1287,7 @@ func (m *CommitteeAssignmentResponse) Reset()         { *m = CommitteeAssignment
 func (m *CommitteeAssignmentResponse) String() string { return proto.CompactTextString(m) }
 func (*CommitteeAssignmentResponse) ProtoMessage()    {}
 func (*CommitteeAssignmentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{21}
+	return fileDescriptor_services_62743181e5545e6e, []int{23}
 }
 func (m *CommitteeAssignmentResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1261,7 +1355,7 @@ func (m *Eth1DataResponse) Reset()         { *m = Eth1DataResponse{} }
 func (m *Eth1DataResponse) String() string { return proto.CompactTextString(m) }
 func (*Eth1DataResponse) ProtoMessage()    {}
 func (*Eth1DataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_services_5b19002a860859a4, []int{22}
+	return fileDescriptor_services_62743181e5545e6e, []int{24}
 }
 func (m *Eth1DataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1316,6 +1410,8 @@ func init() {
 	proto.RegisterType((*Assignment)(nil), "ethereum.beacon.rpc.v1.Assignment")
 	proto.RegisterType((*ValidatorIndexRequest)(nil), "ethereum.beacon.rpc.v1.ValidatorIndexRequest")
 	proto.RegisterType((*ValidatorIndexResponse)(nil), "ethereum.beacon.rpc.v1.ValidatorIndexResponse")
+	proto.RegisterType((*ValidatorIndicesRequest)(nil), "ethereum.beacon.rpc.v1.ValidatorIndicesRequest")
+	proto.RegisterType((*ValidatorIndicesResponse)(nil), "ethereum.beacon.rpc.v1.ValidatorIndicesResponse")
 	proto.RegisterType((*ValidatorEpochAssignmentsRequest)(nil), "ethereum.beacon.rpc.v1.ValidatorEpochAssignmentsRequest")
 	proto.RegisterType((*ValidatorEpochAssignmentsResponse)(nil), "ethereum.beacon.rpc.v1.ValidatorEpochAssignmentsResponse")
 	proto.RegisterType((*PendingDepositsResponse)(nil), "ethereum.beacon.rpc.v1.PendingDepositsResponse")
@@ -1881,6 +1977,7 @@ var _ProposerService_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ValidatorServiceClient interface {
 	ValidatorIndex(ctx context.Context, in *ValidatorIndexRequest, opts ...grpc.CallOption) (*ValidatorIndexResponse, error)
+	ValidatorIndices(ctx context.Context, in *ValidatorIndicesRequest, opts ...grpc.CallOption) (*ValidatorIndicesResponse, error)
 	ValidatorEpochAssignments(ctx context.Context, in *ValidatorEpochAssignmentsRequest, opts ...grpc.CallOption) (*ValidatorEpochAssignmentsResponse, error)
 	ValidatorCommitteeAtSlot(ctx context.Context, in *CommitteeRequest, opts ...grpc.CallOption) (*CommitteeResponse, error)
 	NextEpochCommitteeAssignment(ctx context.Context, in *ValidatorIndexRequest, opts ...grpc.CallOption) (*CommitteeAssignmentResponse, error)
@@ -1903,6 +2000,15 @@ func (c *validatorServiceClient) ValidatorIndex(ctx context.Context, in *Validat
 	return out, nil
 }
 
+func (c *validatorServiceClient) ValidatorIndices(ctx context.Context, in *ValidatorIndicesRequest, opts ...grpc.CallOption) (*ValidatorIndicesResponse, error) {
+	out := new(ValidatorIndicesResponse)
+	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.ValidatorService/ValidatorIndices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *validatorServiceClient) ValidatorEpochAssignments(ctx context.Context, in *ValidatorEpochAssignmentsRequest, opts ...grpc.CallOption) (*ValidatorEpochAssignmentsResponse, error) {
 	out := new(ValidatorEpochAssignmentsResponse)
 	err := c.cc.Invoke(ctx, "/ethereum.beacon.rpc.v1.ValidatorService/ValidatorEpochAssignments", in, out, opts...)
@@ -1933,6 +2039,7 @@ func (c *validatorServiceClient) NextEpochCommitteeAssignment(ctx context.Contex
 // ValidatorServiceServer is the server API for ValidatorService service.
 type ValidatorServiceServer interface {
 	ValidatorIndex(context.Context, *ValidatorIndexRequest) (*ValidatorIndexResponse, error)
+	ValidatorIndices(context.Context, *ValidatorIndicesRequest) (*ValidatorIndicesResponse, error)
 	ValidatorEpochAssignments(context.Context, *ValidatorEpochAssignmentsRequest) (*ValidatorEpochAssignmentsResponse, error)
 	ValidatorCommitteeAtSlot(context.Context, *CommitteeRequest) (*CommitteeResponse, error)
 	NextEpochCommitteeAssignment(context.Context, *ValidatorIndexRequest) (*CommitteeAssignmentResponse, error)
@@ -1960,6 +2067,24 @@ func _ValidatorService_ValidatorIndex_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ValidatorService_ValidatorIndices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorIndicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServiceServer).ValidatorIndices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethereum.beacon.rpc.v1.ValidatorService/ValidatorIndices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServiceServer).ValidatorIndices(ctx, req.(*ValidatorIndicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ValidatorService_ValidatorEpochAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ValidatorEpochAssignmentsRequest)
 	if err := dec(in); err != nil {
@@ -2022,6 +2147,10 @@ var _ValidatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ValidatorIndex",
 			Handler:    _ValidatorService_ValidatorIndex_Handler,
 		},
+		{
+			MethodName: "ValidatorIndices",
+			Handler:    _ValidatorService_ValidatorIndices_Handler,
+		},
 		{
 			MethodName: "ValidatorEpochAssignments",
 			Handler:    _ValidatorService_ValidatorEpochAssignments_Handler,
@@ -2661,6 +2790,73 @@ func (m *ValidatorIndexResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ValidatorIndicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorIndicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKeys) > 0 {
+		for _, b := range m.PublicKeys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintServices(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidatorIndicesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorIndicesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Indices) > 0 {
+		dAtA13 := make([]byte, len(m.Indices)*10)
+		var j12 int
+		for _, num := range m.Indices {
+			for num >= 1<<7 {
+				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j12++
+			}
+			dAtA13[j12] = uint8(num)
+			j12++
+		}
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintServices(dAtA, i, uint64(j12))
+		i += copy(dAtA[i:], dAtA13[:j12])
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *ValidatorEpochAssignmentsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3201,6 +3397,43 @@ func (m *ValidatorIndexResponse) Size() (n int) {
 	return n
 }
 
+func (m *ValidatorIndicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PublicKeys) > 0 {
+		for _, b := range m.PublicKeys {
+			l = len(b)
+			n += 1 + l + sovServices(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidatorIndicesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Indices) > 0 {
+		l = 0
+		for _, e := range m.Indices {
+			l += sovServices(uint64(e))
+		}
+		n += 1 + sovServices(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ValidatorEpochAssignmentsRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5211,6 +5444,210 @@ func (m *ValidatorIndexResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ValidatorIndicesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServices
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorIndicesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorIndicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowServices
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthServices
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKeys = append(m.PublicKeys, make([]byte, postIndex-iNdEx))
+			copy(m.PublicKeys[len(m.PublicKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServices(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServices
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorIndicesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServices
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorIndicesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorIndicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowServices
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indices = append(m.Indices, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowServices
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthServices
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indices) == 0 {
+					m.Indices = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowServices
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indices = append(m.Indices, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indices", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServices(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServices
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ValidatorEpochAssignmentsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5850,97 +6287,99 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("proto/beacon/rpc/v1/services.proto", fileDescriptor_services_5b19002a860859a4)
-}
-
-var fileDescriptor_services_5b19002a860859a4 = []byte{
-	// 1405 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4d, 0x6f, 0x13, 0xc7,
-	0x1b, 0xff, 0x6f, 0xe2, 0x80, 0xf3, 0xd8, 0x89, 0x9d, 0x21, 0x21, 0x66, 0xc3, 0x9f, 0x84, 0x45,
-	0x2a, 0x01, 0x95, 0x35, 0x71, 0xa4, 0x42, 0x8b, 0x90, 0x1a, 0x87, 0x50, 0x28, 0x51, 0x12, 0xd6,
-	0x29, 0x88, 0xaa, 0xd2, 0x6a, 0x6c, 0x4f, 0xec, 0x6d, 0xd6, 0x3b, 0xdb, 0x9d, 0x71, 0x44, 0x2e,
-	0x3d, 0xf4, 0xd0, 0x4b, 0x7b, 0xe8, 0x27, 0xe8, 0x77, 0xe9, 0xa1, 0x52, 0x8f, 0xfd, 0x08, 0x15,
-	0x87, 0x7e, 0x86, 0x1e, 0xab, 0x9d, 0x99, 0x1d, 0xaf, 0x5f, 0x36, 0x09, 0xe5, 0xe6, 0x7d, 0x5e,
-	0x7e, 0xf3, 0xbc, 0xcd, 0xef, 0x19, 0x83, 0x15, 0x46, 0x94, 0xd3, 0x6a, 0x93, 0xe0, 0x16, 0x0d,
-	0xaa, 0x51, 0xd8, 0xaa, 0x9e, 0x6c, 0x54, 0x19, 0x89, 0x4e, 0xbc, 0x16, 0x61, 0xb6, 0x50, 0xa2,
-	0xab, 0x84, 0x77, 0x49, 0x44, 0xfa, 0x3d, 0x5b, 0x9a, 0xd9, 0x51, 0xd8, 0xb2, 0x4f, 0x36, 0xcc,
-	0xd5, 0x21, 0xdf, 0xb0, 0x16, 0xc6, 0xbe, 0xfc, 0x34, 0x4c, 0x1c, 0xcd, 0x95, 0x0e, 0xa5, 0x1d,
-	0x9f, 0x54, 0xc5, 0x57, 0xb3, 0x7f, 0x54, 0x25, 0xbd, 0x90, 0x9f, 0x2a, 0xe5, 0xea, 0xa8, 0x92,
-	0x7b, 0x3d, 0xc2, 0x38, 0xee, 0x85, 0xd2, 0xc0, 0xda, 0x87, 0xf2, 0x36, 0xed, 0xf5, 0x3c, 0xce,
-	0x09, 0x71, 0xc8, 0x77, 0x7d, 0xc2, 0x38, 0x42, 0x90, 0x63, 0x3e, 0xe5, 0x15, 0x63, 0xcd, 0x58,
-	0xcf, 0x39, 0xe2, 0x37, 0xba, 0x0d, 0xa5, 0x13, 0xec, 0x7b, 0x6d, 0xcc, 0x69, 0xe4, 0x7a, 0x41,
-	0x9b, 0xbc, 0xad, 0x4c, 0x09, 0xf5, 0xbc, 0x16, 0x3f, 0x8f, 0xa5, 0xd6, 0x17, 0xb0, 0x90, 0x02,
-	0x64, 0x21, 0x0d, 0x18, 0x41, 0xd7, 0x61, 0xb6, 0x95, 0x08, 0x2b, 0xc6, 0xda, 0xf4, 0x7a, 0xce,
-	0x19, 0x08, 0xd0, 0x22, 0xcc, 0xb0, 0x2e, 0x8e, 0xda, 0x0a, 0x51, 0x7e, 0x58, 0x36, 0x5c, 0xdd,
-	0xe2, 0x3c, 0x0e, 0x96, 0x7b, 0x34, 0x78, 0x1e, 0x1c, 0xd1, 0x24, 0x3e, 0x6d, 0x6f, 0xa4, 0xed,
-	0x7f, 0x9f, 0x82, 0xe5, 0x31, 0x07, 0x75, 0xfe, 0x03, 0xa8, 0xc8, 0x02, 0xba, 0x4d, 0x9f, 0xb6,
-	0x8e, 0xdd, 0x88, 0x52, 0xee, 0x76, 0x31, 0xeb, 0x6e, 0xd6, 0x04, 0x48, 0xd1, 0x59, 0x92, 0xfa,
-	0x7a, 0xac, 0x76, 0x28, 0xe5, 0xcf, 0x84, 0x12, 0x3d, 0x02, 0x93, 0x84, 0xb4, 0xd5, 0x75, 0x9b,
-	0xb4, 0x1f, 0xb4, 0x71, 0x74, 0x3a, 0xe4, 0x3a, 0x25, 0x5c, 0x97, 0x85, 0x45, 0x5d, 0x19, 0xa4,
-	0x9c, 0x6f, 0x43, 0xe9, 0xdb, 0x3e, 0xe3, 0xde, 0x91, 0x47, 0xda, 0xae, 0x30, 0xaa, 0x4c, 0xcb,
-	0x9a, 0x69, 0xf1, 0x4e, 0x2c, 0x45, 0x8f, 0x61, 0x65, 0x60, 0x38, 0x1e, 0x61, 0x4e, 0x1c, 0x53,
-	0xd1, 0x26, 0xa3, 0x41, 0xee, 0x42, 0xd9, 0xc7, 0x71, 0xe2, 0x6e, 0x2b, 0xa2, 0x8c, 0xf9, 0x5e,
-	0x70, 0x5c, 0x99, 0x59, 0x33, 0xd6, 0x0b, 0xb5, 0x9b, 0xf6, 0xe8, 0x54, 0x85, 0xb5, 0xd0, 0x3e,
-	0xd9, 0xb0, 0xb7, 0x13, 0x43, 0xa7, 0x24, 0x5d, 0xb5, 0xc0, 0x7a, 0x03, 0xe6, 0x01, 0x09, 0xda,
-	0x5e, 0xd0, 0x49, 0x55, 0x93, 0x25, 0xb5, 0x7f, 0x04, 0xe6, 0x91, 0xe7, 0x73, 0x12, 0xb9, 0x11,
-	0xc1, 0xed, 0x53, 0xf7, 0x48, 0x8c, 0x43, 0xcb, 0xef, 0x33, 0x8f, 0x06, 0xa2, 0x96, 0x79, 0x67,
-	0x59, 0x5a, 0x38, 0xb1, 0xc1, 0xd3, 0x78, 0x2e, 0x94, 0xda, 0xea, 0xc3, 0xca, 0x44, 0x68, 0xd5,
-	0xa5, 0x57, 0xb0, 0x18, 0x4a, 0xb5, 0x8b, 0x53, 0x7a, 0x31, 0x30, 0x85, 0xda, 0xad, 0xac, 0x5c,
-	0x52, 0x58, 0xce, 0x95, 0x70, 0x1c, 0xdf, 0xaa, 0xc2, 0x35, 0x9d, 0xde, 0x45, 0x86, 0xdd, 0x3a,
-	0x00, 0x73, 0x92, 0xc3, 0x07, 0x0c, 0xf3, 0x4b, 0x40, 0xdb, 0x5d, 0xec, 0x05, 0x0d, 0x8e, 0x23,
-	0xae, 0x91, 0x2a, 0x70, 0x99, 0xc5, 0x02, 0xd2, 0x56, 0x95, 0x4b, 0x3e, 0xd1, 0x4d, 0x28, 0x76,
-	0x48, 0x40, 0x98, 0xc7, 0xdc, 0xf8, 0xc6, 0x2a, 0xb0, 0x82, 0x92, 0x1d, 0x7a, 0x3d, 0x62, 0xfd,
-	0x3a, 0x05, 0xf3, 0x07, 0x11, 0x0d, 0x29, 0xd3, 0xb9, 0xac, 0x42, 0x21, 0xc4, 0x11, 0x09, 0xe4,
-	0xe4, 0xa8, 0xc9, 0x06, 0x29, 0x8a, 0x67, 0x25, 0x36, 0x88, 0x13, 0x74, 0x83, 0x7e, 0xaf, 0x49,
-	0x22, 0x85, 0x0a, 0xb1, 0x68, 0x4f, 0x48, 0xd0, 0x2d, 0x98, 0x8b, 0x70, 0xd0, 0xc6, 0xd4, 0x8d,
-	0xc8, 0x09, 0xc1, 0xbe, 0x18, 0xd8, 0xa2, 0x53, 0x94, 0x42, 0x47, 0xc8, 0x50, 0x15, 0xae, 0xa4,
-	0xfa, 0xe3, 0x36, 0x3d, 0xde, 0xc3, 0xec, 0x58, 0x8d, 0x29, 0x4a, 0xa9, 0xea, 0x52, 0x83, 0x3e,
-	0x83, 0x6b, 0x69, 0x07, 0xdc, 0xe9, 0x44, 0xa4, 0x83, 0x39, 0x71, 0x99, 0xd7, 0xa9, 0xcc, 0x88,
-	0x0a, 0x2e, 0xa7, 0x0c, 0xb6, 0x12, 0x7d, 0xc3, 0xeb, 0xa0, 0x87, 0x30, 0xab, 0x39, 0xab, 0x72,
-	0x49, 0x4c, 0xb5, 0x69, 0x4b, 0x56, 0xb3, 0x13, 0x56, 0xb3, 0x0f, 0x13, 0x0b, 0x67, 0x60, 0x6c,
-	0xdd, 0x87, 0x92, 0xae, 0x8f, 0x2a, 0xf8, 0xff, 0x01, 0xe4, 0xf5, 0x4a, 0xd5, 0x67, 0x56, 0x48,
-	0xe2, 0xf2, 0x58, 0x0f, 0x60, 0x51, 0x79, 0x48, 0x32, 0x4b, 0xd5, 0x35, 0x5d, 0x36, 0x63, 0xb4,
-	0x6c, 0xd6, 0x3d, 0x58, 0x1a, 0x71, 0x54, 0x07, 0x2e, 0xc2, 0x8c, 0x24, 0x4b, 0x45, 0x55, 0xe2,
-	0xc3, 0xaa, 0xc1, 0x42, 0x83, 0x63, 0x4e, 0xe2, 0x3b, 0x9c, 0x8e, 0x2d, 0xce, 0x9f, 0x88, 0xab,
-	0x9f, 0xc4, 0xc6, 0x12, 0x33, 0xeb, 0x11, 0xcc, 0xcb, 0xa1, 0xd6, 0x0e, 0x77, 0xa0, 0x9c, 0xae,
-	0x6a, 0x2a, 0xa5, 0x52, 0x4a, 0x2e, 0x12, 0xfb, 0xc9, 0x00, 0xd8, 0x62, 0xcc, 0xeb, 0x04, 0x3d,
-	0x12, 0xf0, 0xf8, 0xa8, 0xb0, 0xdf, 0xf4, 0xbd, 0x96, 0x7b, 0x4c, 0x4e, 0x93, 0xa3, 0xa4, 0xe4,
-	0x05, 0x39, 0x9d, 0x3c, 0xc2, 0xf1, 0x68, 0x48, 0x58, 0x12, 0xb9, 0xe2, 0xc6, 0x48, 0x2e, 0x2b,
-	0x26, 0xc2, 0x46, 0xbc, 0x26, 0x6e, 0xc1, 0x5c, 0xa8, 0x0a, 0x21, 0x8d, 0x72, 0xd2, 0x28, 0x11,
-	0xc6, 0x46, 0xd6, 0x27, 0xb0, 0xf4, 0x6a, 0x68, 0x69, 0x24, 0x75, 0x3e, 0x3b, 0xae, 0x78, 0x23,
-	0x8c, 0xfa, 0x9d, 0x59, 0xe6, 0x26, 0xac, 0x69, 0x7b, 0x41, 0xb4, 0x83, 0x12, 0xb0, 0x54, 0x6b,
-	0x25, 0xc1, 0x8b, 0x9b, 0x97, 0xb4, 0x56, 0x88, 0xc4, 0x5d, 0x1d, 0x89, 0x69, 0x6a, 0x34, 0xa6,
-	0x0e, 0xdc, 0x3c, 0xe3, 0x0c, 0x15, 0x5e, 0x1d, 0x00, 0x6b, 0xb1, 0xc0, 0x28, 0xd4, 0x2c, 0x7b,
-	0xf2, 0xc2, 0xb7, 0x07, 0x00, 0x4e, 0xca, 0xcb, 0x22, 0xb0, 0xac, 0xb8, 0xf3, 0x09, 0x09, 0x29,
-	0xf3, 0x52, 0xf0, 0x5f, 0x42, 0x39, 0xe1, 0xcd, 0xb6, 0xd2, 0x29, 0xce, 0x5c, 0xcd, 0xe2, 0x4c,
-	0x85, 0xe1, 0x94, 0xc2, 0x61, 0x4c, 0xeb, 0x47, 0x03, 0x56, 0x34, 0xe5, 0xa5, 0x42, 0xf9, 0x00,
-	0xf2, 0xd3, 0x14, 0x3b, 0x9d, 0x7a, 0x4f, 0xac, 0x42, 0xc1, 0x63, 0x6e, 0x32, 0x16, 0x62, 0x4c,
-	0xf2, 0x0e, 0x78, 0x2c, 0xb9, 0x46, 0xd6, 0x4b, 0x28, 0xef, 0xf0, 0xee, 0xc6, 0x13, 0xcc, 0xb1,
-	0x3e, 0xfc, 0x31, 0xcc, 0x12, 0xde, 0xdd, 0x70, 0xdb, 0x98, 0x63, 0xd1, 0xaa, 0x42, 0x6d, 0x2d,
-	0x2b, 0x43, 0xed, 0x9c, 0x27, 0xea, 0xd7, 0xdd, 0x3a, 0xcc, 0xe9, 0x5e, 0x39, 0xd4, 0x27, 0xa8,
-	0x00, 0x97, 0xbf, 0xda, 0x7b, 0xb1, 0xb7, 0xff, 0x7a, 0xaf, 0xfc, 0x3f, 0x54, 0x84, 0xfc, 0xd6,
-	0xe1, 0xe1, 0x4e, 0xe3, 0x70, 0xc7, 0x29, 0x1b, 0xf1, 0xd7, 0x81, 0xb3, 0x7f, 0xb0, 0xdf, 0xd8,
-	0x71, 0xca, 0x53, 0x28, 0x0f, 0xb9, 0xfa, 0xfe, 0xe1, 0xb3, 0xf2, 0x74, 0xed, 0x9f, 0x69, 0x98,
-	0xab, 0x8b, 0x83, 0x1a, 0xf2, 0xfd, 0x86, 0xde, 0xc0, 0xc2, 0x6b, 0xec, 0xf1, 0xa7, 0x34, 0x1a,
-	0x30, 0x3c, 0xba, 0x3a, 0x46, 0x51, 0x3b, 0xf1, 0xab, 0xcc, 0xbc, 0x9b, 0xd5, 0xf5, 0xf1, 0xed,
-	0x70, 0xdf, 0x40, 0xbb, 0x30, 0xb7, 0x8d, 0x03, 0x1a, 0x78, 0x2d, 0xec, 0x3f, 0x23, 0xb8, 0x9d,
-	0x09, 0x9b, 0xb9, 0x1b, 0xeb, 0x83, 0x57, 0x0d, 0x72, 0x60, 0x61, 0x57, 0xec, 0xfa, 0xd4, 0x72,
-	0x7c, 0x7f, 0xc4, 0x94, 0xf3, 0x7d, 0x03, 0x7d, 0x0d, 0xa5, 0x91, 0xa9, 0xcc, 0x44, 0xac, 0x66,
-	0xa5, 0x9e, 0x35, 0xd6, 0xbb, 0x90, 0x4f, 0x9a, 0x98, 0x09, 0xba, 0x9e, 0x05, 0x3a, 0x36, 0x3b,
-	0x9f, 0x43, 0xfe, 0x29, 0x8d, 0x8e, 0xcf, 0x44, 0xbb, 0x9e, 0x95, 0x74, 0xec, 0x59, 0xfb, 0xdb,
-	0x80, 0xd2, 0x56, 0x42, 0x76, 0xba, 0xf9, 0x20, 0x45, 0xa2, 0x3d, 0x17, 0x29, 0x9a, 0xf9, 0x51,
-	0xe6, 0xc5, 0x1f, 0xa6, 0xf7, 0xb7, 0xb0, 0x34, 0xf2, 0x9c, 0xdd, 0xe2, 0x82, 0x63, 0xed, 0xb3,
-	0x01, 0x46, 0x9f, 0xcb, 0xd9, 0x85, 0xcf, 0x78, 0x2d, 0xd7, 0x7e, 0x9b, 0xd6, 0x9b, 0x53, 0x27,
-	0xea, 0xc3, 0xdc, 0xd0, 0x86, 0x43, 0x1f, 0x67, 0xb6, 0x73, 0xc2, 0x06, 0x35, 0xef, 0x5d, 0xd0,
-	0x5a, 0xe5, 0xfe, 0x3d, 0x5c, 0x99, 0xf0, 0x50, 0x44, 0xb5, 0x73, 0x46, 0x68, 0xc2, 0x83, 0xd5,
-	0xdc, 0x7c, 0x2f, 0x1f, 0x75, 0xfe, 0x37, 0x50, 0x54, 0x81, 0xc9, 0xab, 0x73, 0x91, 0xfb, 0x65,
-	0xde, 0x3e, 0x27, 0x47, 0x8d, 0xde, 0x14, 0xff, 0xb9, 0xc2, 0x3e, 0x27, 0xfa, 0x15, 0x70, 0xb1,
-	0x13, 0xee, 0x64, 0x9d, 0x30, 0xf6, 0x9a, 0xa8, 0xfd, 0x9c, 0x83, 0xb2, 0x26, 0xbb, 0xa4, 0x89,
-	0x14, 0xe6, 0x87, 0x17, 0x28, 0xca, 0xec, 0xcb, 0xc4, 0x05, 0x6d, 0xda, 0x17, 0x35, 0x57, 0x99,
-	0xfe, 0x62, 0xc0, 0xb5, 0xcc, 0xf5, 0x88, 0x1e, 0x9e, 0x8b, 0x96, 0xb1, 0xb5, 0xcd, 0x4f, 0xff,
-	0x83, 0xa7, 0x0a, 0x89, 0x42, 0x45, 0x1b, 0x0d, 0x16, 0x9d, 0xbc, 0x59, 0x99, 0x6c, 0x32, 0xfa,
-	0xaf, 0x21, 0xbb, 0x13, 0xe3, 0x7f, 0x17, 0x7e, 0x30, 0xe0, 0xfa, 0x1e, 0x79, 0xcb, 0x45, 0x44,
-	0x13, 0x56, 0xeb, 0xfb, 0xf6, 0x60, 0xf3, 0xdc, 0xa3, 0xc7, 0xd7, 0x76, 0xbd, 0xf8, 0xc7, 0xbb,
-	0x1b, 0xc6, 0x9f, 0xef, 0x6e, 0x18, 0x7f, 0xbd, 0xbb, 0x61, 0x34, 0x2f, 0x09, 0xde, 0xdb, 0xfc,
-	0x37, 0x00, 0x00, 0xff, 0xff, 0x94, 0x72, 0xe7, 0x56, 0x98, 0x10, 0x00, 0x00,
+	proto.RegisterFile("proto/beacon/rpc/v1/services.proto", fileDescriptor_services_62743181e5545e6e)
+}
+
+var fileDescriptor_services_62743181e5545e6e = []byte{
+	// 1439 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xa5, 0x57, 0x4b, 0x4f, 0x1b, 0x57,
+	0x14, 0xae, 0x79, 0x24, 0xe6, 0xd8, 0x80, 0xb9, 0xbc, 0xcc, 0x40, 0x0b, 0x4c, 0xa4, 0xe6, 0xa1,
+	0x66, 0x0c, 0xa6, 0x6a, 0xd2, 0x44, 0x91, 0x8a, 0x09, 0x29, 0x69, 0x10, 0xd0, 0x81, 0x26, 0x4a,
+	0x55, 0x69, 0x34, 0xb6, 0x2f, 0xf6, 0x94, 0xf1, 0xcc, 0x74, 0x66, 0x8c, 0x60, 0xd3, 0x45, 0x2b,
+	0x75, 0xd3, 0x4d, 0x7f, 0x41, 0xff, 0x4b, 0x17, 0x95, 0xba, 0xec, 0x4f, 0xa8, 0xba, 0xe8, 0x6f,
+	0xe8, 0xb2, 0xf7, 0x39, 0xbe, 0x7e, 0x0c, 0x98, 0x66, 0x81, 0xc4, 0x9c, 0xf7, 0x39, 0xf7, 0x3b,
+	0x0f, 0x83, 0x1e, 0x84, 0x7e, 0xec, 0x97, 0xaa, 0xd8, 0xae, 0xf9, 0x5e, 0x29, 0x0c, 0x6a, 0xa5,
+	0xf3, 0xcd, 0x52, 0x84, 0xc3, 0x73, 0xa7, 0x86, 0x23, 0x83, 0x31, 0xd1, 0x02, 0x8e, 0x9b, 0x38,
+	0xc4, 0xed, 0x96, 0xc1, 0xc5, 0x0c, 0x22, 0x66, 0x9c, 0x6f, 0x6a, 0xab, 0x5d, 0xba, 0x41, 0x39,
+	0xa0, 0xba, 0xf1, 0x65, 0x20, 0x15, 0xb5, 0xe5, 0x86, 0xef, 0x37, 0x5c, 0x5c, 0x62, 0x5f, 0xd5,
+	0xf6, 0x69, 0x09, 0xb7, 0x82, 0xf8, 0x52, 0x30, 0x57, 0x7b, 0x99, 0xb1, 0xd3, 0xc2, 0x51, 0x6c,
+	0xb7, 0x02, 0x2e, 0xa0, 0x1f, 0x42, 0x61, 0xc7, 0x6f, 0xb5, 0x9c, 0x38, 0xc6, 0xd8, 0xc4, 0xdf,
+	0xb5, 0x09, 0x13, 0x21, 0x18, 0x8b, 0x5c, 0x3f, 0x2e, 0x66, 0xd6, 0x32, 0xf7, 0xc6, 0x4c, 0xf6,
+	0x3f, 0xba, 0x0b, 0xd3, 0xe7, 0xb6, 0xeb, 0xd4, 0xed, 0xd8, 0x0f, 0x2d, 0xc7, 0xab, 0xe3, 0x8b,
+	0xe2, 0x08, 0x63, 0x4f, 0x25, 0xe4, 0x97, 0x94, 0xaa, 0x7f, 0x0e, 0x33, 0x8a, 0xc1, 0x28, 0xf0,
+	0xbd, 0x08, 0xa3, 0x15, 0x98, 0xa8, 0x49, 0x22, 0x31, 0x3b, 0x4a, 0xf4, 0x3a, 0x04, 0x34, 0x07,
+	0xe3, 0x51, 0xd3, 0x0e, 0xeb, 0xc2, 0x22, 0xff, 0xd0, 0x0d, 0x58, 0xd8, 0x26, 0x6c, 0x12, 0x6c,
+	0xec, 0xf8, 0xde, 0x4b, 0xef, 0xd4, 0x97, 0xf1, 0x25, 0xf2, 0x19, 0x55, 0xfe, 0xf7, 0x11, 0x58,
+	0xec, 0x53, 0x10, 0xfe, 0x1f, 0x41, 0x91, 0x17, 0xd0, 0xaa, 0xba, 0x7e, 0xed, 0xcc, 0x0a, 0x7d,
+	0x3f, 0xb6, 0x9a, 0x76, 0xd4, 0xdc, 0x2a, 0x33, 0x23, 0x79, 0x73, 0x9e, 0xf3, 0x2b, 0x94, 0x6d,
+	0x12, 0xee, 0x1e, 0x63, 0xa2, 0xa7, 0xa0, 0xe1, 0xc0, 0xaf, 0x35, 0xad, 0xaa, 0xdf, 0xf6, 0xea,
+	0x76, 0x78, 0xd9, 0xa5, 0x3a, 0xc2, 0x54, 0x17, 0x99, 0x44, 0x45, 0x08, 0x28, 0xca, 0xa4, 0x66,
+	0xdf, 0xb6, 0xa3, 0xd8, 0x39, 0x75, 0x70, 0xdd, 0x62, 0x42, 0xc5, 0x51, 0x5e, 0xb3, 0x84, 0xbc,
+	0x4b, 0xa9, 0xe8, 0x19, 0x2c, 0x77, 0x04, 0xfb, 0x23, 0x1c, 0x63, 0x6e, 0x8a, 0x89, 0x48, 0x6f,
+	0x90, 0xfb, 0x50, 0x70, 0x6d, 0x9a, 0xb8, 0x55, 0x0b, 0xfd, 0x28, 0x72, 0x1d, 0xef, 0xac, 0x38,
+	0x4e, 0x74, 0x72, 0xe5, 0x75, 0xa3, 0x17, 0x55, 0x04, 0x40, 0x04, 0x55, 0xc6, 0x8e, 0x14, 0x34,
+	0xa7, 0xb9, 0x6a, 0x42, 0xd0, 0xdf, 0x82, 0x76, 0x84, 0xbd, 0xba, 0xe3, 0x35, 0x94, 0x6a, 0x46,
+	0xb2, 0xf6, 0xa4, 0x20, 0xa7, 0x8e, 0x1b, 0xe3, 0xd0, 0x0a, 0xb1, 0x5d, 0xbf, 0xb4, 0x4e, 0x19,
+	0x1c, 0x6a, 0x6e, 0x3b, 0x22, 0x52, 0xac, 0x96, 0x59, 0x73, 0x91, 0x4b, 0x98, 0x54, 0xe0, 0x05,
+	0xc5, 0x85, 0x60, 0xeb, 0x6d, 0x58, 0x1e, 0x68, 0x5a, 0xbc, 0xd2, 0x6b, 0x98, 0x0b, 0x38, 0xdb,
+	0xb2, 0x15, 0x3e, 0x03, 0x4c, 0xae, 0x7c, 0x27, 0x2d, 0x17, 0xc5, 0x96, 0x39, 0x1b, 0xf4, 0xdb,
+	0xd7, 0x4b, 0xb0, 0x94, 0xa4, 0x37, 0x0c, 0xd8, 0xf5, 0x23, 0xd0, 0x06, 0x29, 0xbc, 0x03, 0x98,
+	0xbf, 0x04, 0xb4, 0xd3, 0xb4, 0x1d, 0xef, 0x38, 0xb6, 0xc3, 0x38, 0xb1, 0x54, 0x84, 0xdb, 0x11,
+	0x25, 0xe0, 0xba, 0xa8, 0x9c, 0xfc, 0x44, 0xeb, 0x90, 0x6f, 0x60, 0x0f, 0x47, 0x4e, 0x64, 0xd1,
+	0x8e, 0x15, 0xc6, 0x72, 0x82, 0x76, 0x42, 0x48, 0xfa, 0xaf, 0x23, 0x30, 0x75, 0x14, 0xfa, 0x81,
+	0x1f, 0x25, 0xb9, 0xac, 0x42, 0x2e, 0xb0, 0x43, 0xec, 0x71, 0xe4, 0x08, 0x64, 0x03, 0x27, 0x51,
+	0xac, 0x50, 0x01, 0x9a, 0xa0, 0xe5, 0xb5, 0x5b, 0x55, 0x1c, 0x0a, 0xab, 0x40, 0x49, 0x07, 0x8c,
+	0x82, 0xee, 0xc0, 0x64, 0x68, 0x13, 0x1c, 0xfb, 0xe4, 0x79, 0xcf, 0xb1, 0xed, 0x32, 0xc0, 0xe6,
+	0xcd, 0x3c, 0x27, 0x9a, 0x8c, 0x86, 0x4a, 0x30, 0xab, 0xbc, 0x8f, 0x55, 0x75, 0xe2, 0x96, 0x1d,
+	0x9d, 0x09, 0x98, 0x22, 0x85, 0x55, 0xe1, 0x1c, 0xf4, 0x04, 0x96, 0x54, 0x05, 0xbb, 0xd1, 0x08,
+	0x71, 0x83, 0xc0, 0xce, 0x8a, 0x9c, 0x06, 0x41, 0x2a, 0xad, 0xe0, 0xa2, 0x22, 0xb0, 0x2d, 0xf9,
+	0xc7, 0x4e, 0x03, 0x3d, 0x86, 0x89, 0x64, 0x66, 0x15, 0x6f, 0x31, 0x54, 0x6b, 0x06, 0x9f, 0x6a,
+	0x86, 0x9c, 0x6a, 0xc6, 0x89, 0x94, 0x30, 0x3b, 0xc2, 0xfa, 0x06, 0x4c, 0x27, 0xf5, 0x11, 0x05,
+	0x7f, 0x1f, 0x80, 0xb7, 0x97, 0x52, 0x9f, 0x09, 0x46, 0xa1, 0xe5, 0xd1, 0x1f, 0xc1, 0x9c, 0xd0,
+	0xe0, 0xc3, 0x4c, 0xa9, 0xab, 0x5a, 0xb6, 0x4c, 0x6f, 0xd9, 0xf4, 0x87, 0x30, 0xdf, 0xa3, 0x28,
+	0x1c, 0x12, 0x34, 0xf0, 0x61, 0x29, 0x46, 0x15, 0xfb, 0xd0, 0xcb, 0x30, 0x43, 0x80, 0x10, 0x63,
+	0xda, 0xc3, 0x6a, 0x6c, 0x34, 0x7f, 0xcc, 0x5a, 0x5f, 0xc6, 0x16, 0x49, 0x31, 0xfd, 0x29, 0x4c,
+	0x71, 0x50, 0x27, 0x0a, 0xf7, 0xa1, 0xa0, 0x56, 0x55, 0x49, 0x69, 0x5a, 0xa1, 0xb3, 0xc4, 0x7e,
+	0xce, 0x00, 0x6c, 0x47, 0xa4, 0xda, 0x5e, 0x8b, 0x40, 0x81, 0xba, 0x0a, 0xda, 0x55, 0xd7, 0xa9,
+	0x59, 0x67, 0xf8, 0x52, 0xba, 0xe2, 0x94, 0x57, 0xf8, 0x72, 0x30, 0x84, 0x29, 0x34, 0xb8, 0x59,
+	0xd2, 0xfb, 0xac, 0x63, 0xf8, 0x2c, 0xcb, 0x4b, 0xe2, 0x31, 0x5d, 0x13, 0x44, 0x28, 0x10, 0x85,
+	0xe0, 0x42, 0x63, 0x5c, 0x48, 0x12, 0xa9, 0x90, 0xfe, 0x09, 0xcc, 0xbf, 0xee, 0x5a, 0x1a, 0xb2,
+	0xce, 0x57, 0xc7, 0x45, 0x37, 0x42, 0xaf, 0xde, 0x95, 0x65, 0x7e, 0x02, 0x8b, 0xaa, 0x3c, 0x5d,
+	0xb6, 0x6a, 0xa7, 0x24, 0x9e, 0xf8, 0x84, 0xa1, 0x9d, 0x22, 0x5d, 0x45, 0xfa, 0xc7, 0x50, 0xec,
+	0xd7, 0xed, 0xb4, 0xad, 0xc3, 0x49, 0xa2, 0xfd, 0xe5, 0xa7, 0x5e, 0x85, 0xb5, 0x44, 0x8b, 0x8d,
+	0xf6, 0x4e, 0xd1, 0x55, 0xd7, 0x7c, 0xa5, 0xb0, 0x5e, 0x97, 0x60, 0x62, 0x24, 0x36, 0x1d, 0x7a,
+	0xaa, 0x30, 0xd2, 0x5b, 0x85, 0x06, 0xac, 0x5f, 0xe1, 0x43, 0x84, 0x58, 0x01, 0xb0, 0x13, 0x32,
+	0xb3, 0x91, 0x2b, 0xeb, 0xc6, 0xe0, 0x13, 0xc3, 0xe8, 0x18, 0x30, 0x15, 0x2d, 0x1d, 0xc3, 0xa2,
+	0x98, 0xd6, 0xcf, 0x49, 0x74, 0x91, 0xa3, 0x98, 0xff, 0x02, 0x0a, 0x72, 0x52, 0xd7, 0x05, 0x4f,
+	0x4c, 0xe9, 0xd5, 0xb4, 0x29, 0x2d, 0x6c, 0x98, 0xd3, 0x41, 0xb7, 0x4d, 0xfd, 0xa7, 0x0c, 0x2c,
+	0x27, 0x43, 0x56, 0x09, 0xe5, 0x1d, 0xc6, 0x6d, 0x32, 0xd4, 0x47, 0x95, 0x0b, 0x86, 0xd4, 0x9d,
+	0x4c, 0x53, 0x09, 0x44, 0x06, 0xcc, 0xac, 0x09, 0x4e, 0x24, 0x1b, 0x97, 0xcc, 0xe8, 0xc2, 0x6e,
+	0xdc, 0xdc, 0x7c, 0x6e, 0xc7, 0x76, 0xe2, 0xfc, 0x19, 0x4c, 0x90, 0x7c, 0x36, 0x2d, 0x52, 0x6d,
+	0x9b, 0x3d, 0x55, 0xae, 0xbc, 0x96, 0x96, 0x61, 0xa2, 0x9c, 0xc5, 0xe2, 0xbf, 0x07, 0x15, 0x98,
+	0x4c, 0xde, 0xca, 0xf4, 0x5d, 0x8c, 0x72, 0x70, 0xfb, 0xab, 0x83, 0x57, 0x07, 0x87, 0x6f, 0x0e,
+	0x0a, 0xef, 0xa1, 0x3c, 0x64, 0xb7, 0x4f, 0x4e, 0x76, 0x8f, 0x4f, 0x76, 0xcd, 0x42, 0x86, 0x7e,
+	0x1d, 0x99, 0x87, 0x47, 0x87, 0xc7, 0xe4, 0x6b, 0x04, 0x65, 0x61, 0xac, 0x72, 0x78, 0xb2, 0x57,
+	0x18, 0x2d, 0xff, 0x3b, 0x0a, 0x93, 0x15, 0xe6, 0xe8, 0x98, 0x5f, 0x8c, 0xe8, 0x2d, 0xcc, 0xbc,
+	0xb1, 0x9d, 0x98, 0xac, 0xd6, 0xce, 0x4e, 0x41, 0x0b, 0x7d, 0x43, 0x71, 0x97, 0xde, 0x81, 0xda,
+	0x83, 0xb4, 0x57, 0xef, 0xdf, 0x47, 0x1b, 0x19, 0x72, 0x4a, 0x4c, 0xee, 0xd8, 0x9e, 0xef, 0x39,
+	0x35, 0xdb, 0xdd, 0x23, 0xfb, 0x3b, 0xd5, 0x6c, 0xea, 0x36, 0xae, 0x74, 0xee, 0x28, 0x64, 0xc2,
+	0xcc, 0x3e, 0xbb, 0x2e, 0x94, 0x75, 0x7c, 0x73, 0x8b, 0x8a, 0x32, 0x89, 0xf0, 0x6b, 0x32, 0xd5,
+	0xbb, 0x11, 0x94, 0x6a, 0xb1, 0x94, 0x96, 0x7a, 0x1a, 0xac, 0xf7, 0x21, 0x2b, 0x1f, 0x31, 0xd5,
+	0xe8, 0xbd, 0x34, 0xa3, 0x7d, 0xd8, 0xf9, 0x0c, 0xb2, 0xe4, 0x89, 0xce, 0xae, 0xb4, 0xb6, 0x92,
+	0x96, 0x34, 0xd5, 0x2c, 0xff, 0x93, 0x81, 0xe9, 0x6d, 0x39, 0x5e, 0x93, 0xc7, 0x07, 0x4e, 0x62,
+	0xcf, 0x33, 0x4c, 0xd1, 0xb4, 0x0f, 0x53, 0x1b, 0xbf, 0x7b, 0xa1, 0x5c, 0xc0, 0x7c, 0xcf, 0x01,
+	0xbd, 0x1d, 0xb3, 0xa9, 0x6e, 0x5c, 0x6d, 0xa0, 0xf7, 0x40, 0x4f, 0x2f, 0x7c, 0xca, 0x7d, 0x5e,
+	0xfe, 0x6d, 0x34, 0xd9, 0xd5, 0x49, 0xa2, 0x2e, 0x4c, 0x76, 0xed, 0x54, 0xf4, 0x51, 0xea, 0x73,
+	0x0e, 0xd8, 0xd9, 0xda, 0xc3, 0x21, 0xa5, 0x45, 0xee, 0xdf, 0xc3, 0xec, 0x80, 0xd3, 0x14, 0x95,
+	0xaf, 0x81, 0xd0, 0x80, 0x13, 0x59, 0xdb, 0xba, 0x91, 0x8e, 0xf0, 0xff, 0x0d, 0xe4, 0x45, 0x60,
+	0xbc, 0x75, 0x86, 0xe9, 0x2f, 0xed, 0xee, 0x35, 0x39, 0x26, 0xd6, 0xab, 0xec, 0x57, 0x5e, 0xd0,
+	0x26, 0x27, 0x95, 0x3c, 0x28, 0x86, 0xf3, 0x70, 0x3f, 0xcd, 0x43, 0xdf, 0xfd, 0x52, 0xfe, 0x71,
+	0x1c, 0x0a, 0xc9, 0xb0, 0x93, 0x8f, 0xe8, 0xc3, 0x54, 0xf7, 0xca, 0x46, 0xa9, 0xef, 0x32, 0xf0,
+	0x24, 0xd0, 0x8c, 0x61, 0xc5, 0x45, 0xa6, 0x6d, 0x25, 0x08, 0xb1, 0xb7, 0x51, 0x69, 0x18, 0x1b,
+	0xca, 0x75, 0xa0, 0x6d, 0x0c, 0xaf, 0x20, 0xdc, 0xfe, 0x92, 0x81, 0xa5, 0xd4, 0xad, 0x8c, 0x1e,
+	0x5f, 0x6b, 0x2f, 0xe5, 0x58, 0xd0, 0x3e, 0xfd, 0x1f, 0x9a, 0x22, 0x24, 0x5f, 0xb9, 0x60, 0x3a,
+	0xfb, 0x95, 0x37, 0x74, 0xea, 0x10, 0xeb, 0xfd, 0x79, 0x94, 0x0e, 0x80, 0xfe, 0xdf, 0x45, 0x3f,
+	0x64, 0x60, 0xe5, 0x00, 0x5f, 0xc4, 0x2c, 0xa2, 0x01, 0x1b, 0xfd, 0xa6, 0x4f, 0xbf, 0x75, 0xad,
+	0xeb, 0xfe, 0x6b, 0xa1, 0x92, 0xff, 0xe3, 0xef, 0x0f, 0x32, 0x7f, 0x92, 0xbf, 0xbf, 0xc8, 0x5f,
+	0xf5, 0x16, 0x1b, 0xb7, 0x5b, 0xff, 0x01, 0x16, 0x15, 0x67, 0xf8, 0x81, 0x11, 0x00, 0x00,
 }
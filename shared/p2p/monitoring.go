@@ -13,10 +13,30 @@ var (
 		Name: "p2p_peer_count",
 		Help: "The number of currently connected peers",
 	})
+	peerScoreMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_peer_score",
+		Help: "The score of each connected peer",
+	}, []string{"peer"})
+	peerReportsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_peer_reports_total",
+		Help: "The number of peer behaviors reported, by behavior",
+	}, []string{"behavior"})
+	peerBansMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_peer_bans_total",
+		Help: "The number of peers banned for misbehaving",
+	})
+	invalidMessagesMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_invalid_messages_total",
+		Help: "The number of received messages dropped by the validator of their topic, by topic",
+	}, []string{"topic"})
 )
 
 func init() {
 	prometheus.MustRegister(peerCountMetric)
+	prometheus.MustRegister(peerScoreMetric)
+	prometheus.MustRegister(peerReportsMetric)
+	prometheus.MustRegister(peerBansMetric)
+	prometheus.MustRegister(invalidMessagesMetric)
 }
 
 func startPeerWatcher(ctx context.Context, h host.Host) {
@@ -24,3 +24,9 @@ type Adapter func(Handler) Handler
 //
 // See http://godoc.org/github.com/prysmaticlabs/prysm/shared/p2p#Server.RegisterTopic
 type Handler func(Message)
+
+// Validator checks a message received on a topic before it is propagated to other peers
+// and passed to the adapters of the topic. Messages failing validation are dropped.
+//
+// See http://godoc.org/github.com/prysmaticlabs/prysm/shared/p2p#Server.RegisterValidator
+type Validator func(Message) bool
@@ -1,6 +1,15 @@
 package p2p
 
-// Peer TODO(175): - Design and implement.
-// See design doc: https://docs.google.com/document/d/1cthKuGPreOSQH96Ujt7sArcT-IRICk6b-QcdD0EnLsI/edit
-// https://github.com/prysmaticlabs/prysm/issues/175
-type Peer struct{}
+import (
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// Peer is a remote node of the p2p network, identified by its libp2p peer ID. The zero
+// value does not refer to any peer, and messages sent to it are broadcast instead.
+type Peer struct {
+	ID peer.ID
+	// claimed is set when the ID was taken from the content of a gossiped message rather
+	// than from the connection the message was received on. Anyone can claim any ID, so
+	// claimed peers are not scored.
+	claimed bool
+}
@@ -0,0 +1,227 @@
+package p2p
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	net "github.com/libp2p/go-libp2p-net"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/sirupsen/logrus"
+)
+
+// PeerBehavior is a behavior of a peer reported to the p2p server, which raises or lowers
+// the score of the peer.
+type PeerBehavior int
+
+const (
+	// ValidResponse is reported when a peer answers a request.
+	ValidResponse PeerBehavior = iota
+	// ValidBlock is reported when a block received from a peer is processed successfully.
+	ValidBlock
+	// InvalidMessage is reported when a peer sends a message which can not be decoded.
+	InvalidMessage
+	// InvalidBlock is reported when a block received from a peer fails validation.
+	InvalidBlock
+	// InvalidSignature is reported when a message received from a peer carries an invalid
+	// signature.
+	InvalidSignature
+	// Spam is reported when a peer sends unsolicited or excessive messages.
+	Spam
+	// Timeout is reported when a peer does not answer a request in time.
+	Timeout
+)
+
+var peerBehaviors = map[PeerBehavior]struct {
+	name  string
+	score float64
+}{
+	ValidResponse:    {"valid_response", 1},
+	ValidBlock:       {"valid_block", 2},
+	InvalidMessage:   {"invalid_message", -10},
+	InvalidBlock:     {"invalid_block", -30},
+	InvalidSignature: {"invalid_signature", -50},
+	Spam:             {"spam", -20},
+	Timeout:          {"timeout", -5},
+}
+
+func (b PeerBehavior) String() string {
+	if behavior, ok := peerBehaviors[b]; ok {
+		return behavior.name
+	}
+	return "unknown"
+}
+
+const (
+	// maxPeerScore caps the score a peer can build up with good behavior.
+	maxPeerScore = 100
+	// peerBanThreshold is the score below which a peer is disconnected and banned.
+	peerBanThreshold = -100
+)
+
+var (
+	// peerScoreHalfLife is the time it takes for a score to decay halfway back to zero, so
+	// that peers recover from occasional misbehavior.
+	peerScoreHalfLife = 10 * time.Minute
+	// peerBanDuration is the time a banned peer is refused for.
+	peerBanDuration = 1 * time.Hour
+)
+
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// peerScorer keeps the scores of peers and the peers banned for misbehaving.
+type peerScorer struct {
+	lock   sync.Mutex
+	scores map[peer.ID]*peerScore
+	bans   map[peer.ID]time.Time
+	now    func() time.Time
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		scores: make(map[peer.ID]*peerScore),
+		bans:   make(map[peer.ID]time.Time),
+		now:    time.Now,
+	}
+}
+
+// report applies a behavior to the score of the peer. It returns the new score, and
+// whether the peer was banned because its score fell below the ban threshold.
+func (ps *peerScorer) report(id peer.ID, behavior PeerBehavior) (float64, bool) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if ps.banned(id) {
+		return 0, false
+	}
+
+	score := ps.decayed(id)
+	score.value = math.Min(score.value+peerBehaviors[behavior].score, maxPeerScore)
+	if score.value >= peerBanThreshold {
+		return score.value, false
+	}
+	delete(ps.scores, id)
+	ps.bans[id] = ps.now().Add(peerBanDuration)
+	return score.value, true
+}
+
+// score returns the current score of the peer.
+func (ps *peerScorer) score(id peer.ID) float64 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if _, ok := ps.scores[id]; !ok {
+		return 0
+	}
+	return ps.decayed(id).value
+}
+
+// isBanned returns whether the peer is currently banned.
+func (ps *peerScorer) isBanned(id peer.ID) bool {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	return ps.banned(id)
+}
+
+// forget drops the score of a disconnected peer once it has decayed back to about zero,
+// so that the scores of departed peers do not accumulate.
+func (ps *peerScorer) forget(id peer.ID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if _, ok := ps.scores[id]; ok && math.Abs(ps.decayed(id).value) < 1 {
+		delete(ps.scores, id)
+	}
+}
+
+func (ps *peerScorer) banned(id peer.ID) bool {
+	until, ok := ps.bans[id]
+	if !ok {
+		return false
+	}
+	if ps.now().Before(until) {
+		return true
+	}
+	delete(ps.bans, id)
+	return false
+}
+
+// decayed returns the score of the peer, decayed towards zero since its last update.
+func (ps *peerScorer) decayed(id peer.ID) *peerScore {
+	now := ps.now()
+	score, ok := ps.scores[id]
+	if !ok {
+		score = &peerScore{updated: now}
+		ps.scores[id] = score
+		return score
+	}
+	elapsed := now.Sub(score.updated)
+	score.value *= math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+	score.updated = now
+	return score
+}
+
+// ReportPeer records a behavior of the peer. A peer whose score falls below the ban
+// threshold is disconnected, and its messages and connections are refused until the ban
+// expires.
+//
+// Only peers identified by the connection a message was received on are scored. Reports
+// about the claimed publisher of a gossiped message are ignored, as the claim may be forged.
+func (s *Server) ReportPeer(peer Peer, behavior PeerBehavior) {
+	if peer.ID == "" || peer.claimed || s.scorer == nil {
+		return
+	}
+	peerReportsMetric.WithLabelValues(behavior.String()).Inc()
+	score, banned := s.scorer.report(peer.ID, behavior)
+	if !banned {
+		peerScoreMetric.WithLabelValues(peer.ID.Pretty()).Set(score)
+		return
+	}
+
+	peerScoreMetric.DeleteLabelValues(peer.ID.Pretty())
+	peerBansMetric.Inc()
+	log.WithFields(logrus.Fields{
+		"peer":     peer.ID.Pretty(),
+		"behavior": behavior,
+		"duration": peerBanDuration,
+	}).Warn("Banning misbehaving peer")
+	if err := s.host.Network().ClosePeer(peer.ID); err != nil {
+		log.WithError(err).Error("Could not disconnect banned peer")
+	}
+}
+
+// PeerScore returns the current score of the peer.
+func (s *Server) PeerScore(peer Peer) float64 {
+	if s.scorer == nil {
+		return 0
+	}
+	return s.scorer.score(peer.ID)
+}
+
+// isBanned returns whether messages from the peer must be dropped.
+func (s *Server) isBanned(id peer.ID) bool {
+	return s.scorer != nil && s.scorer.isBanned(id)
+}
+
+// scoringNotifiee closes the connections of banned peers, and drops the scores of
+// disconnected peers.
+func (s *Server) scoringNotifiee() net.Notifiee {
+	return &net.NotifyBundle{
+		ConnectedF: func(n net.Network, conn net.Conn) {
+			if s.isBanned(conn.RemotePeer()) {
+				log.WithField("peer", conn.RemotePeer().Pretty()).Debug("Refusing connection of banned peer")
+				if err := conn.Close(); err != nil {
+					log.WithError(err).Debug("Could not close connection of banned peer")
+				}
+			}
+		},
+		DisconnectedF: func(n net.Network, conn net.Conn) {
+			id := conn.RemotePeer()
+			if len(n.ConnsToPeer(id)) > 0 {
+				return
+			}
+			peerScoreMetric.DeleteLabelValues(id.Pretty())
+			s.scorer.forget(id)
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+func TestPeerScorer_BansBelowThreshold(t *testing.T) {
+	now := time.Unix(0, 0)
+	ps := newPeerScorer()
+	ps.now = func() time.Time { return now }
+	id := peer.ID("peer")
+
+	if _, banned := ps.report(id, InvalidBlock); banned {
+		t.Fatal("Expected a single invalid block not to ban the peer")
+	}
+	if _, banned := ps.report(id, InvalidSignature); banned {
+		t.Fatal("Expected the peer not to be banned above the threshold")
+	}
+	if _, banned := ps.report(id, InvalidSignature); !banned {
+		t.Fatal("Expected the peer to be banned below the threshold")
+	}
+	if !ps.isBanned(id) {
+		t.Error("Expected the peer to be banned")
+	}
+	// Reports about a banned peer are ignored.
+	if _, banned := ps.report(id, InvalidSignature); banned {
+		t.Error("Expected an already banned peer not to be banned again")
+	}
+
+	now = now.Add(peerBanDuration)
+	if ps.isBanned(id) {
+		t.Error("Expected the ban to expire")
+	}
+	if score := ps.score(id); score != 0 {
+		t.Errorf("Expected the score of an unbanned peer to be reset, received %f", score)
+	}
+}
+
+func TestPeerScorer_ScoreDecays(t *testing.T) {
+	now := time.Unix(0, 0)
+	ps := newPeerScorer()
+	ps.now = func() time.Time { return now }
+	id := peer.ID("peer")
+
+	ps.report(id, InvalidBlock)
+	now = now.Add(peerScoreHalfLife)
+	want := peerBehaviors[InvalidBlock].score / 2
+	if score := ps.score(id); score != want {
+		t.Errorf("Expected score %f after a half-life, received %f", want, score)
+	}
+
+	for i := 0; i < 200; i++ {
+		ps.report(id, ValidResponse)
+	}
+	if score := ps.score(id); score != maxPeerScore {
+		t.Errorf("Expected score to be capped at %d, received %f", maxPeerScore, score)
+	}
+}
+
+func TestReportPeer_IgnoresClaimedPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	s, other := connectedServers(ctx, t)
+	s.scorer = newPeerScorer()
+
+	// The publisher of a gossiped message, as returned by messageSender.
+	sender := Peer{ID: other.host.ID(), claimed: true}
+	for i := 0; i < 10; i++ {
+		s.ReportPeer(sender, InvalidSignature)
+	}
+	if score := s.PeerScore(Peer{ID: other.host.ID()}); score != 0 {
+		t.Errorf("Expected the claimed sender of a gossiped message not to be scored, received score %f", score)
+	}
+	if s.isBanned(other.host.ID()) {
+		t.Error("Expected the claimed sender of a gossiped message not to be banned")
+	}
+}
+
+func TestReportPeer_DisconnectsBannedPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	s, other := connectedServers(ctx, t)
+	s.scorer = newPeerScorer()
+	s.host.Network().Notify(s.scoringNotifiee())
+	otherPeer := Peer{ID: other.host.ID()}
+
+	for !s.isBanned(otherPeer.ID) {
+		s.ReportPeer(otherPeer, InvalidSignature)
+	}
+	if len(s.host.Network().ConnsToPeer(otherPeer.ID)) != 0 {
+		t.Error("Expected the banned peer to be disconnected")
+	}
+
+	// The banned peer can not connect again.
+	if err := other.host.Connect(ctx, s.host.Peerstore().PeerInfo(s.host.ID())); err == nil {
+		// Short delay to let the connection notification close the connection.
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(s.host.Network().ConnsToPeer(otherPeer.ID)) != 0 {
+		t.Error("Expected the connection of the banned peer to be closed")
+	}
+}
@@ -0,0 +1,384 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	net "github.com/libp2p/go-libp2p-net"
+	protocol "github.com/libp2p/go-libp2p-protocol"
+	"github.com/sirupsen/logrus"
+)
+
+// A request is sent to a peer over a new stream for the RPC protocol, and the peer writes
+// its response to the same stream. Both are written as a frame made of:
+//
+//	request ID    uvarint
+//	code          1 byte, always RPCSuccess for requests
+//	message name  uvarint length, followed by the registered protobuf message name
+//	payload       uvarint length, followed by the protobuf encoding of the message, or
+//	              the error message when the code is not RPCSuccess
+//
+// The response echoes the ID of the request it answers.
+
+// rpcProtocol is the ID of the libp2p protocol of request/response streams.
+const rpcProtocol = protocol.ID("/prysm/rpc/1.0.0")
+
+// maxMessageNameLength bounds the length of the message name of a frame.
+const maxMessageNameLength = 256
+
+// maxRequestSize is the maximum size of the payload of a request.
+const maxRequestSize = 1 << 20
+
+// maxResponseSize is the maximum size of the payload of a response.
+const maxResponseSize = maxStreamMessageSize
+
+// requestTimeout bounds the time taken by a request whose context has no deadline, and
+// the time a handler is given to respond.
+var requestTimeout = 10 * time.Second
+
+// RPCErrorCode describes why a request failed.
+type RPCErrorCode byte
+
+const (
+	// RPCSuccess is the code of a successful response.
+	RPCSuccess RPCErrorCode = iota
+	// RPCInvalidRequest means the request could not be decoded, or was rejected by the handler.
+	RPCInvalidRequest
+	// RPCUnknownRequest means the peer has no handler for the type of the request.
+	RPCUnknownRequest
+	// RPCRequestTooLarge means the request exceeded the maximum request size.
+	RPCRequestTooLarge
+	// RPCResponseTooLarge means the response exceeded the maximum response size.
+	RPCResponseTooLarge
+	// RPCResourceUnavailable means the peer does not have the requested data.
+	RPCResourceUnavailable
+	// RPCServerError means the handler failed to process the request.
+	RPCServerError
+	// RPCTimeout means no response was received before the deadline of the request.
+	RPCTimeout
+	// RPCInvalidResponse means the response could not be decoded, or did not match the request.
+	RPCInvalidResponse
+)
+
+var rpcErrorCodeNames = map[RPCErrorCode]string{
+	RPCSuccess:             "success",
+	RPCInvalidRequest:      "invalid request",
+	RPCUnknownRequest:      "unknown request",
+	RPCRequestTooLarge:     "request too large",
+	RPCResponseTooLarge:    "response too large",
+	RPCResourceUnavailable: "resource unavailable",
+	RPCServerError:         "server error",
+	RPCTimeout:             "timeout",
+	RPCInvalidResponse:     "invalid response",
+}
+
+func (c RPCErrorCode) String() string {
+	if name, ok := rpcErrorCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown error code %d", c)
+}
+
+// RPCError is the error of a failed request. Handlers return an RPCError to respond with
+// a specific error code, any other error is reported to the requester as RPCServerError.
+type RPCError struct {
+	Code    RPCErrorCode
+	Message string
+}
+
+// NewRPCError returns an RPCError with the given code and message.
+func NewRPCError(code RPCErrorCode, format string, args ...interface{}) *RPCError {
+	return &RPCError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// RequestHandler responds to a request received from a peer. The context of the message
+// is cancelled once the request times out.
+type RequestHandler func(msg Message) (proto.Message, error)
+
+// rpcFrame is a request or a response.
+type rpcFrame struct {
+	id      uint64
+	code    RPCErrorCode
+	name    string
+	payload []byte
+}
+
+// requestHandler is a registered request handler, along with the name of the type of the
+// responses it returns.
+type requestHandler struct {
+	handle   RequestHandler
+	response string
+}
+
+// RegisterRequestHandler registers the handler of the requests of the type of the given
+// request message, which responds with messages of the type of the given response message.
+// Peers receive an RPCUnknownRequest error for the requests of other types.
+func (s *Server) RegisterRequestHandler(request proto.Message, response proto.Message, handler RequestHandler) {
+	name := proto.MessageName(request)
+	log.WithFields(logrus.Fields{
+		"request":  name,
+		"response": proto.MessageName(response),
+	}).Debug("Registering request handler")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.requestHandlers == nil {
+		s.requestHandlers = make(map[string]requestHandler)
+		s.host.SetStreamHandler(rpcProtocol, s.handleRequestStream)
+	}
+	s.requestHandlers[name] = requestHandler{handle: handler, response: proto.MessageName(response)}
+}
+
+// Request sends the request to the peer and decodes its response into resp. If the
+// context has no deadline, the request times out after a default timeout.
+//
+// The returned error is an *RPCError if the peer responded with an error, if the response
+// was invalid or not of the type of resp, or if the request timed out. Timeouts and invalid
+// responses count against the score of the peer.
+func (s *Server) Request(ctx context.Context, peer Peer, req proto.Message, resp proto.Message) error {
+	// The response is received over a connection to the peer, so the peer is scored for it
+	// even if its ID was claimed by a gossiped message.
+	peer = Peer{ID: peer.ID}
+	err := s.request(ctx, peer, req, resp)
+	if rpcErr, ok := err.(*RPCError); ok {
+		switch rpcErr.Code {
+		case RPCTimeout:
+			s.ReportPeer(peer, Timeout)
+		case RPCInvalidResponse:
+			s.ReportPeer(peer, InvalidMessage)
+		}
+	}
+	if err == nil {
+		s.ReportPeer(peer, ValidResponse)
+	}
+	return err
+}
+
+func (s *Server) request(ctx context.Context, peer Peer, msg proto.Message, resp proto.Message) error {
+	if peer.ID == "" {
+		return errors.New("no peer to send the request to")
+	}
+	name := proto.MessageName(msg)
+	if name == "" {
+		return fmt.Errorf("message type %T is not registered", msg)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("could not marshal request: %v", err)
+	}
+	if len(payload) > maxRequestSize {
+		return NewRPCError(RPCRequestTooLarge, "request of %d bytes exceeds %d bytes", len(payload), maxRequestSize)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	s.mutex.Lock()
+	s.lastRequestID++
+	id := s.lastRequestID
+	s.mutex.Unlock()
+
+	stream, err := s.host.NewStream(ctx, peer.ID, rpcProtocol)
+	if err != nil {
+		return rpcStreamError(deadline, "could not open stream", err)
+	}
+	defer stream.Close()
+	if err := stream.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("could not set stream deadline: %v", err)
+	}
+
+	if err := writeFrame(stream, &rpcFrame{id: id, code: RPCSuccess, name: name, payload: payload}); err != nil {
+		return rpcStreamError(deadline, "could not write request", err)
+	}
+	frame, err := readFrame(bufio.NewReader(stream), maxResponseSize)
+	if err != nil {
+		if rpcErr, ok := err.(*RPCError); ok {
+			rpcErr.Code = RPCResponseTooLarge
+			return rpcErr
+		}
+		return rpcStreamError(deadline, "could not read response", err)
+	}
+	if frame.id != id {
+		return NewRPCError(RPCInvalidResponse, "expected response to request %d, received response to request %d", id, frame.id)
+	}
+	if frame.code != RPCSuccess {
+		return &RPCError{Code: frame.code, Message: string(frame.payload)}
+	}
+
+	if want := proto.MessageName(resp); frame.name != want {
+		return NewRPCError(RPCInvalidResponse, "expected response of type %s, received %s", want, frame.name)
+	}
+	if err := proto.Unmarshal(frame.payload, resp); err != nil {
+		return NewRPCError(RPCInvalidResponse, "could not unmarshal %s: %v", frame.name, err)
+	}
+	return nil
+}
+
+// handleRequestStream reads a request from a stream opened by a peer, and writes the
+// response of the handler of the request to the stream.
+func (s *Server) handleRequestStream(stream net.Stream) {
+	defer stream.Close()
+	ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	if err := stream.SetDeadline(deadline); err != nil {
+		log.WithError(err).Error("Could not set stream deadline")
+		return
+	}
+
+	peer := Peer{ID: stream.Conn().RemotePeer()}
+	if s.isBanned(peer.ID) {
+		return
+	}
+	req, err := readFrame(bufio.NewReader(stream), maxRequestSize)
+	if err != nil {
+		rpcErr, ok := err.(*RPCError)
+		if !ok {
+			log.WithError(err).WithField("peer", peer.ID.Pretty()).Debug("Could not read request")
+			return
+		}
+		// The request ID was read before the payload was found to be too large.
+		s.respond(stream, peer, req.id, nil, rpcErr)
+		return
+	}
+
+	s.mutex.Lock()
+	handler, ok := s.requestHandlers[req.name]
+	s.mutex.Unlock()
+	if !ok {
+		s.respond(stream, peer, req.id, nil, NewRPCError(RPCUnknownRequest, "no handler for %s", req.name))
+		return
+	}
+
+	reqType := proto.MessageType(req.name)
+	if reqType == nil {
+		s.respond(stream, peer, req.id, nil, NewRPCError(RPCUnknownRequest, "unknown request type %s", req.name))
+		return
+	}
+	msg := reflect.New(reqType.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(req.payload, msg); err != nil {
+		s.ReportPeer(peer, InvalidMessage)
+		s.respond(stream, peer, req.id, nil, NewRPCError(RPCInvalidRequest, "could not unmarshal %s: %v", req.name, err))
+		return
+	}
+
+	resp, err := handler.handle(Message{Ctx: ctx, Peer: peer, Data: msg})
+	if err == nil && resp != nil && proto.MessageName(resp) != handler.response {
+		err = NewRPCError(RPCServerError, "handler responded with %s instead of %s", proto.MessageName(resp), handler.response)
+	}
+	s.respond(stream, peer, req.id, resp, err)
+}
+
+// respond writes the response to a request, or the error of the handler of the request.
+func (s *Server) respond(stream net.Stream, peer Peer, id uint64, resp proto.Message, handlerErr error) {
+	frame := &rpcFrame{id: id}
+	if handlerErr == nil && resp == nil {
+		handlerErr = NewRPCError(RPCServerError, "no response")
+	}
+	if handlerErr == nil {
+		payload, err := proto.Marshal(resp)
+		if err != nil {
+			handlerErr = NewRPCError(RPCServerError, "could not marshal response: %v", err)
+		} else if len(payload) > maxResponseSize {
+			handlerErr = NewRPCError(RPCResponseTooLarge, "response of %d bytes exceeds %d bytes", len(payload), maxResponseSize)
+		} else {
+			frame.code = RPCSuccess
+			frame.name = proto.MessageName(resp)
+			frame.payload = payload
+		}
+	}
+	if handlerErr != nil {
+		rpcErr, ok := handlerErr.(*RPCError)
+		if !ok {
+			rpcErr = NewRPCError(RPCServerError, "%v", handlerErr)
+		}
+		log.WithFields(logrus.Fields{
+			"peer": peer.ID.Pretty(),
+			"code": rpcErr.Code,
+		}).Debugf("Responding to request with error: %s", rpcErr.Message)
+		frame.code = rpcErr.Code
+		frame.payload = []byte(rpcErr.Message)
+	}
+
+	if err := writeFrame(stream, frame); err != nil {
+		log.WithError(err).WithField("peer", peer.ID.Pretty()).Debug("Could not write response")
+	}
+}
+
+// rpcStreamError returns an RPCTimeout error if the stream failed because the deadline of
+// the request passed.
+func rpcStreamError(deadline time.Time, msg string, err error) error {
+	if !time.Now().Before(deadline) {
+		return NewRPCError(RPCTimeout, "%s: %v", msg, err)
+	}
+	return fmt.Errorf("%s: %v", msg, err)
+}
+
+func writeFrame(w io.Writer, frame *rpcFrame) error {
+	buf := make([]byte, 0, 3*binary.MaxVarintLen64+1+len(frame.name)+len(frame.payload))
+	var scratch [binary.MaxVarintLen64]byte
+	buf = append(buf, scratch[:binary.PutUvarint(scratch[:], frame.id)]...)
+	buf = append(buf, byte(frame.code))
+	buf = append(buf, scratch[:binary.PutUvarint(scratch[:], uint64(len(frame.name)))]...)
+	buf = append(buf, frame.name...)
+	buf = append(buf, scratch[:binary.PutUvarint(scratch[:], uint64(len(frame.payload)))]...)
+	buf = append(buf, frame.payload...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readFrame reads a frame whose payload is at most maxPayloadSize bytes. If the payload is
+// larger, it returns the frame read so far along with an RPCRequestTooLarge error.
+func readFrame(r *bufio.Reader, maxPayloadSize uint64) (*rpcFrame, error) {
+	frame := &rpcFrame{}
+	var err error
+	if frame.id, err = binary.ReadUvarint(r); err != nil {
+		return nil, err
+	}
+	code, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	frame.code = RPCErrorCode(code)
+
+	nameLength, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if nameLength > maxMessageNameLength {
+		return nil, errors.New("message name too long")
+	}
+	name := make([]byte, nameLength)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return nil, err
+	}
+	frame.name = string(name)
+
+	payloadLength, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if payloadLength > maxPayloadSize {
+		return frame, NewRPCError(RPCRequestTooLarge, "payload of %d bytes exceeds %d bytes", payloadLength, maxPayloadSize)
+	}
+	frame.payload = make([]byte, payloadLength)
+	if _, err := io.ReadFull(r, frame.payload); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
@@ -0,0 +1,167 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	bhost "github.com/libp2p/go-libp2p-blankhost"
+	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	shardpb "github.com/prysmaticlabs/prysm/proto/sharding/p2p/v1"
+)
+
+// connectedServers returns a requesting server connected to a responding server.
+func connectedServers(ctx context.Context, t *testing.T) (*Server, *Server) {
+	var servers []*Server
+	for i := 0; i < 2; i++ {
+		servers = append(servers, &Server{
+			ctx:          ctx,
+			host:         bhost.NewBlankHost(swarmt.GenSwarm(t, ctx)),
+			feeds:        make(map[reflect.Type]Feed),
+			mutex:        &sync.Mutex{},
+			topicMapping: make(map[reflect.Type]string),
+		})
+	}
+	requester, responder := servers[0], servers[1]
+	if err := requester.host.Connect(ctx, responder.host.Peerstore().PeerInfo(responder.host.ID())); err != nil {
+		t.Fatalf("Could not connect to host for test setup: %v", err)
+	}
+	return requester, responder
+}
+
+func expectRPCError(t *testing.T, err error, code RPCErrorCode) {
+	rpcErr, ok := err.(*RPCError)
+	if !ok {
+		t.Fatalf("Expected RPC error with code %q, received %v", code, err)
+	}
+	if rpcErr.Code != code {
+		t.Errorf("Expected RPC error with code %q, received %q", code, rpcErr.Code)
+	}
+}
+
+func TestRequest_OK(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	requester, responder := connectedServers(ctx, t)
+
+	responder.RegisterRequestHandler(&shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		if msg.Peer.ID != requester.host.ID() {
+			t.Errorf("Unexpected request sender: %s. Wanted %s.", msg.Peer.ID.Pretty(), requester.host.ID().Pretty())
+		}
+		req := msg.Data.(*shardpb.CollationBodyRequest)
+		return &shardpb.CollationBodyResponse{HeaderHash: req.ChunkRoot, Body: []byte("body")}, nil
+	})
+
+	resp := &shardpb.CollationBodyResponse{}
+	if err := requester.Request(ctx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{ChunkRoot: []byte("root")}, resp); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	want := &shardpb.CollationBodyResponse{HeaderHash: []byte("root"), Body: []byte("body")}
+	if !proto.Equal(resp, want) {
+		t.Errorf("Unexpected response: %+v. Wanted %+v.", resp, want)
+	}
+}
+
+func TestRequest_UnknownRequest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	requester, responder := connectedServers(ctx, t)
+
+	responder.RegisterRequestHandler(&shardpb.CollationBodyResponse{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		return msg.Data, nil
+	})
+
+	err := requester.Request(ctx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{})
+	expectRPCError(t, err, RPCUnknownRequest)
+}
+
+func TestRequest_UnexpectedResponseType(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	requester, responder := connectedServers(ctx, t)
+
+	responder.RegisterRequestHandler(&shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		return &shardpb.CollationBodyResponse{Body: []byte("body")}, nil
+	})
+
+	// The response does not decode as the type expected by the requester.
+	err := requester.Request(ctx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{}, &shardpb.CollationBodyRequest{})
+	expectRPCError(t, err, RPCInvalidResponse)
+
+	// The handler responds with a type other than the one it was registered with.
+	responder.RegisterRequestHandler(&shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		return msg.Data, nil
+	})
+	err = requester.Request(ctx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{})
+	expectRPCError(t, err, RPCServerError)
+}
+
+func TestRequest_HandlerErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	requester, responder := connectedServers(ctx, t)
+
+	var handlerErr error
+	responder.RegisterRequestHandler(&shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		return nil, handlerErr
+	})
+
+	tests := []struct {
+		handlerErr error
+		code       RPCErrorCode
+	}{
+		{handlerErr: NewRPCError(RPCResourceUnavailable, "no such collation"), code: RPCResourceUnavailable},
+		{handlerErr: errors.New("database failure"), code: RPCServerError},
+		// A handler returning neither a response nor an error.
+		{handlerErr: nil, code: RPCServerError},
+	}
+	for _, tt := range tests {
+		handlerErr = tt.handlerErr
+		err := requester.Request(ctx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{})
+		expectRPCError(t, err, tt.code)
+	}
+}
+
+func TestRequest_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	requester, responder := connectedServers(ctx, t)
+
+	responder.RegisterRequestHandler(&shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{}, func(msg Message) (proto.Message, error) {
+		<-msg.Ctx.Done()
+		return nil, msg.Ctx.Err()
+	})
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer reqCancel()
+	err := requester.Request(reqCtx, Peer{ID: responder.host.ID()}, &shardpb.CollationBodyRequest{}, &shardpb.CollationBodyResponse{})
+	expectRPCError(t, err, RPCTimeout)
+}
+
+func TestReadFrame_PayloadTooLarge(t *testing.T) {
+	buf := new(bytes.Buffer)
+	frame := &rpcFrame{id: 7, name: "ethereum.sharding.p2p.v1.CollationBodyRequest", payload: make([]byte, 101)}
+	if err := writeFrame(buf, frame); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := readFrame(bufio.NewReader(bytes.NewReader(buf.Bytes())), 100)
+	expectRPCError(t, err, RPCRequestTooLarge)
+	if read.id != frame.id {
+		t.Errorf("Expected the request ID %d to be read, received %d", frame.id, read.id)
+	}
+
+	read, err = readFrame(bufio.NewReader(bytes.NewReader(buf.Bytes())), 101)
+	if err != nil {
+		t.Fatalf("Could not read frame: %v", err)
+	}
+	if !reflect.DeepEqual(read, frame) {
+		t.Errorf("Unexpected frame: %+v. Wanted %+v.", read, frame)
+	}
+}
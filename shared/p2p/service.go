@@ -14,6 +14,7 @@ import (
 	libp2p "github.com/libp2p/go-libp2p"
 	host "github.com/libp2p/go-libp2p-host"
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
+	peer "github.com/libp2p/go-libp2p-peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -25,7 +26,7 @@ import (
 // Sender represents a struct that is able to relay information via p2p.
 // Server implements this interface.
 type Sender interface {
-	Send(msg interface{}, peer Peer)
+	Send(msg proto.Message, peer Peer)
 }
 
 // Server is a placeholder for a p2p service. To be designed.
@@ -40,6 +41,15 @@ type Server struct {
 	topicMapping  map[reflect.Type]string
 	bootstrapNode string
 	relayNodeAddr string
+	// The handlers of the requests received from peers, by request message name.
+	requestHandlers map[string]requestHandler
+	lastRequestID   uint64
+	scorer          *peerScorer
+	// The validators of the messages received on topics, by topic.
+	validators map[string]Validator
+	// The queues of the messages waiting to be sent to peers, by peer.
+	sendQueues     map[peer.ID]chan outboundMessage
+	sendQueuesLock sync.Mutex
 }
 
 // ServerConfig for peer to peer networking.
@@ -77,7 +87,7 @@ func NewServer(cfg *ServerConfig) (*Server, error) {
 		return nil, err
 	}
 
-	return &Server{
+	s := &Server{
 		ctx:           ctx,
 		cancel:        cancel,
 		feeds:         make(map[reflect.Type]Feed),
@@ -88,7 +98,10 @@ func NewServer(cfg *ServerConfig) (*Server, error) {
 		topicMapping:  make(map[reflect.Type]string),
 		bootstrapNode: cfg.BootstrapNodeAddr,
 		relayNodeAddr: cfg.RelayNodeAddr,
-	}, nil
+		scorer:        newPeerScorer(),
+	}
+	h.Network().Notify(s.scoringNotifiee())
+	return s, nil
 }
 
 func checkAvailablePort(port int) bool {
@@ -160,6 +173,9 @@ func (s *Server) Status() error {
 //
 // The topics can originate from multiple sources. In other words, messages on
 // TopicA may come from direct peer communication or a pub/sub channel.
+//
+// Messages can be checked before they reach the adapters by registering a
+// validator for the message type with RegisterValidator.
 func (s *Server) RegisterTopic(topic string, message proto.Message, adapters ...Adapter) {
 	log.WithFields(logrus.Fields{
 		"topic": topic,
@@ -181,6 +197,21 @@ func (s *Server) RegisterTopic(topic string, message proto.Message, adapters ...
 		adapters[i], adapters[opp] = adapters[opp], adapters[i]
 	}
 
+	handle := func(pMsg Message) {
+		var h Handler = func(pMsg Message) {
+			s.emit(pMsg, feed)
+		}
+
+		for _, adapter := range adapters {
+			h = adapter(h)
+		}
+
+		h(pMsg)
+	}
+
+	// Messages of the topic sent directly to this node go through the same adapters.
+	s.host.SetStreamHandler(topicProtocol(topic), s.topicStreamHandler(topic, msgType, handle))
+
 	go func() {
 		defer sub.Cancel()
 		for {
@@ -195,26 +226,36 @@ func (s *Server) RegisterTopic(topic string, message proto.Message, adapters ...
 				return
 			}
 
+			sender := messageSender(msg)
+			if s.isBanned(sender.ID) {
+				continue
+			}
+
 			d := message
 			if err := proto.Unmarshal(msg.Data, d); err != nil {
 				log.WithError(err).Error("Failed to decode data")
+				s.ReportPeer(sender, InvalidMessage)
 				continue
 			}
 
-			var h Handler = func(pMsg Message) {
-				s.emit(pMsg, feed)
-			}
-
-			pMsg := Message{Ctx: s.ctx, Data: d}
-			for _, adapter := range adapters {
-				h = adapter(h)
-			}
-
-			h(pMsg)
+			handle(Message{Ctx: s.ctx, Peer: sender, Data: d})
 		}
 	}()
 }
 
+// messageSender returns the peer which claims to have published the gossiped message.
+// Gossipsub does not tell which connected peer relayed the message, and the publisher in
+// the message is not authenticated, so the returned peer is only used to address replies
+// and is never scored.
+func messageSender(msg *pubsub.Message) Peer {
+	from, err := peer.IDFromBytes(msg.From)
+	if err != nil {
+		log.WithError(err).Debug("Could not decode message sender")
+		return Peer{}
+	}
+	return Peer{ID: from, claimed: true}
+}
+
 func (s *Server) emit(msg Message, feed Feed) {
 	i := feed.Send(msg)
 	log.WithFields(logrus.Fields{
@@ -229,15 +270,27 @@ func (s *Server) Subscribe(msg proto.Message, channel chan Message) event.Subscr
 	return s.Feed(msg).Subscribe(channel)
 }
 
-// Send a message to a specific peer.
+// Send a message to a specific peer, over a direct libp2p stream for the protocol of the
+// message's topic. The message is broadcast to all peers if no peer is given.
+//
+// Send does not wait for the message to be delivered: the message is added to the send
+// queue of the peer, whose messages are sent in the background one at a time. It logs an
+// error if the topic of the message is unknown, if the send queue of the peer is full, or
+// if the message could not be delivered to the peer.
 func (s *Server) Send(msg proto.Message, peer Peer) {
-	// TODO(#175)
-	// https://github.com/prysmaticlabs/prysm/issues/175
+	if peer.ID == "" {
+		log.Debug("No peer to send to, broadcasting to everyone")
+		s.Broadcast(msg)
+		return
+	}
+
+	topic := s.topicMapping[messageType(msg)]
+	if topic == "" {
+		log.Errorf("Topic is unknown for message type %T, not sending to peer", msg)
+		return
+	}
 
-	// TODO(#175): Remove debug log after send is implemented.
-	_ = peer
-	log.Debug("Broadcasting to everyone rather than sending a single peer")
-	s.Broadcast(msg)
+	s.enqueueSend(peer.ID, outboundMessage{msg: msg, topic: topic})
 }
 
 // Broadcast publishes a message to all localized peers using gossipsub.
@@ -171,6 +171,9 @@ func testSubscribe(ctx context.Context, t *testing.T, s Server, gsub *pubsub.Pub
 		if !proto.Equal(msg.Data.(proto.Message), pbMsg) {
 			t.Errorf("Unexpected msg: %+v. Wanted %+v.", msg.Data, pbMsg)
 		}
+		if msg.Peer.ID != s.host.ID() {
+			t.Errorf("Unexpected msg sender: %s. Wanted %s.", msg.Peer.ID.Pretty(), s.host.ID().Pretty())
+		}
 
 		done <- true
 	}()
@@ -191,6 +194,130 @@ func testSubscribe(ctx context.Context, t *testing.T, s Server, gsub *pubsub.Pub
 	}
 }
 
+func TestSend_OK(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	topic := shardpb.Topic_COLLATION_BODY_REQUEST
+
+	var servers []*Server
+	for i := 0; i < 3; i++ {
+		h := bhost.NewBlankHost(swarmt.GenSwarm(t, ctx))
+		gsub, err := pubsub.NewFloodSub(ctx, h)
+		if err != nil {
+			t.Fatalf("Failed to create pubsub: %v", err)
+		}
+		s := &Server{
+			ctx:          ctx,
+			gsub:         gsub,
+			host:         h,
+			feeds:        make(map[reflect.Type]Feed),
+			mutex:        &sync.Mutex{},
+			topicMapping: make(map[reflect.Type]string),
+		}
+		s.RegisterTopic(topic.String(), &shardpb.CollationBodyRequest{})
+		servers = append(servers, s)
+	}
+	sender, receiver, other := servers[0], servers[1], servers[2]
+	for _, s := range []*Server{receiver, other} {
+		if err := sender.host.Connect(ctx, s.host.Peerstore().PeerInfo(s.host.ID())); err != nil {
+			t.Fatalf("Could not connect to host for test setup: %v", err)
+		}
+	}
+
+	ch := make(chan Message, 1)
+	sub := receiver.Subscribe(&shardpb.CollationBodyRequest{}, ch)
+	defer sub.Unsubscribe()
+	otherCh := make(chan Message, 1)
+	otherSub := other.Subscribe(&shardpb.CollationBodyRequest{}, otherCh)
+	defer otherSub.Unsubscribe()
+
+	pbMsg := &shardpb.CollationBodyRequest{ShardId: 5}
+	sender.Send(pbMsg, Peer{ID: receiver.host.ID()})
+
+	select {
+	case msg := <-ch:
+		if !proto.Equal(msg.Data, pbMsg) {
+			t.Errorf("Unexpected msg: %+v. Wanted %+v.", msg.Data, pbMsg)
+		}
+		if msg.Peer.ID != sender.host.ID() {
+			t.Errorf("Unexpected msg sender: %s. Wanted %s.", msg.Peer.ID.Pretty(), sender.host.ID().Pretty())
+		}
+	case <-ctx.Done():
+		t.Fatal("Context timed out before a message was received!")
+	}
+
+	// Only the addressed peer receives the message.
+	select {
+	case msg := <-otherCh:
+		t.Errorf("Unexpected msg received by another peer: %+v", msg.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSend_QueuesMessagesPerPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Second)
+	defer cancel()
+	topic := shardpb.Topic_COLLATION_BODY_REQUEST
+
+	var servers []*Server
+	for i := 0; i < 2; i++ {
+		h := bhost.NewBlankHost(swarmt.GenSwarm(t, ctx))
+		gsub, err := pubsub.NewFloodSub(ctx, h)
+		if err != nil {
+			t.Fatalf("Failed to create pubsub: %v", err)
+		}
+		s := &Server{
+			ctx:          ctx,
+			gsub:         gsub,
+			host:         h,
+			feeds:        make(map[reflect.Type]Feed),
+			mutex:        &sync.Mutex{},
+			topicMapping: make(map[reflect.Type]string),
+		}
+		s.RegisterTopic(topic.String(), &shardpb.CollationBodyRequest{})
+		servers = append(servers, s)
+	}
+	sender, receiver := servers[0], servers[1]
+	if err := sender.host.Connect(ctx, receiver.host.Peerstore().PeerInfo(receiver.host.ID())); err != nil {
+		t.Fatalf("Could not connect to host for test setup: %v", err)
+	}
+
+	ch := make(chan Message, sendQueueSize)
+	sub := receiver.Subscribe(&shardpb.CollationBodyRequest{}, ch)
+	defer sub.Unsubscribe()
+
+	// Send only queues the messages, which are delivered in the background.
+	const count = 10
+	for i := uint64(0); i < count; i++ {
+		sender.Send(&shardpb.CollationBodyRequest{ShardId: i}, Peer{ID: receiver.host.ID()})
+	}
+
+	received := make(map[uint64]bool)
+	for len(received) < count {
+		select {
+		case msg := <-ch:
+			received[msg.Data.(*shardpb.CollationBodyRequest).ShardId] = true
+		case <-ctx.Done():
+			t.Fatalf("Context timed out after receiving %d of %d messages", len(received), count)
+		}
+	}
+
+	// The goroutine sending to the peer exits once its queue is empty.
+	for {
+		sender.sendQueuesLock.Lock()
+		queues := len(sender.sendQueues)
+		sender.sendQueuesLock.Unlock()
+		if queues == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("Expected the send queue of the peer to be removed once empty")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
 func TestRegisterTopic_InvalidProtobufs(t *testing.T) {
 	topic := shardpb.Topic_COLLATION_BODY_REQUEST
 	hook := logTest.NewGlobal()
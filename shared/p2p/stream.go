@@ -0,0 +1,144 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	net "github.com/libp2p/go-libp2p-net"
+	peer "github.com/libp2p/go-libp2p-peer"
+	protocol "github.com/libp2p/go-libp2p-protocol"
+	"github.com/sirupsen/logrus"
+)
+
+// topicProtocolPrefix prefixes the topic of a message in the ID of the libp2p protocol
+// used to send the message directly to a peer.
+const topicProtocolPrefix = "/prysm/topic/"
+
+// maxStreamMessageSize is the maximum size of a message received over a stream, large
+// enough for batched block responses and states.
+const maxStreamMessageSize = 32 << 20
+
+// sendTimeout bounds the time taken to open a stream to a peer and write a message to it.
+var sendTimeout = 10 * time.Second
+
+// sendQueueSize is the number of messages waiting to be sent to a peer. The messages sent
+// to a peer whose queue is full are dropped.
+const sendQueueSize = 64
+
+// outboundMessage is a message waiting in the send queue of a peer.
+type outboundMessage struct {
+	msg   proto.Message
+	topic string
+}
+
+// topicProtocol returns the ID of the protocol used to send the messages of a topic.
+func topicProtocol(topic string) protocol.ID {
+	return protocol.ID(topicProtocolPrefix + topic)
+}
+
+// enqueueSend adds the message to the send queue of the peer, and starts the goroutine
+// sending the queued messages if the peer has none. It never blocks on the network.
+func (s *Server) enqueueSend(id peer.ID, m outboundMessage) {
+	s.sendQueuesLock.Lock()
+	defer s.sendQueuesLock.Unlock()
+	if s.sendQueues == nil {
+		s.sendQueues = make(map[peer.ID]chan outboundMessage)
+	}
+	queue, ok := s.sendQueues[id]
+	if !ok {
+		queue = make(chan outboundMessage, sendQueueSize)
+		s.sendQueues[id] = queue
+		go s.sendQueued(id, queue)
+	}
+	select {
+	case queue <- m:
+	default:
+		log.WithFields(logrus.Fields{
+			"peer":  id.Pretty(),
+			"topic": m.topic,
+		}).Warn("Send queue of peer is full, dropping message")
+	}
+}
+
+// sendQueued sends the queued messages to the peer one at a time, and exits once the queue
+// is empty.
+func (s *Server) sendQueued(id peer.ID, queue chan outboundMessage) {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case m := <-queue:
+			ctx, cancel := context.WithTimeout(s.ctx, sendTimeout)
+			if err := s.sendToPeer(ctx, m.msg, id, m.topic); err != nil {
+				log.WithFields(logrus.Fields{
+					"peer":  id.Pretty(),
+					"topic": m.topic,
+				}).Errorf("Failed to send message to peer: %v", err)
+			}
+			cancel()
+		default:
+			// Messages are only queued with the lock held, so the queue is still empty
+			// once it is removed.
+			s.sendQueuesLock.Lock()
+			if len(queue) == 0 {
+				delete(s.sendQueues, id)
+				s.sendQueuesLock.Unlock()
+				return
+			}
+			s.sendQueuesLock.Unlock()
+		}
+	}
+}
+
+// sendToPeer opens a stream to the peer for the protocol of the topic, and writes the
+// length-prefixed message to it.
+func (s *Server) sendToPeer(ctx context.Context, msg proto.Message, id peer.ID, topic string) error {
+	stream, err := s.host.NewStream(ctx, id, topicProtocol(topic))
+	if err != nil {
+		return fmt.Errorf("could not open stream: %v", err)
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetWriteDeadline(deadline); err != nil {
+			return fmt.Errorf("could not set write deadline: %v", err)
+		}
+	}
+	if err := ggio.NewDelimitedWriter(stream).WriteMsg(msg); err != nil {
+		return fmt.Errorf("could not write message: %v", err)
+	}
+	return nil
+}
+
+// topicStreamHandler returns the handler of the streams opened by peers for the protocol of
+// a topic. It reads the message sent over the stream and passes it to handle, along with
+// the peer which sent it, if it passes the validator of the topic.
+func (s *Server) topicStreamHandler(topic string, msgType reflect.Type, handle Handler) net.StreamHandler {
+	return func(stream net.Stream) {
+		defer stream.Close()
+		sender := Peer{ID: stream.Conn().RemotePeer()}
+		if s.isBanned(sender.ID) {
+			return
+		}
+
+		msg := reflect.New(msgType).Interface().(proto.Message)
+		if err := ggio.NewDelimitedReader(stream, maxStreamMessageSize).ReadMsg(msg); err != nil {
+			log.WithError(err).Error("Failed to decode stream data")
+			s.ReportPeer(sender, InvalidMessage)
+			return
+		}
+		pMsg := Message{
+			Ctx:  s.ctx,
+			Peer: sender,
+			Data: msg,
+		}
+		if validator := s.validator(topic); validator != nil && !s.validate(topic, pMsg, validator) {
+			return
+		}
+		handle(pMsg)
+	}
+}
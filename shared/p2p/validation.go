@@ -0,0 +1,83 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterValidator registers the validator of the messages of the given type, whose topic
+// must have been registered with RegisterTopic.
+//
+// The validator runs before the adapter stack of the topic. Gossiped messages failing
+// validation are not propagated to other peers, and neither gossiped messages nor messages
+// sent directly to this node reach the adapters and the feed of the topic if they fail
+// validation. The sender of an invalid message is reported for it.
+//
+// Validators run concurrently and must be cheap, such as checking the signature or the
+// slot range of a message.
+func (s *Server) RegisterValidator(message proto.Message, validator Validator) error {
+	msgType := messageType(message)
+	topic, ok := s.topicMapping[msgType]
+	if !ok {
+		return fmt.Errorf("topic is unknown for message type %T", message)
+	}
+
+	s.mutex.Lock()
+	if s.validators == nil {
+		s.validators = make(map[string]Validator)
+	}
+	if _, ok := s.validators[topic]; ok {
+		s.mutex.Unlock()
+		return fmt.Errorf("validator already registered for topic %s", topic)
+	}
+	s.validators[topic] = validator
+	s.mutex.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"topic": topic,
+	}).Debug("Registering topic validator")
+
+	return s.gsub.RegisterTopicValidator(topic, func(ctx context.Context, msg *pubsub.Message) bool {
+		sender := messageSender(msg)
+		if s.isBanned(sender.ID) {
+			return false
+		}
+		data := reflect.New(msgType).Interface().(proto.Message)
+		if err := proto.Unmarshal(msg.Data, data); err != nil {
+			log.WithError(err).Error("Failed to decode data")
+			s.ReportPeer(sender, InvalidMessage)
+			return false
+		}
+		return s.validate(topic, Message{Ctx: ctx, Peer: sender, Data: data}, validator)
+	})
+}
+
+// validator returns the validator of the topic, if any.
+func (s *Server) validator(topic string) Validator {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.validators[topic]
+}
+
+// validate runs the validator on the message, and reports the sender of an invalid message.
+func (s *Server) validate(topic string, msg Message, validator Validator) bool {
+	if validator(msg) {
+		return true
+	}
+
+	log.WithFields(logrus.Fields{
+		"topic": topic,
+		"peer":  msg.Peer.ID.Pretty(),
+	}).Debug("Dropping invalid message")
+	invalidMessagesMetric.WithLabelValues(topic).Inc()
+	// Messages published by this node are validated too.
+	if msg.Peer.ID != s.host.ID() {
+		s.ReportPeer(msg.Peer, InvalidMessage)
+	}
+	return false
+}
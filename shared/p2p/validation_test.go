@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	bhost "github.com/libp2p/go-libp2p-blankhost"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	shardpb "github.com/prysmaticlabs/prysm/proto/sharding/p2p/v1"
+)
+
+func TestRegisterValidator_UnknownTopic(t *testing.T) {
+	s := &Server{
+		mutex:        &sync.Mutex{},
+		topicMapping: make(map[reflect.Type]string),
+	}
+	if err := s.RegisterValidator(&shardpb.CollationBodyRequest{}, func(Message) bool { return true }); err == nil {
+		t.Error("Expected an error registering a validator for an unregistered topic")
+	}
+}
+
+func TestRegisterValidator_DropsInvalidMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 1*time.Second)
+	defer cancel()
+	topic := shardpb.Topic_COLLATION_BODY_REQUEST
+
+	var servers []*Server
+	for i := 0; i < 3; i++ {
+		h := bhost.NewBlankHost(swarmt.GenSwarm(t, ctx))
+		gsub, err := pubsub.NewFloodSub(ctx, h)
+		if err != nil {
+			t.Fatalf("Failed to create pubsub: %v", err)
+		}
+		s := &Server{
+			ctx:          ctx,
+			gsub:         gsub,
+			host:         h,
+			feeds:        make(map[reflect.Type]Feed),
+			mutex:        &sync.Mutex{},
+			topicMapping: make(map[reflect.Type]string),
+			scorer:       newPeerScorer(),
+		}
+		s.RegisterTopic(topic.String(), &shardpb.CollationBodyRequest{})
+		servers = append(servers, s)
+	}
+	// The validating server relays the messages of the publisher to the last server.
+	publisher, validating, last := servers[0], servers[1], servers[2]
+	for _, s := range []*Server{publisher, last} {
+		if err := validating.host.Connect(ctx, s.host.Peerstore().PeerInfo(s.host.ID())); err != nil {
+			t.Fatalf("Could not connect to host for test setup: %v", err)
+		}
+	}
+	if err := validating.RegisterValidator(&shardpb.CollationBodyRequest{}, func(msg Message) bool {
+		return msg.Data.(*shardpb.CollationBodyRequest).ShardId != 1
+	}); err != nil {
+		t.Fatalf("Could not register validator: %v", err)
+	}
+
+	ch := make(chan Message, 2)
+	sub := validating.Subscribe(&shardpb.CollationBodyRequest{}, ch)
+	defer sub.Unsubscribe()
+	lastCh := make(chan Message, 2)
+	lastSub := last.Subscribe(&shardpb.CollationBodyRequest{}, lastCh)
+	defer lastSub.Unsubscribe()
+
+	// Short delay to let the peers exchange their subscriptions.
+	time.Sleep(50 * time.Millisecond)
+
+	invalid := &shardpb.CollationBodyRequest{ShardId: 1}
+	valid := &shardpb.CollationBodyRequest{ShardId: 5}
+	publisher.Broadcast(invalid)
+	publisher.Send(invalid, Peer{ID: validating.host.ID()})
+	publisher.Broadcast(valid)
+
+	for _, c := range []chan Message{ch, lastCh} {
+		select {
+		case msg := <-c:
+			if msg.Data.(*shardpb.CollationBodyRequest).ShardId != valid.ShardId {
+				t.Errorf("Unexpected msg: %+v. Wanted %+v.", msg.Data, valid)
+			}
+		case <-ctx.Done():
+			t.Fatal("Context timed out before a message was received!")
+		}
+	}
+	select {
+	case msg := <-ch:
+		t.Errorf("Unexpected msg passing validation: %+v", msg.Data)
+	case msg := <-lastCh:
+		t.Errorf("Unexpected msg propagated: %+v", msg.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if score := validating.PeerScore(Peer{ID: publisher.host.ID()}); score >= 0 {
+		t.Errorf("Expected the publisher of invalid messages to be penalized, received score %f", score)
+	}
+}
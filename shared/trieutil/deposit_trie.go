@@ -5,6 +5,7 @@ package trieutil
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -38,6 +39,23 @@ func NewDepositTrie() *DepositTrie {
 	}
 }
 
+// NewDepositTrieFromBranch restores a Merkle trie for deposits from the branch and
+// deposit count of a previously built trie, as returned by Branch and DepositCount.
+func NewDepositTrieFromBranch(branch [][]byte, depositCount uint64) (*DepositTrie, error) {
+	if len(branch) != 32 {
+		return nil, fmt.Errorf("expected a branch of 32 hashes, received %d", len(branch))
+	}
+	d := NewDepositTrie()
+	for i, h := range branch {
+		if len(h) != 32 {
+			return nil, fmt.Errorf("expected branch hash %d to be 32 bytes, received %d", i, len(h))
+		}
+		copy(d.branch[i][:], h)
+	}
+	d.depositCount = depositCount
+	return d, nil
+}
+
 // UpdateDepositTrie updates the Merkle trie representing deposits on
 // the ETH 1.0 PoW chain contract.
 func (d *DepositTrie) UpdateDepositTrie(depositData []byte) {
@@ -82,6 +100,11 @@ func (d *DepositTrie) Root() [32]byte {
 	return root
 }
 
+// DepositCount returns the number of deposits included in the trie.
+func (d *DepositTrie) DepositCount() uint64 {
+	return d.depositCount
+}
+
 // Branch returns the merkle branch of the left most leaf of the trie.
 func (d *DepositTrie) Branch() [][]byte {
 	nBranch := make([][]byte, 32)
@@ -80,3 +80,28 @@ func TestVerifyMerkleBranch_OK(t *testing.T) {
 		t.Error("Expected Merkle branch to verify, received false")
 	}
 }
+
+func TestNewDepositTrieFromBranch_RestoresTrie(t *testing.T) {
+	d := NewDepositTrie()
+	d.UpdateDepositTrie([]byte{1, 2, 3})
+	d.UpdateDepositTrie([]byte{5, 6, 7})
+
+	restored, err := NewDepositTrieFromBranch(d.Branch(), d.DepositCount())
+	if err != nil {
+		t.Fatalf("Could not restore deposit trie: %v", err)
+	}
+	if restored.Root() != d.Root() {
+		t.Errorf("Expected restored root %#x, received %#x", d.Root(), restored.Root())
+	}
+
+	// The restored trie keeps being updated like the original one.
+	d.UpdateDepositTrie([]byte{8, 9, 10})
+	restored.UpdateDepositTrie([]byte{8, 9, 10})
+	if restored.Root() != d.Root() {
+		t.Errorf("Expected root %#x after update, received %#x", d.Root(), restored.Root())
+	}
+
+	if _, err := NewDepositTrieFromBranch(d.Branch()[:31], d.DepositCount()); err == nil {
+		t.Error("Expected a short branch to be rejected")
+	}
+}